@@ -9,11 +9,16 @@ func GetBundleCmd(config *config.Config) *cobra.Command {
 	bundleCmd := &cobra.Command{
 		Use:   "bundle SUBCOMMAND [flags]",
 		Short: "Manage CRC bundles",
-		Long:  "Manage CRC bundles",
+		Long: `Manage CRC bundles
+
+The zstd compression of the generated bundles can be tuned with the
+CRC_BUNDLE_COMPRESSION_LEVEL (1-22) and CRC_BUNDLE_COMPRESSION_THREADS
+environment variables.`,
 		Run: func(cmd *cobra.Command, _ []string) {
 			_ = cmd.Help()
 		},
 	}
 	bundleCmd.AddCommand(getGenerateCmd(config))
+	bundleCmd.AddCommand(getDeltaCmd())
 	return bundleCmd
 }
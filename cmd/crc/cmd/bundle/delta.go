@@ -0,0 +1,43 @@
+package bundle
+
+import (
+	"os"
+
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/bundle"
+	"github.com/spf13/cobra"
+)
+
+func getDeltaCmd() *cobra.Command {
+	var outputDir string
+	deltaCmd := &cobra.Command{
+		Use:   "delta BASE_BUNDLE TARGET_BUNDLE",
+		Short: "Generate a delta bundle between two bundles from the cache",
+		Long: "Generate a delta bundle between two bundles from the cache. The delta bundle only contains the " +
+			"changes of the disk image of TARGET_BUNDLE compared to BASE_BUNDLE. BASE_BUNDLE must be in the cache " +
+			"when the delta bundle is used.",
+		Args: cobra.ExactArgs(2),
+		RunE: func(_ *cobra.Command, args []string) error {
+			return runDelta(args[0], args[1], outputDir)
+		},
+	}
+	deltaCmd.Flags().StringVarP(&outputDir, "output-dir", "o", "", "Directory where the delta bundle is created (defaults to the current directory)")
+	return deltaCmd
+}
+
+func runDelta(baseBundle, targetBundle, outputDir string) error {
+	if outputDir == "" {
+		cwd, err := os.Getwd()
+		if err != nil {
+			return err
+		}
+		outputDir = cwd
+	}
+	bundlePath, err := bundle.GenerateDelta(baseBundle, targetBundle, outputDir)
+	if err != nil {
+		return err
+	}
+	logging.Infof("Delta bundle is generated in %s", bundlePath)
+	logging.Infof("It can be used with 'crc start -b %s' when %s is in the cache", bundlePath, bundle.GetBundleNameWithExtension(baseBundle))
+	return nil
+}
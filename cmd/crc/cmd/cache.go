@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cache"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addOutputFormatFlag(cacheUsageCmd)
+	cacheCmd.AddCommand(cacheUsageCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache SUBCOMMAND [flags]",
+	Short: "Manage the CRC cache",
+	Long:  fmt.Sprintf("Manage the bundles and executables stored in %s and %s", constants.MachineCacheDir, constants.CrcBinDir),
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var cacheUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Display the disk space used by the cache",
+	Long:  "Display the disk space used by each bundle, bundle archive and executable of the cache",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runCacheUsage(os.Stdout, newMachine(), constants.MachineCacheDir, constants.CrcBinDir, outputFormat)
+	},
+}
+
+func runCacheUsage(writer io.Writer, client machine.Client, cacheDir, binDir, outputFormat string) error {
+	items, err := cache.Usage(cacheDir, binDir, machine.InUseBundles(client, config))
+	return render(&cacheUsageResult{
+		Success:   err == nil,
+		Error:     crcErrors.ToSerializableError(err),
+		Items:     items,
+		TotalSize: cache.TotalSize(items),
+	}, writer, outputFormat)
+}
+
+type cacheUsageResult struct {
+	Success   bool                         `json:"success"`
+	Error     *crcErrors.SerializableError `json:"error,omitempty"`
+	Items     []cache.Item                 `json:"items,omitempty"`
+	TotalSize int64                        `json:"totalSize"`
+}
+
+func (s *cacheUsageResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NAME\tKIND\tSIZE\tIN USE"); err != nil {
+		return err
+	}
+	for _, item := range s.Items {
+		inUse := ""
+		if item.InUse {
+			inUse = "yes"
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Name, item.Kind, units.HumanSize(float64(item.Size)), inUse); err != nil {
+			return err
+		}
+	}
+	if _, err := fmt.Fprintf(w, "Total\t\t%s\n", units.HumanSize(float64(s.TotalSize))); err != nil {
+		return err
+	}
+	return w.Flush()
+}
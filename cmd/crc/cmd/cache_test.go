@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlainCacheUsage(t *testing.T) {
+	cacheDir := t.TempDir()
+	binDir := t.TempDir()
+	bundleDir := filepath.Join(cacheDir, "crc_libvirt_4.16.7_amd64")
+	require.NoError(t, os.Mkdir(bundleDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(bundleDir, "crc-bundle-info.json"), make([]byte, 2000), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(binDir, "crc-admin-helper-linux"), make([]byte, 1000), 0600))
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, runCacheUsage(out, fakemachine.NewClient(), cacheDir, binDir, ""))
+	assert.Equal(t, `NAME                      KIND        SIZE  IN USE
+crc_libvirt_4.16.7_amd64  bundle      2kB   yes
+crc-admin-helper-linux    executable  1kB   yes
+Total                                 3kB
+`, out.String())
+}
+
+func TestJSONCacheUsageWithError(t *testing.T) {
+	out := new(bytes.Buffer)
+	cacheFile := filepath.Join(t.TempDir(), "cache")
+	require.NoError(t, os.WriteFile(cacheFile, nil, 0600))
+	assert.NoError(t, runCacheUsage(out, fakemachine.NewClient(), cacheFile, t.TempDir(), jsonFormat))
+	assert.Contains(t, out.String(), `"success": false`)
+}
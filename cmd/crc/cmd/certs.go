@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addOutputFormatFlag(certsStatusCmd)
+	addOutputFormatFlag(certsRenewCmd)
+	certsCmd.AddCommand(certsStatusCmd)
+	certsCmd.AddCommand(certsRenewCmd)
+	rootCmd.AddCommand(certsCmd)
+}
+
+var certsCmd = &cobra.Command{
+	Use:   "certs SUBCOMMAND [flags]",
+	Short: "Manage the certificates of the OpenShift cluster",
+	Long: fmt.Sprintf(`Show and renew the certificates of the OpenShift cluster.
+'crc status' warns about the certificates expiring within '%s' days.`, crcConfig.CertExpiryWarningDays),
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var certsStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the expiry dates of the certificates",
+	Long:  "Show the expiry dates of the kubelet, aggregator, API server and ingress certificates of the running cluster",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runCertsStatus(os.Stdout, newMachine(), outputFormat)
+	},
+}
+
+var certsRenewCmd = &cobra.Command{
+	Use:   "renew",
+	Short: "Renew the certificates",
+	Long:  "Renew the certificates of the running cluster and wait for them to be in use",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runCertsRenew(os.Stdout, newMachine(), outputFormat)
+	},
+}
+
+type certsStatusResult struct {
+	Success      bool                         `json:"success"`
+	Error        *crcErrors.SerializableError `json:"error,omitempty"`
+	Certificates []cluster.CertificateStatus  `json:"certificates,omitempty"`
+}
+
+func runCertsStatus(writer io.Writer, client machine.Client, outputFormat string) error {
+	return render(getCertsStatus(client), writer, outputFormat)
+}
+
+func getCertsStatus(client machine.Client) *certsStatusResult {
+	if err := checkIfMachineMissing(client); err != nil {
+		return &certsStatusResult{Success: false, Error: crcErrors.ToSerializableError(err)}
+	}
+	result, err := client.GetCertificatesStatus()
+	if err != nil {
+		return &certsStatusResult{Success: false, Error: crcErrors.ToSerializableError(err)}
+	}
+	return &certsStatusResult{
+		Success:      true,
+		Certificates: result.Certificates,
+	}
+}
+
+func (s *certsStatusResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tEXPIRES\tSTATUS")
+	for _, cert := range s.Certificates {
+		switch {
+		case cert.NotAfter == nil:
+			fmt.Fprintf(w, "%s\t-\t%s\n", cert.Name, cert.Error)
+		case cert.ExpiresWithin(0):
+			fmt.Fprintf(w, "%s\t%s\tExpired\n", cert.Name, cert.NotAfter.Format(time.RFC3339))
+		default:
+			fmt.Fprintf(w, "%s\t%s\tValid\n", cert.Name, cert.NotAfter.Format(time.RFC3339))
+		}
+	}
+	return w.Flush()
+}
+
+type certsRenewResult struct {
+	Success bool                         `json:"success"`
+	Error   *crcErrors.SerializableError `json:"error,omitempty"`
+}
+
+func runCertsRenew(writer io.Writer, client machine.Client, outputFormat string) error {
+	err := checkIfMachineMissing(client)
+	if err == nil {
+		err = client.RenewCertificates(context.Background())
+	}
+	return render(&certsRenewResult{
+		Success: err == nil,
+		Error:   crcErrors.ToSerializableError(err),
+	}, writer, outputFormat)
+}
+
+func (s *certsRenewResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	_, err := fmt.Fprintln(writer, "Renewed the certificates of the cluster")
+	return err
+}
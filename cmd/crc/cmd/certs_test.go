@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertsStatusPlain(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runCertsStatus(out, fakemachine.NewClient(), ""))
+	assert.Equal(t, `NAME            EXPIRES               STATUS
+kubelet-client  2024-06-01T09:30:00Z  Expired
+ingress         -                     connection refused
+`, out.String())
+}
+
+func TestCertsStatusJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runCertsStatus(out, fakemachine.NewClient(), jsonFormat))
+	assert.JSONEq(t, `{
+  "success": true,
+  "certificates": [
+    {"name": "kubelet-client", "path": "/var/lib/kubelet/pki/kubelet-client-current.pem", "notAfter": "2024-06-01T09:30:00Z"},
+    {"name": "ingress", "error": "connection refused"}
+  ]
+}`, out.String())
+}
+
+func TestCertsStatusError(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.EqualError(t, runCertsStatus(out, fakemachine.NewFailingClient(), ""), "broken")
+}
+
+func TestCertsRenew(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runCertsRenew(out, fakemachine.NewClient(), ""))
+	assert.Equal(t, "Renewed the certificates of the cluster\n", out.String())
+
+	out.Reset()
+	assert.NoError(t, runCertsRenew(out, fakemachine.NewFailingClient(), jsonFormat))
+	assert.JSONEq(t, `{"success": false, "error": "renew certificates failed"}`, out.String())
+}
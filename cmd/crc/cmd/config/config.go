@@ -8,6 +8,8 @@ import (
 	"text/tabwriter"
 
 	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
 	"github.com/spf13/cobra"
 )
 
@@ -52,19 +54,32 @@ func configurableFields(config *config.Config) string {
 	return buf.String()
 }
 
-func GetConfigCmd(config *config.Config) *cobra.Command {
+// envOverridesHelp documents the environment variables overriding the properties
+func envOverridesHelp() string {
+	return fmt.Sprintf(`Each property can be overridden by an environment variable named after it, such as %s
+for '%s'. Environment variables take precedence over the values set with 'crc config set'.`,
+		config.EnvVarName(constants.CrcEnvPrefix, config.CPUs), config.CPUs)
+}
+
+func GetConfigCmd(config *config.Config, profiles *config.Profiles, newMachine func() machine.Client) *cobra.Command {
 	configCmd := &cobra.Command{
 		Use:   "config SUBCOMMAND [flags]",
 		Short: "Modify crc configuration",
 		Long: `Modifies crc configuration properties.
+` + envOverridesHelp() + `
 Properties: ` + "\n\n" + configurableFields(config),
 		Run: func(cmd *cobra.Command, _ []string) {
 			_ = cmd.Help()
 		},
 	}
 	configCmd.AddCommand(configGetCmd(config))
-	configCmd.AddCommand(configSetCmd(config))
-	configCmd.AddCommand(configUnsetCmd(config))
-	configCmd.AddCommand(configViewCmd(config))
+	configCmd.AddCommand(configSetCmd(config, newMachine))
+	configCmd.AddCommand(configUnsetCmd(config, newMachine))
+	configCmd.AddCommand(configViewCmd(config, profiles))
+	configCmd.AddCommand(configProfileCmd(config, profiles))
+	configCmd.AddCommand(configExportCmd(config))
+	configCmd.AddCommand(configImportCmd(config))
+	configCmd.AddCommand(configSchemaCmd(config))
+	configCmd.AddCommand(configValidateCmd(config))
 	return configCmd
 }
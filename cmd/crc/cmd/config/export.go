@@ -0,0 +1,51 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	yamlFormat = "yaml"
+	jsonFormat = "json"
+)
+
+func configExportCmd(config *config.Config) *cobra.Command {
+	var format string
+	exportCmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the crc configuration",
+		Long: `Prints the crc configuration properties which are not set to their default value,
+in a format which can be read by 'crc config import'. Secret properties are omitted.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runConfigExport(config.Export(), format, os.Stdout)
+		},
+	}
+	exportCmd.Flags().StringVarP(&format, "output", "o", yamlFormat, "Output format. One of: yaml, json")
+	return exportCmd
+}
+
+func runConfigExport(values map[string]interface{}, format string, writer io.Writer) error {
+	var out []byte
+	var err error
+	switch format {
+	case yamlFormat:
+		out, err = yaml.Marshal(values)
+	case jsonFormat:
+		out, err = json.MarshalIndent(values, "", "  ")
+		out = append(out, '\n')
+	default:
+		return fmt.Errorf("invalid output format '%s', it must be one of: %s, %s", format, yamlFormat, jsonFormat)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(out)
+	return err
+}
@@ -0,0 +1,43 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func configImportCmd(config *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "import FILE",
+		Short: "Import crc configuration properties from a file",
+		Long: `Sets the crc configuration properties of FILE, which is a YAML or JSON file such as
+the output of 'crc config export'. No property is set when one of them is invalid.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Please provide the file to import")
+			}
+			content, err := os.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			// JSON is a subset of YAML
+			var values map[string]interface{}
+			if err := yaml.Unmarshal(content, &values); err != nil {
+				return fmt.Errorf("cannot parse %s: %w", args[0], err)
+			}
+			messages, err := config.Import(values)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Imported %d configuration properties from %s\n", len(values), args[0])
+			for _, message := range messages {
+				fmt.Println(message)
+			}
+			return nil
+		},
+	}
+}
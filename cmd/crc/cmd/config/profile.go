@@ -0,0 +1,116 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/spf13/cobra"
+)
+
+func configProfileCmd(config *config.Config, profiles *config.Profiles) *cobra.Command {
+	profileCmd := &cobra.Command{
+		Use:   "profile SUBCOMMAND [flags]",
+		Short: "Manage crc configuration profiles",
+		Long: `Manages named sets of crc configuration properties.
+'crc config set' and 'crc config unset' modify the active profile.`,
+		Run: func(cmd *cobra.Command, _ []string) {
+			_ = cmd.Help()
+		},
+	}
+	profileCmd.AddCommand(configProfileCreateCmd(profiles))
+	profileCmd.AddCommand(configProfileUseCmd(config, profiles))
+	profileCmd.AddCommand(configProfileListCmd(profiles))
+	profileCmd.AddCommand(configProfileDeleteCmd(profiles))
+	return profileCmd
+}
+
+func configProfileCreateCmd(profiles *config.Profiles) *cobra.Command {
+	var from string
+	createCmd := &cobra.Command{
+		Use:   "create PROFILE",
+		Short: "Create a configuration profile",
+		Long:  `Creates a configuration profile with the configuration properties of the default profile, or of another profile.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Please provide the name of the profile to create")
+			}
+			if err := profiles.Create(args[0], from); err != nil {
+				return err
+			}
+			fmt.Printf("Created profile '%s', use it with 'crc config profile use %s'\n", args[0], args[0])
+			return nil
+		},
+	}
+	createCmd.Flags().StringVar(&from, "from", "", "Copy the configuration properties of this profile instead of the default profile")
+	return createCmd
+}
+
+func configProfileUseCmd(config *config.Config, profiles *config.Profiles) *cobra.Command {
+	return &cobra.Command{
+		Use:   "use PROFILE",
+		Short: "Switch to another configuration profile",
+		Long:  `Makes PROFILE the active configuration profile.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Please provide the name of the profile to use")
+			}
+			messages, err := config.UseProfile(profiles, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("Switched to profile '%s'\n", args[0])
+			for _, message := range messages {
+				fmt.Println(message)
+			}
+			return nil
+		},
+	}
+}
+
+func configProfileListCmd(profiles *config.Profiles) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the configuration profiles",
+		Long:  `Lists the configuration profiles, the active profile is marked with '*'.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runConfigProfileList(profiles, os.Stdout)
+		},
+	}
+}
+
+func runConfigProfileList(profiles *config.Profiles, writer io.Writer) error {
+	names, err := profiles.List()
+	if err != nil {
+		return err
+	}
+	active := profiles.Active()
+	for _, name := range names {
+		marker := " "
+		if name == active {
+			marker = "*"
+		}
+		fmt.Fprintf(writer, "%s %s\n", marker, name)
+	}
+	return nil
+}
+
+func configProfileDeleteCmd(profiles *config.Profiles) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete PROFILE",
+		Short: "Delete a configuration profile",
+		Long:  `Deletes a configuration profile. The active profile cannot be deleted.`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("Please provide the name of the profile to delete")
+			}
+			if err := profiles.Delete(args[0]); err != nil {
+				return err
+			}
+			fmt.Printf("Deleted profile '%s'\n", args[0])
+			return nil
+		},
+	}
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/spf13/cobra"
+)
+
+func configSchemaCmd(config *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON schema of the crc configuration",
+		Long: `Prints a JSON schema describing the crc configuration properties, which editors
+can use to validate the files read by 'crc config import'.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			out, err := json.MarshalIndent(config.GenerateJSONSchema(), "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(out))
+			return nil
+		},
+	}
+}
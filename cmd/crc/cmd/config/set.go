@@ -5,11 +5,13 @@ import (
 	"fmt"
 
 	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
 	"github.com/crc-org/crc/v2/pkg/crc/telemetry"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
 	"github.com/spf13/cobra"
 )
 
-func configSetCmd(config *config.Config) *cobra.Command {
+func configSetCmd(config *config.Config, newMachine func() machine.Client) *cobra.Command {
 	return &cobra.Command{
 		Use:   "set CONFIG-KEY VALUE",
 		Short: "Set a crc configuration property",
@@ -23,6 +25,7 @@ CONFIG-KEYS: ` + "\n\n" + configurableFields(config),
 			if err != nil {
 				return err
 			}
+			setMessage = applyResources(newMachine, args[0], setMessage)
 
 			telemetry.SetConfigurationKey(cmd.Context(), args[0])
 
@@ -33,3 +36,12 @@ CONFIG-KEYS: ` + "\n\n" + configurableFields(config),
 		},
 	}
 }
+
+// applyResources returns the message telling that key was applied to the
+// running instance when it was, otherwise it returns message
+func applyResources(newMachine func() machine.Client, key, message string) string {
+	if crcstrings.Contains(machine.ApplyChangedResources(newMachine(), []string{key}), key) {
+		return fmt.Sprintf("Successfully applied %s to the running CRC instance", key)
+	}
+	return message
+}
@@ -5,11 +5,12 @@ import (
 	"fmt"
 
 	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
 	"github.com/crc-org/crc/v2/pkg/crc/telemetry"
 	"github.com/spf13/cobra"
 )
 
-func configUnsetCmd(config config.Storage) *cobra.Command {
+func configUnsetCmd(config config.Storage, newMachine func() machine.Client) *cobra.Command {
 	return &cobra.Command{
 		Use:   "unset CONFIG-KEY",
 		Short: "Unset a crc configuration property",
@@ -22,6 +23,7 @@ func configUnsetCmd(config config.Storage) *cobra.Command {
 			if err != nil {
 				return err
 			}
+			unsetMessage = applyResources(newMachine, args[0], unsetMessage)
 
 			telemetry.SetConfigurationKey(cmd.Context(), args[0])
 
@@ -0,0 +1,33 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/spf13/cobra"
+)
+
+func configValidateCmd(config *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Validate the crc configuration",
+		Long: `Checks every configuration property and their combinations against the preset
+and the host, and reports all the problems which would prevent the instance from starting.`,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			return runConfigValidate(config.Validate(), os.Stdout)
+		},
+	}
+}
+
+func runConfigValidate(problems []config.Problem, writer io.Writer) error {
+	if len(problems) == 0 {
+		fmt.Fprintln(writer, "The configuration is valid")
+		return nil
+	}
+	for _, problem := range problems {
+		fmt.Fprintf(writer, "- %s\n", problem.Error())
+	}
+	return fmt.Errorf("the configuration has %d problem(s)", len(problems))
+}
@@ -13,35 +13,48 @@ import (
 )
 
 const (
-	DefaultConfigViewFormat = "- {{.ConfigKey | printf \"%-38s\"}}: {{.ConfigValue}}"
+	DefaultConfigViewFormat       = "- {{.ConfigKey | printf \"%-38s\"}}: {{.ConfigValue}}"
+	DefaultConfigViewSourceFormat = "- {{.ConfigKey | printf \"%-38s\"}} {{.ConfigSource | printf \"%-14s\"}}: {{.ConfigValue}}"
 )
 
 var (
 	configViewFormat string
 	showSecrets      bool
+	showSource       bool
 )
 
 type configViewTemplate struct {
-	ConfigKey   string
-	ConfigValue interface{}
+	ConfigKey    string
+	ConfigValue  interface{}
+	ConfigSource config.Source
 }
 
-func configViewCmd(config config.Storage) *cobra.Command {
+func configViewCmd(cfg *config.Config, profiles *config.Profiles) *cobra.Command {
 	configViewCmd := &cobra.Command{
 		Use:   "view",
 		Short: "Display all assigned crc configuration properties",
 		Long:  `Displays all assigned crc configuration properties and their values.`,
-		RunE: func(_ *cobra.Command, _ []string) error {
-			tmpl, err := determineTemplate(configViewFormat)
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			format := configViewFormat
+			var sources map[string]config.Source
+			if showSource {
+				if !cmd.Flags().Changed("format") {
+					format = DefaultConfigViewSourceFormat
+				}
+				sources = cfg.AllSources()
+			}
+			tmpl, err := determineTemplate(format)
 			if err != nil {
 				return err
 			}
-			return runConfigView(config.AllConfigs(), tmpl, os.Stdout)
+			return runConfigView(profiles.Active(), cfg.AllConfigs(), sources, tmpl, os.Stdout)
 		},
 	}
 	configViewCmd.Flags().StringVar(&configViewFormat, "format", DefaultConfigViewFormat,
 		`Go template format to apply to the configuration file. For more information about Go templates, see: https://golang.org/pkg/text/template/`)
 	configViewCmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show values of secret config properties")
+	configViewCmd.Flags().BoolVar(&showSource, "show-source", false,
+		"Show all config properties and where their value comes from (default, preset-default, file, keyring, secrets-file, flag or env)")
 	return configViewCmd
 }
 
@@ -53,16 +66,27 @@ func determineTemplate(tempFormat string) (*template.Template, error) {
 	return tmpl, nil
 }
 
-func runConfigView(cfg map[string]config.SettingValue, tmpl *template.Template, writer io.Writer) error {
+// runConfigView prints the properties which are not set to their default
+// value, or all of them along with their source when sources is not nil
+func runConfigView(profile string, cfg map[string]config.SettingValue, sources map[string]config.Source, tmpl *template.Template, writer io.Writer) error {
+	// the active profile is only shown when profiles are used
+	if profile != config.DefaultProfile {
+		var buffer bytes.Buffer
+		if err := tmpl.Execute(&buffer, configViewTemplate{ConfigKey: "profile", ConfigValue: profile}); err != nil {
+			return err
+		}
+		fmt.Fprintln(writer, buffer.String())
+	}
+
 	var lines []string
 	for k, v := range cfg {
-		if v.IsDefault {
+		if v.IsDefault && sources == nil {
 			continue
 		}
 		if v.IsSecret && !showSecrets {
 			continue
 		}
-		viewTmplt := configViewTemplate{k, v.AsString()}
+		viewTmplt := configViewTemplate{k, v.AsString(), sources[k]}
 		var buffer bytes.Buffer
 		if err := tmpl.Execute(&buffer, viewTmplt); err != nil {
 			return err
@@ -0,0 +1,30 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigViewSourcesWithPreset(t *testing.T) {
+	cfg := config.New(config.NewEmptyInMemoryStorage(), config.NewEmptyInMemorySecretStorage())
+	config.RegisterSettings(cfg)
+	_, err := cfg.Set(config.Preset, preset.Microshift.String())
+	require.NoError(t, err)
+
+	tmpl, err := determineTemplate(DefaultConfigViewSourceFormat)
+	require.NoError(t, err)
+	var out bytes.Buffer
+	require.NoError(t, runConfigView(config.DefaultProfile, cfg.AllConfigs(), cfg.AllSources(), tmpl, &out))
+
+	assert.Contains(t, out.String(), fmt.Sprintf("- %-38s %-14s: %d\n", config.CPUs, config.SourcePresetDefault, constants.GetDefaultCPUs(preset.Microshift)))
+	assert.Contains(t, out.String(), fmt.Sprintf("- %-38s %-14s: %d\n", config.Memory, config.SourcePresetDefault, constants.GetDefaultMemory(preset.Microshift)))
+	assert.Contains(t, out.String(), fmt.Sprintf("- %-38s %-14s: %s\n", config.Preset, config.SourceFile, preset.Microshift))
+	assert.Contains(t, out.String(), fmt.Sprintf("- %-38s %-14s: %s\n", config.ConsentTelemetry, config.SourceDefault, ""))
+}
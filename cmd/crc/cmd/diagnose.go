@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/crc-org/crc/v2/pkg/crc/diagnose"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+var diagnoseOutput string
+
+func init() {
+	diagnoseCmd.Flags().StringVarP(&diagnoseOutput, "output", "o", "", "Path of the diagnostics tarball (default crc-diagnostics-<timestamp>.tar.gz)")
+	rootCmd.AddCommand(diagnoseCmd)
+}
+
+var diagnoseCmd = &cobra.Command{
+	Use:   "diagnose",
+	Short: "Collect diagnostics in a tarball",
+	Long: `Collect the crc logs, configuration, bundle metadata and preflight results,
+and when the instance is running, the journal, containers, disk usage and state of the cluster.
+Secrets are masked, the tarball can be attached to bug reports.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		outputPath := diagnoseOutput
+		if outputPath == "" {
+			outputPath = fmt.Sprintf("crc-diagnostics-%s.tar.gz", time.Now().Format("20060102-150405"))
+		}
+		return runDiagnose(os.Stdout, newMachine(), outputPath)
+	},
+}
+
+func runDiagnose(writer io.Writer, client machine.Client, outputPath string) error {
+	archive := diagnose.NewArchive(diagnose.Secrets(config)...)
+
+	bundleName, err := client.GetBundleName()
+	if err != nil {
+		logging.Debugf("Cannot get the bundle of the instance: %v", err)
+	}
+	diagnose.CollectHost(archive, config, bundleName)
+	collectVM(archive, client)
+
+	file, err := os.OpenFile(outputPath, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	if err := archive.Write(file); err != nil {
+		return errors.Wrapf(err, "Cannot write %s", outputPath)
+	}
+
+	if collectErrors := archive.Errors(); len(collectErrors) != 0 {
+		fmt.Fprintln(writer, "Some diagnostics could not be collected:")
+		for _, collectError := range collectErrors {
+			fmt.Fprintf(writer, "  %s\n", collectError)
+		}
+	}
+	fmt.Fprintf(writer, "Diagnostics written to %s\n", outputPath)
+	return nil
+}
+
+// collectVM runs the commands over SSH, it does not need the API server
+func collectVM(archive *diagnose.Archive, client machine.Client) {
+	running, err := client.IsRunning()
+	if err != nil {
+		archive.AddError("vm", err)
+		return
+	}
+	if !running {
+		archive.AddError("vm", errors.New("the instance is not running"))
+		return
+	}
+	connectionDetails, err := client.ConnectionDetails()
+	if err != nil {
+		archive.AddError("vm", err)
+		return
+	}
+	runner, err := ssh.CreateRunner(connectionDetails.IP, connectionDetails.SSHPort, connectionDetails.SSHKeys...)
+	if err != nil {
+		archive.AddError("vm", err)
+		return
+	}
+	defer runner.Close()
+	diagnose.CollectVM(archive, runner, client.GetPreset())
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/docker/go-units"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	addOutputFormatFlag(imageListCmd)
+	imageCmd.AddCommand(imageLoadCmd)
+	imageCmd.AddCommand(imageListCmd)
+	rootCmd.AddCommand(imageCmd)
+}
+
+var imageCmd = &cobra.Command{
+	Use:   "image SUBCOMMAND [flags]",
+	Short: "Manage the container images of the instance",
+	Long:  "Load container images from the host into the instance and list the images of the instance",
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var imageLoadCmd = &cobra.Command{
+	Use:   "load IMAGE...",
+	Short: "Load images from the host",
+	Long: `Load images from the podman or docker storage of the host into the container storage
+of the instance, which is used by the cluster. The tags of the images are preserved.`,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) == 0 {
+			return errors.New("Please provide the images to load")
+		}
+		return runImageLoad(os.Stdout, newMachine(), args)
+	},
+}
+
+var imageListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the images of the instance",
+	Long:  "List the images of the container storage of the instance",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runImageList(os.Stdout, newMachine(), outputFormat)
+	},
+}
+
+func runImageLoad(writer io.Writer, client machine.Client, images []string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	if err := client.LoadImages(images); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Loaded %s\n", strings.Join(images, ", "))
+	return err
+}
+
+type imageListResult struct {
+	Success bool                         `json:"success"`
+	Error   *crcErrors.SerializableError `json:"error,omitempty"`
+	Images  []types.Image                `json:"images,omitempty"`
+}
+
+func runImageList(writer io.Writer, client machine.Client, outputFormat string) error {
+	err := checkIfMachineMissing(client)
+	var images []types.Image
+	if err == nil {
+		images, err = client.ListImages()
+	}
+	return render(&imageListResult{
+		Success: err == nil,
+		Error:   crcErrors.ToSerializableError(err),
+		Images:  images,
+	}, writer, outputFormat)
+}
+
+func (s *imageListResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "IMAGE\tID\tSIZE")
+	for _, image := range s.Images {
+		id := image.ID
+		if len(id) > 12 {
+			id = id[:12]
+		}
+		size := units.HumanSize(float64(image.Size))
+		if len(image.Tags) == 0 {
+			fmt.Fprintf(w, "<none>\t%s\t%s\n", id, size)
+		}
+		for _, tag := range image.Tags {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", tag, id, size)
+		}
+	}
+	return w.Flush()
+}
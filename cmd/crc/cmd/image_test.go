@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImageListPlain(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runImageList(out, fakemachine.NewClient(), ""))
+	assert.Equal(t, `IMAGE                ID            SIZE
+quay.io/team/app:v1  7c6d5e4f3a2b  52.43MB
+<none>               2f1e0d9c8b7a  1.024kB
+`, out.String())
+}
+
+func TestImageListJSONError(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runImageList(out, fakemachine.NewFailingClient(), jsonFormat))
+	assert.JSONEq(t, `{"success": false, "error": "list images failed"}`, out.String())
+}
+
+func TestImageLoad(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runImageLoad(out, fakemachine.NewClient(), []string{"quay.io/team/app:v1", "quay.io/team/db:v2"}))
+	assert.Equal(t, "Loaded quay.io/team/app:v1, quay.io/team/db:v2\n", out.String())
+
+	assert.EqualError(t, runImageLoad(out, fakemachine.NewFailingClient(), []string{"quay.io/team/app:v1"}), "load images failed")
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/spf13/cobra"
+)
+
+var (
+	kubeconfigUser string
+	kubeconfigFile string
+)
+
+func init() {
+	kubeconfigExportCmd.Flags().StringVar(&kubeconfigUser, "user", "", "User of the context, kubeadmin or developer (default: kubeadmin)")
+	kubeconfigExportCmd.Flags().StringVar(&kubeconfigFile, "file", "", "File to write the kubeconfig to (default: the standard output)")
+	kubeconfigMergeCmd.Flags().StringVar(&kubeconfigFile, "into", "", "Kubeconfig file to add the contexts to (default: the global kubeconfig file)")
+	kubeconfigRemoveCmd.Flags().StringVar(&kubeconfigFile, "from", "", "Kubeconfig file to remove the contexts from (default: the global kubeconfig file)")
+	kubeconfigCmd.AddCommand(kubeconfigExportCmd)
+	kubeconfigCmd.AddCommand(kubeconfigMergeCmd)
+	kubeconfigCmd.AddCommand(kubeconfigRemoveCmd)
+	rootCmd.AddCommand(kubeconfigCmd)
+}
+
+var kubeconfigCmd = &cobra.Command{
+	Use:   "kubeconfig SUBCOMMAND [flags]",
+	Short: "Manage the kubeconfig contexts of the cluster",
+	Long: fmt.Sprintf(`Export the kubeconfig of the cluster, add its contexts to a kubeconfig file or remove them.
+'crc start' adds the contexts to the global kubeconfig file unless '%s' is disabled.`, crcConfig.KubeconfigAutoMerge),
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var kubeconfigExportCmd = &cobra.Command{
+	Use:   "export [flags]",
+	Short: "Export a kubeconfig file for the cluster",
+	Long:  "Export a standalone kubeconfig file with the context of a user of the cluster",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.New("Too many arguments")
+		}
+		return runKubeconfigExport(os.Stdout, newMachine(), kubeconfigUser, kubeconfigFile)
+	},
+}
+
+var kubeconfigMergeCmd = &cobra.Command{
+	Use:   "merge [flags]",
+	Short: "Add the contexts of the cluster to a kubeconfig file",
+	Long:  "Add the contexts of the cluster to a kubeconfig file, the contexts are updated when they already exist",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.New("Too many arguments")
+		}
+		return runKubeconfigMerge(os.Stdout, newMachine(), kubeconfigFile)
+	},
+}
+
+var kubeconfigRemoveCmd = &cobra.Command{
+	Use:   "remove [flags]",
+	Short: "Remove the contexts of the cluster from a kubeconfig file",
+	Long:  "Remove the clusters, contexts and users of the cluster from a kubeconfig file",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 0 {
+			return errors.New("Too many arguments")
+		}
+		return runKubeconfigRemove(os.Stdout, newMachine(), kubeconfigFile)
+	},
+}
+
+func runKubeconfigExport(writer io.Writer, client machine.Client, username, file string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	kubeconfig, err := client.ExportKubeconfig(username)
+	if err != nil {
+		return err
+	}
+	if file == "" {
+		_, err := writer.Write(kubeconfig)
+		return err
+	}
+	if err := os.WriteFile(file, kubeconfig, 0600); err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "Kubeconfig written to %s\n", file)
+	return err
+}
+
+func runKubeconfigMerge(writer io.Writer, client machine.Client, file string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	if err := client.MergeKubeconfig(file); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Added the contexts of the cluster to %s\n", kubeconfigName(file))
+	return err
+}
+
+func runKubeconfigRemove(writer io.Writer, client machine.Client, file string) error {
+	if err := client.RemoveKubeconfig(file); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Removed the contexts of the cluster from %s\n", kubeconfigName(file))
+	return err
+}
+
+func kubeconfigName(file string) string {
+	if file == "" {
+		return "the global kubeconfig file"
+	}
+	return file
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeconfigExport(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runKubeconfigExport(out, fakemachine.NewClient(), "developer", ""))
+	assert.Equal(t, "apiVersion: v1\nkind: Config\ncurrent-context: developer\n", out.String())
+
+	out.Reset()
+	file := filepath.Join(t.TempDir(), "kubeconfig")
+	assert.NoError(t, runKubeconfigExport(out, fakemachine.NewClient(), "", file))
+	assert.Equal(t, "Kubeconfig written to "+file+"\n", out.String())
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.Equal(t, "apiVersion: v1\nkind: Config\ncurrent-context: kubeadmin\n", string(content))
+
+	assert.EqualError(t, runKubeconfigExport(out, fakemachine.NewFailingClient(), "", ""), "export kubeconfig failed")
+}
+
+func TestKubeconfigMergeAndRemove(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runKubeconfigMerge(out, fakemachine.NewClient(), "/tmp/project.kubeconfig"))
+	assert.NoError(t, runKubeconfigRemove(out, fakemachine.NewClient(), ""))
+	assert.Equal(t, "Added the contexts of the cluster to /tmp/project.kubeconfig\nRemoved the contexts of the cluster from the global kubeconfig file\n", out.String())
+
+	assert.EqualError(t, runKubeconfigMerge(out, fakemachine.NewFailingClient(), ""), "merge kubeconfig failed")
+}
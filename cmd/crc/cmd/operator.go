@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/spf13/cobra"
+)
+
+var (
+	operatorChannel   string
+	operatorNamespace string
+)
+
+func init() {
+	operatorInstallCmd.Flags().StringVar(&operatorChannel, "channel", "", "Channel to subscribe to (default: the default channel of the package)")
+	operatorInstallCmd.Flags().StringVar(&operatorNamespace, "namespace", crcConfig.DefaultOperatorsNamespace, "Namespace to install the operator in")
+	operatorCmd.AddCommand(operatorInstallCmd)
+	rootCmd.AddCommand(operatorCmd)
+}
+
+var operatorCmd = &cobra.Command{
+	Use:   "operator SUBCOMMAND [flags]",
+	Short: "Manage the OLM operators of the OpenShift cluster",
+	Long: fmt.Sprintf(`Manage the OLM operators of the OpenShift cluster.
+The operators listed in the '%s' setting are installed by 'crc start'.`, crcConfig.Operators),
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var operatorInstallCmd = &cobra.Command{
+	Use:   "install PACKAGE [flags]",
+	Short: "Install an operator",
+	Long:  "Subscribe to an operator of the cluster catalogs and wait for it to be installed",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("Please provide the name of the operator package")
+		}
+		return runOperatorInstall(os.Stdout, newMachine(), crcConfig.Operator{
+			Package:   args[0],
+			Channel:   operatorChannel,
+			Namespace: operatorNamespace,
+		})
+	},
+}
+
+func runOperatorInstall(writer io.Writer, client machine.Client, operator crcConfig.Operator) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	csv, err := client.InstallOperator(context.Background(), operator)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "Operator %s is installed in namespace %s (%s)\n", operator.Package, operator.Namespace, csv)
+	return err
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOperatorInstall(t *testing.T) {
+	operator := crcConfig.Operator{Package: "serverless-operator", Namespace: crcConfig.DefaultOperatorsNamespace}
+	out := new(bytes.Buffer)
+	assert.NoError(t, runOperatorInstall(out, fakemachine.NewClient(), operator))
+	assert.Equal(t, "Operator serverless-operator is installed in namespace openshift-operators (serverless-operator.v1.0.0)\n", out.String())
+
+	assert.EqualError(t, runOperatorInstall(out, fakemachine.NewFailingClient(), operator), "install operator failed")
+}
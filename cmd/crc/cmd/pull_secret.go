@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/spf13/cobra"
+)
+
+var (
+	registryUsername string
+	registryPassword string
+)
+
+func init() {
+	addOutputFormatFlag(pullSecretShowCmd)
+	pullSecretAddRegistryCmd.Flags().StringVar(&registryUsername, "username", "", "Username to log in to the registry")
+	pullSecretAddRegistryCmd.Flags().StringVar(&registryPassword, "password", "", "Password to log in to the registry, read from the standard input when it is not provided")
+	_ = pullSecretAddRegistryCmd.MarkFlagRequired("username")
+	pullSecretCmd.AddCommand(pullSecretShowCmd)
+	pullSecretCmd.AddCommand(pullSecretAddRegistryCmd)
+	pullSecretCmd.AddCommand(pullSecretRemoveRegistryCmd)
+	rootCmd.AddCommand(pullSecretCmd)
+}
+
+var pullSecretCmd = &cobra.Command{
+	Use:   "pull-secret SUBCOMMAND [flags]",
+	Short: "Manage the registries of the pull secret",
+	Long: `Show the registries of the pull secret, add or remove registry credentials.
+The pull secret stored by crc and the pull secret of the running cluster are both updated.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var pullSecretShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the registries of the pull secret",
+	Long:  "Show the registries of the pull secret and their usernames, the tokens are not shown",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runPullSecretShow(os.Stdout, newMachine(), outputFormat)
+	},
+}
+
+var pullSecretAddRegistryCmd = &cobra.Command{
+	Use:   "add-registry HOST --username USERNAME [--password PASSWORD]",
+	Short: "Add the credentials of a registry to the pull secret",
+	Long:  "Add the credentials of a registry to the pull secret, the existing credentials of the registry are replaced",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("Please provide the host of the registry")
+		}
+		password := registryPassword
+		if password == "" {
+			var err error
+			password, err = readSecret(fmt.Sprintf("the password of %s", args[0]))
+			if err != nil {
+				return err
+			}
+		}
+		return runPullSecretAddRegistry(os.Stdout, newMachine(), args[0], registryUsername, password)
+	},
+}
+
+var pullSecretRemoveRegistryCmd = &cobra.Command{
+	Use:   "remove-registry HOST",
+	Short: "Remove the credentials of a registry from the pull secret",
+	Long:  "Remove the credentials of a registry from the pull secret",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("Please provide the host of the registry")
+		}
+		return runPullSecretRemoveRegistry(os.Stdout, newMachine(), args[0])
+	},
+}
+
+type pullSecretShowResult struct {
+	Success    bool                         `json:"success"`
+	Error      *crcErrors.SerializableError `json:"error,omitempty"`
+	Registries []cluster.PullSecretRegistry `json:"registries,omitempty"`
+}
+
+func runPullSecretShow(writer io.Writer, client machine.Client, outputFormat string) error {
+	registries, err := client.GetPullSecretRegistries()
+	return render(&pullSecretShowResult{
+		Success:    err == nil,
+		Error:      crcErrors.ToSerializableError(err),
+		Registries: registries,
+	}, writer, outputFormat)
+}
+
+func (s *pullSecretShowResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REGISTRY\tUSERNAME")
+	for _, registry := range s.Registries {
+		username := registry.Username
+		if username == "" {
+			username = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", registry.Registry, username)
+	}
+	return w.Flush()
+}
+
+func runPullSecretAddRegistry(writer io.Writer, client machine.Client, registry, username, password string) error {
+	if err := client.AddPullSecretRegistry(registry, username, password); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Added the credentials of %s to the pull secret\n", registry)
+	return err
+}
+
+func runPullSecretRemoveRegistry(writer io.Writer, client machine.Client, registry string) error {
+	if err := client.RemovePullSecretRegistry(registry); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Removed the credentials of %s from the pull secret\n", registry)
+	return err
+}
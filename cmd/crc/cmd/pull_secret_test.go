@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPullSecretShowPlain(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runPullSecretShow(out, fakemachine.NewClient(), ""))
+	assert.Equal(t, `REGISTRY                USERNAME
+cloud.openshift.com     user
+registry.internal:5000  -
+`, out.String())
+}
+
+func TestPullSecretShowJSON(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runPullSecretShow(out, fakemachine.NewClient(), jsonFormat))
+	assert.JSONEq(t, `{"success": true, "registries": [{"registry": "cloud.openshift.com", "username": "user"}, {"registry": "registry.internal:5000"}]}`, out.String())
+
+	out.Reset()
+	assert.NoError(t, runPullSecretShow(out, fakemachine.NewFailingClient(), jsonFormat))
+	assert.JSONEq(t, `{"success": false, "error": "get pull secret registries failed"}`, out.String())
+}
+
+func TestPullSecretAddAndRemoveRegistry(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runPullSecretAddRegistry(out, fakemachine.NewClient(), "registry.internal:5000", "user", "password"))
+	assert.NoError(t, runPullSecretRemoveRegistry(out, fakemachine.NewClient(), "registry.internal:5000"))
+	assert.Equal(t, "Added the credentials of registry.internal:5000 to the pull secret\nRemoved the credentials of registry.internal:5000 from the pull secret\n", out.String())
+
+	assert.EqualError(t, runPullSecretAddRegistry(out, fakemachine.NewFailingClient(), "registry.internal:5000", "user", "password"), "add pull secret registry failed")
+}
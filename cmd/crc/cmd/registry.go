@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/spf13/cobra"
+)
+
+var registryUser string
+
+func init() {
+	for _, cmd := range []*cobra.Command{registryLoginCmd, registryPushCmd} {
+		cmd.Flags().StringVar(&registryUser, "user", "developer", "User to authenticate with, developer or kubeadmin")
+	}
+	registryCmd.AddCommand(registryExposeCmd)
+	registryCmd.AddCommand(registryLoginCmd)
+	registryCmd.AddCommand(registryPushCmd)
+	rootCmd.AddCommand(registryCmd)
+}
+
+var registryCmd = &cobra.Command{
+	Use:   "registry SUBCOMMAND [flags]",
+	Short: "Use the image registry of the OpenShift cluster",
+	Long:  "Expose the internal image registry of the OpenShift cluster and push host images to it with podman (login and push require a local podman on Linux)",
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var registryExposeCmd = &cobra.Command{
+	Use:   "expose",
+	Short: "Expose the image registry",
+	Long:  "Enable the default route of the image registry and print its address",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runRegistryExpose(os.Stdout, newMachine())
+	},
+}
+
+var registryLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to the image registry",
+	Long: `Log podman in to the image registry with the token of the user. The certificate
+authority of the ingress is trusted for the address of the registry.`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runRegistryLogin(os.Stdout, newMachine(), registryUser)
+	},
+}
+
+var registryPushCmd = &cobra.Command{
+	Use:   "push IMAGE NAMESPACE/NAME[:TAG]",
+	Short: "Push a host image to the image registry",
+	Long:  "Push an image of the podman storage of the host to an image stream of the OpenShift cluster",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 2 {
+			return errors.New("Please provide the image to push and its destination")
+		}
+		return runRegistryPush(os.Stdout, newMachine(), args[0], args[1], registryUser)
+	},
+}
+
+func runRegistryExpose(writer io.Writer, client machine.Client) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	registry, err := client.ExposeRegistry()
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "The image registry is exposed at %s\n", registry)
+	return err
+}
+
+func runRegistryLogin(writer io.Writer, client machine.Client, username string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	registry, err := client.RegistryLogin(username)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "Logged in to %s as %s\n", registry, username)
+	return err
+}
+
+func runRegistryPush(writer io.Writer, client machine.Client, image, destination, username string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	reference, err := client.RegistryPush(image, destination, username)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(writer, "Pushed %s, it is available in the cluster as %s\n", image, reference)
+	return err
+}
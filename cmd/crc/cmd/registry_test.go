@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryExpose(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runRegistryExpose(out, fakemachine.NewClient()))
+	assert.Equal(t, "The image registry is exposed at default-route-openshift-image-registry.apps-crc.testing\n", out.String())
+
+	assert.EqualError(t, runRegistryExpose(out, fakemachine.NewFailingClient()), "expose registry failed")
+}
+
+func TestRegistryLogin(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runRegistryLogin(out, fakemachine.NewClient(), "developer"))
+	assert.Equal(t, "Logged in to default-route-openshift-image-registry.apps-crc.testing as developer\n", out.String())
+}
+
+func TestRegistryPush(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runRegistryPush(out, fakemachine.NewClient(), "localhost/app:v1", "myproject/app:v1", "developer"))
+	assert.Equal(t, "Pushed localhost/app:v1, it is available in the cluster as image-registry.openshift-image-registry.svc:5000/myproject/app:v1\n", out.String())
+
+	assert.EqualError(t, runRegistryPush(out, fakemachine.NewFailingClient(), "localhost/app:v1", "myproject/app:v1", "developer"), "registry push failed")
+}
@@ -10,6 +10,7 @@ import (
 
 	cmdBundle "github.com/crc-org/crc/v2/cmd/crc/cmd/bundle"
 	cmdConfig "github.com/crc-org/crc/v2/cmd/crc/cmd/config"
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
 	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	crcErr "github.com/crc-org/crc/v2/pkg/crc/errors"
@@ -42,6 +43,7 @@ var (
 	globalForce   bool
 	viper         *crcConfig.ViperStorage
 	config        *crcConfig.Config
+	profiles      *crcConfig.Profiles
 	segmentClient *segment.Client
 )
 
@@ -50,7 +52,7 @@ func init() {
 		logging.Fatal(err.Error())
 	}
 	var err error
-	config, viper, err = newConfig()
+	config, viper, profiles, err = newConfig()
 	if err != nil {
 		logging.Fatal(err.Error())
 	}
@@ -66,7 +68,7 @@ func init() {
 	}
 
 	// subcommands
-	rootCmd.AddCommand(cmdConfig.GetConfigCmd(config))
+	rootCmd.AddCommand(cmdConfig.GetConfigCmd(config, profiles, newMachine))
 	rootCmd.AddCommand(cmdBundle.GetBundleCmd(config))
 
 	logging.AddLogLevelFlag(rootCmd.PersistentFlags())
@@ -144,15 +146,18 @@ func setProxyDefaults() error {
 	return nil
 }
 
-func newConfig() (*crcConfig.Config, *crcConfig.ViperStorage, error) {
-	viper, err := crcConfig.NewViperStorage(constants.ConfigPath, constants.CrcEnvPrefix)
+func newConfig() (*crcConfig.Config, *crcConfig.ViperStorage, *crcConfig.Profiles, error) {
+	profiles := crcConfig.NewProfiles(constants.ConfigPath)
+	viper, err := crcConfig.NewViperStorageWithProfiles(profiles, constants.CrcEnvPrefix)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
-	cfg := crcConfig.New(viper, crcConfig.NewSecretStorage())
+	secretStorage := crcConfig.NewSecretStorage(constants.CrcEnvPrefix, constants.SecretsPath)
+	cfg := crcConfig.New(viper, secretStorage)
+	cluster.UseSecretStorage(secretStorage)
 	crcConfig.RegisterSettings(cfg)
 	preflight.RegisterSettings(cfg)
-	return cfg, viper, nil
+	return cfg, viper, profiles, nil
 }
 
 func newMachine() machine.Client {
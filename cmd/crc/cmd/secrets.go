@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/AlecAivazis/survey/v2"
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	crcTerminal "github.com/crc-org/crc/v2/pkg/os/terminal"
+	"github.com/spf13/cobra"
+)
+
+// pullSecretName is the name of the pull secret in the 'crc secrets' commands
+const pullSecretName = "pull-secret"
+
+var secretsMigrateTo string
+
+func init() {
+	addOutputFormatFlag(secretsListCmd)
+	secretsMigrateCmd.Flags().StringVar(&secretsMigrateTo, "to", "",
+		fmt.Sprintf("Backend receiving the secrets (%s or %s)", crcConfig.KeyringSecretStorage, crcConfig.FileSecretStorage))
+	secretsCmd.AddCommand(secretsListCmd)
+	secretsCmd.AddCommand(secretsSetCmd)
+	secretsCmd.AddCommand(secretsMigrateCmd)
+	rootCmd.AddCommand(secretsCmd)
+}
+
+var secretsCmd = &cobra.Command{
+	Use:   "secrets SUBCOMMAND [flags]",
+	Short: "Manage the secrets used by crc",
+	Long: fmt.Sprintf(`Manage the pull secret and the secret configuration properties.
+They are stored in the system keyring, or in an encrypted file when the keyring is not accessible,
+see 'crc config set %s'. The file is encrypted with the passphrase from %s when it is set,
+otherwise with a key bound to this machine.`, crcConfig.SecretBackend, crcConfig.EnvVarName(constants.CrcEnvPrefix, crcConfig.SecretsPassphrase)),
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var secretsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the secrets",
+	Long:  "List the secrets and the backends storing them, without their value",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runSecretsList(os.Stdout, config, outputFormat)
+	},
+}
+
+var secretsSetCmd = &cobra.Command{
+	Use:   "set NAME [VALUE]",
+	Short: "Store a secret",
+	Long: fmt.Sprintf(`Store the secret NAME, which is '%s' or a secret configuration property.
+VALUE is read from the standard input when it is not provided.`, pullSecretName),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) < 1 || len(args) > 2 {
+			return errors.New("Please provide the name of the secret and its value")
+		}
+		var value string
+		if len(args) == 2 {
+			value = args[1]
+		} else {
+			var err error
+			if value, err = readSecret(args[0]); err != nil {
+				return err
+			}
+		}
+		message, err := runSecretsSet(config, args[0], value)
+		if err != nil {
+			return err
+		}
+		fmt.Println(message)
+		return nil
+	},
+}
+
+var secretsMigrateCmd = &cobra.Command{
+	Use:   "migrate --to BACKEND",
+	Short: "Move the secrets to another backend",
+	Long:  "Move the secrets from the keyring to the encrypted file, or from the encrypted file to the keyring, and use this backend from now on",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		migrated, err := runSecretsMigrate(config, crcConfig.SecretStorageBackend(secretsMigrateTo))
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Moved %d secret(s) to the %s backend\n", len(migrated), secretsMigrateTo)
+		return nil
+	},
+}
+
+// secretKeys returns the keys of the secrets in the secret storage
+func secretKeys(config crcConfig.Storage) []string {
+	keys := []string{cluster.PullSecretKey}
+	for key, value := range config.AllConfigs() {
+		if value.IsSecret {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys[1:])
+	return keys
+}
+
+func secretName(key string) string {
+	if key == cluster.PullSecretKey {
+		return pullSecretName
+	}
+	return key
+}
+
+func runSecretsList(writer io.Writer, config *crcConfig.Config, outputFormat string) error {
+	storage, ok := config.SecretStorage()
+	if !ok {
+		return render(&secretsListResult{
+			Error: crcErrors.ToSerializableError(crcConfig.ErrSecretsNotAccessible),
+		}, writer, outputFormat)
+	}
+	result := &secretsListResult{
+		Success: true,
+		Backend: storage.Backend(),
+	}
+	for _, key := range secretKeys(config) {
+		source := storage.Source(key)
+		if key != cluster.PullSecretKey {
+			source = config.Source(key)
+		}
+		result.Secrets = append(result.Secrets, secretItem{
+			Name:     secretName(key),
+			Source:   source,
+			StoredIn: storage.StoredIn(key),
+		})
+	}
+	return render(result, writer, outputFormat)
+}
+
+func runSecretsSet(config *crcConfig.Config, name, value string) (string, error) {
+	if name == pullSecretName {
+		if err := cluster.StorePullSecret(value); err != nil {
+			return "", err
+		}
+		return "Successfully stored the pull secret", nil
+	}
+	setting := config.Get(name)
+	if setting.Invalid {
+		return "", fmt.Errorf("Unknown secret '%s'", name)
+	}
+	if !setting.IsSecret {
+		return "", fmt.Errorf("'%s' is not a secret, use 'crc config set %s' instead", name, name)
+	}
+	return config.Set(name, value)
+}
+
+func runSecretsMigrate(config *crcConfig.Config, to crcConfig.SecretStorageBackend) ([]string, error) {
+	storage, ok := config.SecretStorage()
+	if !ok {
+		return nil, crcConfig.ErrSecretsNotAccessible
+	}
+	migrated, err := storage.Migrate(secretKeys(config), to)
+	if err != nil {
+		return migrated, err
+	}
+	if _, err := config.Set(crcConfig.SecretBackend, string(to)); err != nil {
+		return migrated, err
+	}
+	return migrated, nil
+}
+
+func readSecret(name string) (string, error) {
+	var value string
+	if crcTerminal.IsRunningInTerminal() {
+		prompt := &survey.Password{
+			Message: fmt.Sprintf("Please enter the value of %s", name),
+		}
+		if err := survey.AskOne(prompt, &value); err != nil {
+			return "", err
+		}
+		return value, nil
+	}
+	bin, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(bin)), nil
+}
+
+type secretItem struct {
+	Name     string                           `json:"name"`
+	Source   crcConfig.Source                 `json:"source"`
+	StoredIn []crcConfig.SecretStorageBackend `json:"storedIn"`
+}
+
+type secretsListResult struct {
+	Success bool                           `json:"success"`
+	Error   *crcErrors.SerializableError   `json:"error,omitempty"`
+	Backend crcConfig.SecretStorageBackend `json:"backend,omitempty"`
+	Secrets []secretItem                   `json:"secrets,omitempty"`
+}
+
+func (s *secretsListResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	if _, err := fmt.Fprintf(writer, "Secrets are stored in: %s\n\n", s.Backend); err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	if _, err := fmt.Fprintln(w, "NAME\tSOURCE\tSTORED IN"); err != nil {
+		return err
+	}
+	for _, secret := range s.Secrets {
+		var storedIn []string
+		for _, backend := range secret.StoredIn {
+			storedIn = append(storedIn, string(backend))
+		}
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", secret.Name, secret.Source, strings.Join(storedIn, ", ")); err != nil {
+			return err
+		}
+	}
+	return w.Flush()
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zalando/go-keyring"
+)
+
+func TestSecrets(t *testing.T) {
+	keyring.MockInit()
+	storage := crcConfig.NewSecretStorage("", filepath.Join(t.TempDir(), "secrets.json.enc"))
+	cfg := crcConfig.New(crcConfig.NewEmptyInMemoryStorage(), storage)
+	crcConfig.RegisterSettings(cfg)
+	cluster.UseSecretStorage(storage)
+	defer cluster.UseSecretStorage(crcConfig.NewKeyringStorage())
+
+	_, err := runSecretsSet(cfg, pullSecretName, `{"auths":{"quay.io":{"auth":"secret"}}}`)
+	require.NoError(t, err)
+	_, err = runSecretsSet(cfg, crcConfig.CPUs, "4")
+	assert.EqualError(t, err, "'cpus' is not a secret, use 'crc config set cpus' instead")
+	_, err = runSecretsSet(cfg, "foo", "bar")
+	assert.EqualError(t, err, "Unknown secret 'foo'")
+
+	out := new(bytes.Buffer)
+	require.NoError(t, runSecretsList(out, cfg, ""))
+	assert.Contains(t, out.String(), "Secrets are stored in: keyring\n")
+	assert.Contains(t, out.String(), "pull-secret  keyring  keyring\n")
+
+	migrated, err := runSecretsMigrate(cfg, crcConfig.FileSecretStorage)
+	require.NoError(t, err)
+	assert.Equal(t, []string{cluster.PullSecretKey}, migrated)
+	assert.Equal(t, crcConfig.FileSecretStorage, storage.Backend())
+
+	out.Reset()
+	require.NoError(t, runSecretsList(out, cfg, ""))
+	assert.Contains(t, out.String(), "Secrets are stored in: file\n")
+	assert.Contains(t, out.String(), "pull-secret  secrets-file  file\n")
+
+	_, err = runSecretsMigrate(cfg, "vault")
+	assert.EqualError(t, err, "secrets can only be migrated to keyring or file")
+}
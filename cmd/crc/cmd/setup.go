@@ -9,6 +9,7 @@ import (
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
 	"github.com/crc-org/crc/v2/pkg/crc/input"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
 	"github.com/crc-org/crc/v2/pkg/crc/preflight"
 	"github.com/crc-org/crc/v2/pkg/crc/validation"
 	crcTerminal "github.com/crc-org/crc/v2/pkg/os/terminal"
@@ -67,6 +68,9 @@ func runSetup(_ []string) error {
 	// set global variable to force terminal output
 	crcTerminal.ForceShowOutput = forceShowProgressbars
 	err := preflight.SetupHost(config, checkOnly)
+	if err == nil && !checkOnly {
+		machine.CollectCacheGarbage(newMachine(), config)
+	}
 	if err != nil && checkOnly {
 		err = exec.CodeExitError{
 			Err:  err,
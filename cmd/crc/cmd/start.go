@@ -42,6 +42,10 @@ func init() {
 	flagSet.UintP(crcConfig.DiskSize, "d", constants.DefaultDiskSize, "Total size in GiB of the disk used by the instance")
 	flagSet.StringP(crcConfig.NameServer, "n", "", "IPv4 address of nameserver to use for the instance")
 	flagSet.Bool(crcConfig.DisableUpdateCheck, false, "Don't check for update")
+	flagSet.String(crcConfig.ClusterStableTimeout, "10m", "Maximum time to wait for the cluster operators to be stable")
+	flagSet.Uint(crcConfig.ClusterStableChecks, 3, "Number of consecutive checks where all the cluster operators must be ready")
+	flagSet.String(crcConfig.ClusterStableIgnoredOperators, "", "Comma-separated list of cluster operators to ignore when waiting for the cluster to be stable")
+	flagSet.Bool(crcConfig.ClusterStableStrict, false, "Fail when the cluster operators are not stable in time")
 
 	startCmd.Flags().AddFlagSet(flagSet)
 }
@@ -72,6 +76,8 @@ func runStart(ctx context.Context) (*types.StartResult, error) {
 		Memory:            config.Get(crcConfig.Memory).AsUInt(),
 		DiskSize:          config.Get(crcConfig.DiskSize).AsUInt(),
 		CPUs:              config.Get(crcConfig.CPUs).AsUInt(),
+		MaxCPUs:           config.Get(crcConfig.MaxCPUs).AsUInt(),
+		MaxMemory:         config.Get(crcConfig.MaxMemory).AsUInt(),
 		NameServer:        config.Get(crcConfig.NameServer).AsString(),
 		PullSecret:        cluster.NewInteractivePullSecretLoader(config),
 		KubeAdminPassword: config.Get(crcConfig.KubeAdminPassword).AsString(),
@@ -85,6 +91,8 @@ func runStart(ctx context.Context) (*types.StartResult, error) {
 		PersistentVolumeSize: config.Get(crcConfig.PersistentVolumeSize).AsInt(),
 
 		EnableBundleQuayFallback: config.Get(crcConfig.EnableBundleQuayFallback).AsBool(),
+		BundleTrustedKeys:        crcConfig.GetBundleTrustedKeys(config),
+		RequireSignedBundles:     config.Get(crcConfig.RequireSignedBundles).AsBool(),
 	}
 
 	client := newMachine()
@@ -193,6 +201,9 @@ func validateStartFlags() error {
 	if err := validation.ValidateBundle(config.Get(crcConfig.Bundle).AsString(), crcConfig.GetPreset(config)); err != nil {
 		return err
 	}
+	if err := validation.ValidateDuration(config.Get(crcConfig.ClusterStableTimeout).AsString()); err != nil {
+		return err
+	}
 	if config.Get(crcConfig.NameServer).AsString() != "" {
 		if err := validation.ValidateIPAddress(config.Get(crcConfig.NameServer).AsString()); err != nil {
 			return err
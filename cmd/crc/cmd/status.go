@@ -7,9 +7,13 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/cheggaaa/pb/v3"
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	"github.com/crc-org/crc/v2/pkg/crc/daemonclient"
 	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
@@ -20,11 +24,13 @@ import (
 )
 
 var (
-	watch bool
+	watch     bool
+	operators bool
 )
 
 func init() {
 	statusCmd.Flags().BoolVarP(&watch, "watch", "w", false, "watch mode, continuously update status with CPU load graph")
+	statusCmd.Flags().BoolVar(&operators, "operators", false, "show the condition of each cluster operator, or of the MicroShift node and pods")
 	addOutputFormatFlag(statusCmd)
 	rootCmd.AddCommand(statusCmd)
 }
@@ -34,7 +40,13 @@ var statusCmd = &cobra.Command{
 	Short: "Display status of the OpenShift cluster",
 	Long:  "Show details about the OpenShift cluster",
 	RunE: func(_ *cobra.Command, _ []string) error {
-		return runStatus(os.Stdout, daemonclient.New(), constants.MachineCacheDir, outputFormat, watch)
+		if operators {
+			if watch {
+				return errors.New("--operators cannot be used with --watch")
+			}
+			return runStatusOperators(os.Stdout, daemonclient.New(), outputFormat)
+		}
+		return runStatus(os.Stdout, daemonclient.New(), constants.MachineCacheDir, profiles.Active(), outputFormat, watch)
 	},
 }
 
@@ -53,19 +65,24 @@ type status struct {
 	PersistentVolumeUse  int                          `json:"persistentVolumeUsage,omitempty"`
 	PersistentVolumeSize int                          `json:"persistentVolumeSize,omitempty"`
 	Preset               preset.Preset                `json:"preset"`
+	Profile              string                       `json:"profile,omitempty"`
+	PendingChanges       []types.PendingChange        `json:"pendingChanges,omitempty"`
+	ExpiringCertificates []cluster.CertificateStatus  `json:"expiringCertificates,omitempty"`
+	EnabledCapabilities  []string                     `json:"enabledCapabilities,omitempty"`
+	DisabledCapabilities []string                     `json:"disabledCapabilities,omitempty"`
 }
 
-func runStatus(writer io.Writer, client *daemonclient.Client, cacheDir, outputFormat string, watch bool) error {
+func runStatus(writer io.Writer, client *daemonclient.Client, cacheDir, profile, outputFormat string, watch bool) error {
 	if watch {
-		return runWatchStatus(writer, client, cacheDir)
+		return runWatchStatus(writer, client, cacheDir, profile)
 	}
-	status := getStatus(client, cacheDir)
+	status := getStatus(client, cacheDir, profile)
 	return render(status, writer, outputFormat)
 }
 
-func runWatchStatus(writer io.Writer, client *daemonclient.Client, cacheDir string) error {
+func runWatchStatus(writer io.Writer, client *daemonclient.Client, cacheDir, profile string) error {
 
-	status := getStatus(client, cacheDir)
+	status := getStatus(client, cacheDir, profile)
 	// do not render RAM size/use
 	status.RAMSize = -1
 	status.RAMUsage = -1
@@ -143,7 +160,7 @@ func createCPUBar(cpuNum int, writer io.Writer) *pb.ProgressBar {
 	return bar
 }
 
-func getStatus(client *daemonclient.Client, cacheDir string) *status {
+func getStatus(client *daemonclient.Client, cacheDir, profile string) *status {
 
 	clusterStatus, err := client.APIClient.Status()
 	if err != nil {
@@ -178,6 +195,11 @@ func getStatus(client *daemonclient.Client, cacheDir string) *status {
 		PersistentVolumeSize: clusterStatus.PersistentVolumeSize,
 		CacheDir:             cacheDir,
 		Preset:               clusterStatus.Preset,
+		Profile:              profile,
+		PendingChanges:       clusterStatus.PendingChanges,
+		ExpiringCertificates: clusterStatus.ExpiringCertificates,
+		EnabledCapabilities:  clusterStatus.EnabledCapabilities,
+		DisabledCapabilities: clusterStatus.DisabledCapabilities,
 	}
 }
 
@@ -223,6 +245,19 @@ func (s *status) prettyPrintTo(writer io.Writer) error {
 	lines = append(lines,
 		line{"Cache Usage", units.HumanSize(float64(s.CacheUsage))},
 		line{"Cache Directory", s.CacheDir})
+	// the configuration profile is only shown when profiles are used
+	if s.Profile != "" && s.Profile != crcConfig.DefaultProfile {
+		lines = append(lines, line{"Config Profile", s.Profile})
+	}
+	if len(s.EnabledCapabilities) != 0 || len(s.DisabledCapabilities) != 0 {
+		lines = append(lines, line{"Capabilities", capabilities(s.EnabledCapabilities, s.DisabledCapabilities)})
+	}
+	for _, change := range s.PendingChanges {
+		lines = append(lines, line{"Pending Restart", pendingChange(change)})
+	}
+	for _, cert := range s.ExpiringCertificates {
+		lines = append(lines, line{"Certificate Warning", certificateWarning(cert)})
+	}
 
 	for _, line := range lines {
 		if err := printLine(w, line.left, line.right); err != nil {
@@ -232,6 +267,75 @@ func (s *status) prettyPrintTo(writer io.Writer) error {
 	return w.Flush()
 }
 
+func capabilities(enabled, disabled []string) string {
+	if len(enabled) == 0 {
+		return fmt.Sprintf("none (disabled: %s)", strings.Join(disabled, ", "))
+	}
+	if len(disabled) == 0 {
+		return strings.Join(enabled, ", ")
+	}
+	return fmt.Sprintf("%s (disabled: %s)", strings.Join(enabled, ", "), strings.Join(disabled, ", "))
+}
+
+// pendingChange describes a change which requires 'crc stop' and 'crc start'
+func pendingChange(change types.PendingChange) string {
+	if change.Property == crcConfig.Memory {
+		return fmt.Sprintf("%s %d MiB -> %d MiB", change.Property, change.Current, change.Configured)
+	}
+	return fmt.Sprintf("%s %d -> %d", change.Property, change.Current, change.Configured)
+}
+
+// certificateWarning tells about a certificate which expires soon, or has expired
+func certificateWarning(cert cluster.CertificateStatus) string {
+	if cert.NotAfter.Before(time.Now()) {
+		return fmt.Sprintf("%s expired on %s, run 'crc certs renew'", cert.Name, cert.NotAfter.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("%s expires on %s, run 'crc certs renew'", cert.Name, cert.NotAfter.Format(time.RFC3339))
+}
+
+type operatorsStatus struct {
+	Success   bool                         `json:"success"`
+	Error     *crcErrors.SerializableError `json:"error,omitempty"`
+	Preset    preset.Preset                `json:"preset,omitempty"`
+	Operators []cluster.OperatorCondition  `json:"operators,omitempty"`
+}
+
+func runStatusOperators(writer io.Writer, client *daemonclient.Client, outputFormat string) error {
+	return render(getOperatorsStatus(client), writer, outputFormat)
+}
+
+func getOperatorsStatus(client *daemonclient.Client) *operatorsStatus {
+	result, err := client.APIClient.StatusOperators()
+	if err != nil {
+		var urlError *url.Error
+		if errors.As(err, &urlError) {
+			return &operatorsStatus{Success: false, Error: crcErrors.ToSerializableError(crcErrors.DaemonNotRunning)}
+		}
+		return &operatorsStatus{Success: false, Error: crcErrors.ToSerializableError(err)}
+	}
+	return &operatorsStatus{
+		Success:   true,
+		Preset:    result.Preset,
+		Operators: result.Operators,
+	}
+}
+
+func (s *operatorsStatus) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCONDITION\tSINCE\tMESSAGE")
+	for _, operator := range s.Operators {
+		since := "-"
+		if operator.LastTransitionTime != nil {
+			since = operator.LastTransitionTime.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", operator.Name, operator.Condition, since, operator.Message)
+	}
+	return w.Flush()
+}
+
 func openshiftStatus(status *status) string {
 	if status.OpenShiftVersion != "" {
 		return fmt.Sprintf("%s (v%s)", status.OpenShiftStatus, status.OpenShiftVersion)
@@ -7,10 +7,12 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
 	mocks "github.com/crc-org/crc/v2/test/mocks/api"
 
 	apiClient "github.com/crc-org/crc/v2/pkg/crc/api/client"
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
 	"github.com/crc-org/crc/v2/pkg/crc/daemonclient"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
@@ -55,7 +57,7 @@ func TestPlainStatus(t *testing.T) {
 	out := new(bytes.Buffer)
 	assert.NoError(t, runStatus(out, &daemonclient.Client{
 		APIClient: client,
-	}, cacheDir, "", false))
+	}, cacheDir, "", "", false))
 
 	expected := `CRC VM:          Running
 OpenShift:       Running (v4.5.1)
@@ -85,7 +87,7 @@ func TestStatusWithoutPodman(t *testing.T) {
 	out := new(bytes.Buffer)
 	assert.NoError(t, runStatus(out, &daemonclient.Client{
 		APIClient: client,
-	}, cacheDir, "", false))
+	}, cacheDir, "", "", false))
 
 	expected := `CRC VM:          Running
 OpenShift:       Running (v4.5.1)
@@ -107,7 +109,7 @@ func TestJsonStatus(t *testing.T) {
 	out := new(bytes.Buffer)
 	assert.NoError(t, runStatus(out, &daemonclient.Client{
 		APIClient: client,
-	}, cacheDir, jsonFormat, false))
+	}, cacheDir, "", jsonFormat, false))
 
 	expected := `{
   "success": true,
@@ -134,7 +136,7 @@ func TestPlainStatusWithError(t *testing.T) {
 	out := new(bytes.Buffer)
 	assert.EqualError(t, runStatus(out, &daemonclient.Client{
 		APIClient: client,
-	}, cacheDir, "", false), "broken")
+	}, cacheDir, "", "", false), "broken")
 	assert.Equal(t, "", out.String())
 }
 
@@ -148,7 +150,7 @@ func TestJsonStatusWithError(t *testing.T) {
 	out := new(bytes.Buffer)
 	assert.NoError(t, runStatus(out, &daemonclient.Client{
 		APIClient: client,
-	}, cacheDir, jsonFormat, false))
+	}, cacheDir, "", jsonFormat, false))
 
 	expected := `{
   "success": false,
@@ -179,7 +181,7 @@ func TestStatusWithMemoryPodman(t *testing.T) {
 	out := new(bytes.Buffer)
 	assert.NoError(t, runStatus(out, &daemonclient.Client{
 		APIClient: client,
-	}, cacheDir, "", false))
+	}, cacheDir, "", "", false))
 
 	expected := `CRC VM:          Running
 OpenShift:       Running (v4.5.1)
@@ -190,3 +192,221 @@ Cache Directory: %s
 `
 	assert.Equal(t, fmt.Sprintf(expected, cacheDir), out.String())
 }
+
+func TestPlainStatusWithProfile(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	client := setUpClient(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "crc.qcow2"), make([]byte, 10000), 0600))
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, runStatus(out, &daemonclient.Client{
+		APIClient: client,
+	}, cacheDir, "microshift", "", false))
+
+	expected := `CRC VM:          Running
+OpenShift:       Running (v4.5.1)
+RAM Usage:       0B of 0B
+Disk Usage:      10GB of 20GB (Inside the CRC VM)
+Cache Usage:     10kB
+Cache Directory: %s
+Config Profile:  microshift
+`
+	assert.Equal(t, fmt.Sprintf(expected, cacheDir), out.String())
+}
+
+func TestPlainStatusWithPendingChanges(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	client := mocks.NewClient(t)
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "crc.qcow2"), make([]byte, 10000), 0600))
+
+	client.On("Status").Return(apiClient.ClusterStatusResult{
+		CrcStatus:        string(state.Running),
+		OpenshiftStatus:  string(types.OpenshiftRunning),
+		OpenshiftVersion: "4.5.1",
+		DiskUse:          10_000_000_000,
+		DiskSize:         20_000_000_000,
+		Preset:           preset.OpenShift,
+		PendingChanges: []types.PendingChange{
+			{Property: "cpus", Current: 4, Configured: 6},
+			{Property: "memory", Current: 10752, Configured: 16384},
+		},
+	}, nil)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, runStatus(out, &daemonclient.Client{
+		APIClient: client,
+	}, cacheDir, "", "", false))
+
+	expected := `CRC VM:          Running
+OpenShift:       Running (v4.5.1)
+RAM Usage:       0B of 0B
+Disk Usage:      10GB of 20GB (Inside the CRC VM)
+Cache Usage:     10kB
+Cache Directory: %s
+Pending Restart: cpus 4 -> 6
+Pending Restart: memory 10752 MiB -> 16384 MiB
+`
+	assert.Equal(t, fmt.Sprintf(expected, cacheDir), out.String())
+}
+
+func TestPlainStatusWithExpiringCertificates(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	client := mocks.NewClient(t)
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "crc.qcow2"), make([]byte, 10000), 0600))
+
+	expired := time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC)
+	client.On("Status").Return(apiClient.ClusterStatusResult{
+		CrcStatus:        string(state.Running),
+		OpenshiftStatus:  string(types.OpenshiftRunning),
+		OpenshiftVersion: "4.5.1",
+		DiskUse:          10_000_000_000,
+		DiskSize:         20_000_000_000,
+		Preset:           preset.OpenShift,
+		ExpiringCertificates: []cluster.CertificateStatus{
+			{Name: "kubelet-client", NotAfter: &expired},
+		},
+	}, nil)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, runStatus(out, &daemonclient.Client{
+		APIClient: client,
+	}, cacheDir, "", "", false))
+
+	expected := `CRC VM:              Running
+OpenShift:           Running (v4.5.1)
+RAM Usage:           0B of 0B
+Disk Usage:          10GB of 20GB (Inside the CRC VM)
+Cache Usage:         10kB
+Cache Directory:     %s
+Certificate Warning: kubelet-client expired on 2024-06-01T09:30:00Z, run 'crc certs renew'
+`
+	assert.Equal(t, fmt.Sprintf(expected, cacheDir), out.String())
+}
+
+func TestPlainStatusWithCapabilities(t *testing.T) {
+	cacheDir := t.TempDir()
+
+	client := mocks.NewClient(t)
+	require.NoError(t, os.WriteFile(filepath.Join(cacheDir, "crc.qcow2"), make([]byte, 10000), 0600))
+
+	client.On("Status").Return(apiClient.ClusterStatusResult{
+		CrcStatus:            string(state.Running),
+		OpenshiftStatus:      string(types.OpenshiftRunning),
+		OpenshiftVersion:     "4.5.1",
+		DiskUse:              10_000_000_000,
+		DiskSize:             20_000_000_000,
+		Preset:               preset.OpenShift,
+		EnabledCapabilities:  []string{"image-registry", "olm-catalogs"},
+		DisabledCapabilities: []string{"console", "monitoring"},
+	}, nil)
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, runStatus(out, &daemonclient.Client{
+		APIClient: client,
+	}, cacheDir, "", "", false))
+
+	expected := `CRC VM:          Running
+OpenShift:       Running (v4.5.1)
+RAM Usage:       0B of 0B
+Disk Usage:      10GB of 20GB (Inside the CRC VM)
+Cache Usage:     10kB
+Cache Directory: %s
+Capabilities:    image-registry, olm-catalogs (disabled: console, monitoring)
+`
+	assert.Equal(t, fmt.Sprintf(expected, cacheDir), out.String())
+}
+
+func setUpOperatorsClient(t *testing.T) *mocks.Client {
+	client := mocks.NewClient(t)
+
+	client.On("StatusOperators").Return(apiClient.ClusterOperatorsResult{
+		Preset: preset.OpenShift,
+		Operators: []cluster.OperatorCondition{
+			{
+				Name:               "authentication",
+				Condition:          cluster.ConditionAvailable,
+				Message:            "AsExpected",
+				LastTransitionTime: timestamp(time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)),
+			},
+			{
+				Name:               "ingress",
+				Condition:          cluster.ConditionDegraded,
+				Message:            "The default ingress controller reports Degraded=True",
+				LastTransitionTime: timestamp(time.Date(2024, 5, 2, 9, 45, 0, 0, time.UTC)),
+			},
+			{
+				Name:      "insights",
+				Condition: cluster.ConditionUnavailable,
+				Message:   "The operator does not report its status",
+			},
+		},
+	}, nil)
+
+	return client
+}
+
+func TestPlainStatusOperators(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runStatusOperators(out, &daemonclient.Client{
+		APIClient: setUpOperatorsClient(t),
+	}, ""))
+
+	expected := `NAME            CONDITION    SINCE                 MESSAGE
+authentication  Available    2024-05-02T09:30:00Z  AsExpected
+ingress         Degraded     2024-05-02T09:45:00Z  The default ingress controller reports Degraded=True
+insights        Unavailable  -                     The operator does not report its status
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestJsonStatusOperators(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runStatusOperators(out, &daemonclient.Client{
+		APIClient: setUpOperatorsClient(t),
+	}, jsonFormat))
+
+	expected := `{
+  "success": true,
+  "preset": "openshift",
+  "operators": [
+    {
+      "name": "authentication",
+      "condition": "Available",
+      "message": "AsExpected",
+      "lastTransitionTime": "2024-05-02T09:30:00Z"
+    },
+    {
+      "name": "ingress",
+      "condition": "Degraded",
+      "message": "The default ingress controller reports Degraded=True",
+      "lastTransitionTime": "2024-05-02T09:45:00Z"
+    },
+    {
+      "name": "insights",
+      "condition": "Unavailable",
+      "message": "The operator does not report its status"
+    }
+  ]
+}
+`
+	assert.Equal(t, expected, out.String())
+}
+
+func TestStatusOperatorsWithError(t *testing.T) {
+	client := mocks.NewClient(t)
+	client.On("StatusOperators").Return(apiClient.ClusterOperatorsResult{}, errors.New("broken"))
+
+	out := new(bytes.Buffer)
+	assert.EqualError(t, runStatusOperators(out, &daemonclient.Client{
+		APIClient: client,
+	}, ""), "broken")
+	assert.Equal(t, "", out.String())
+}
+
+func timestamp(t time.Time) *time.Time {
+	return &t
+}
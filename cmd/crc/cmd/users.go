@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/machine"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/spf13/cobra"
+)
+
+var usersClusterRoles []string
+
+func init() {
+	addOutputFormatFlag(usersListCmd)
+	usersAddCmd.Flags().StringSliceVar(&usersClusterRoles, "cluster-role", nil, "Cluster role to bind to the user, can be repeated")
+	usersCmd.AddCommand(usersAddCmd)
+	usersCmd.AddCommand(usersRemoveCmd)
+	usersCmd.AddCommand(usersListCmd)
+	usersCmd.AddCommand(usersPasswdCmd)
+	rootCmd.AddCommand(usersCmd)
+}
+
+var usersCmd = &cobra.Command{
+	Use:   "users SUBCOMMAND [flags]",
+	Short: "Manage the users of the OpenShift cluster",
+	Long: `Manage the users of the htpasswd identity provider of the OpenShift cluster,
+in addition to kubeadmin and developer. Each user gets a 'crc-<name>' kubeconfig context.`,
+	Run: func(cmd *cobra.Command, _ []string) {
+		_ = cmd.Help()
+	},
+}
+
+var usersAddCmd = &cobra.Command{
+	Use:   "add NAME [PASSWORD]",
+	Short: "Add a user",
+	Long:  "Add a user, PASSWORD is read from the standard input when it is not provided",
+	RunE: func(_ *cobra.Command, args []string) error {
+		username, password, err := usernameAndPassword(args)
+		if err != nil {
+			return err
+		}
+		return runUsersAdd(os.Stdout, newMachine(), username, password, usersClusterRoles)
+	},
+}
+
+var usersPasswdCmd = &cobra.Command{
+	Use:   "passwd NAME [PASSWORD]",
+	Short: "Change the password of a user",
+	Long:  "Change the password of a user, PASSWORD is read from the standard input when it is not provided",
+	RunE: func(_ *cobra.Command, args []string) error {
+		username, password, err := usernameAndPassword(args)
+		if err != nil {
+			return err
+		}
+		return runUsersPasswd(os.Stdout, newMachine(), username, password)
+	},
+}
+
+var usersRemoveCmd = &cobra.Command{
+	Use:   "remove NAME",
+	Short: "Remove a user",
+	Long:  "Remove a user and its kubeconfig context",
+	RunE: func(_ *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("Please provide the name of the user")
+		}
+		return runUsersRemove(os.Stdout, newMachine(), args[0])
+	},
+}
+
+var usersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the users",
+	Long:  "List the users of the htpasswd identity provider and their kubeconfig context",
+	RunE: func(_ *cobra.Command, _ []string) error {
+		return runUsersList(os.Stdout, newMachine(), outputFormat)
+	},
+}
+
+func usernameAndPassword(args []string) (string, string, error) {
+	if len(args) < 1 || len(args) > 2 {
+		return "", "", errors.New("Please provide the name of the user and its password")
+	}
+	if len(args) == 2 {
+		return args[0], args[1], nil
+	}
+	password, err := readSecret(fmt.Sprintf("the password of %s", args[0]))
+	if err != nil {
+		return "", "", err
+	}
+	if password == "" {
+		return "", "", errors.New("The password cannot be empty")
+	}
+	return args[0], password, nil
+}
+
+func runUsersAdd(writer io.Writer, client machine.Client, username, password string, clusterRoles []string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	if err := client.AddUser(username, password, clusterRoles); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Added user %s, use 'oc config use-context crc-%s' to log in as this user\n", username, username)
+	return err
+}
+
+func runUsersPasswd(writer io.Writer, client machine.Client, username, password string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	if err := client.SetUserPassword(username, password); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Changed the password of user %s\n", username)
+	return err
+}
+
+func runUsersRemove(writer io.Writer, client machine.Client, username string) error {
+	if err := checkIfMachineMissing(client); err != nil {
+		return err
+	}
+	if err := client.RemoveUser(username); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(writer, "Removed user %s\n", username)
+	return err
+}
+
+type usersListResult struct {
+	Success bool                         `json:"success"`
+	Error   *crcErrors.SerializableError `json:"error,omitempty"`
+	Users   []types.User                 `json:"users,omitempty"`
+}
+
+func runUsersList(writer io.Writer, client machine.Client, outputFormat string) error {
+	err := checkIfMachineMissing(client)
+	var users []types.User
+	if err == nil {
+		users, err = client.ListUsers()
+	}
+	return render(&usersListResult{
+		Success: err == nil,
+		Error:   crcErrors.ToSerializableError(err),
+		Users:   users,
+	}, writer, outputFormat)
+}
+
+func (s *usersListResult) prettyPrintTo(writer io.Writer) error {
+	if s.Error != nil {
+		return s.Error
+	}
+	w := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tCONTEXT")
+	for _, user := range s.Users {
+		context := user.Context
+		if context == "" {
+			context = "-"
+		}
+		fmt.Fprintf(w, "%s\t%s\n", user.Name, context)
+	}
+	return w.Flush()
+}
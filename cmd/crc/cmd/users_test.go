@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersListPlain(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runUsersList(out, fakemachine.NewClient(), ""))
+	assert.Equal(t, `NAME       CONTEXT
+alice      crc-alice
+developer  crc-developer
+kubeadmin  crc-admin
+bob        -
+`, out.String())
+}
+
+func TestUsersListJSONError(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runUsersList(out, fakemachine.NewFailingClient(), jsonFormat))
+	assert.JSONEq(t, `{"success": false, "error": "list users failed"}`, out.String())
+}
+
+func TestUsersAdd(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runUsersAdd(out, fakemachine.NewClient(), "alice", "password", []string{"cluster-admin"}))
+	assert.Equal(t, "Added user alice, use 'oc config use-context crc-alice' to log in as this user\n", out.String())
+
+	assert.EqualError(t, runUsersAdd(out, fakemachine.NewFailingClient(), "alice", "password", nil), "add user failed")
+}
+
+func TestUsersPasswdAndRemove(t *testing.T) {
+	out := new(bytes.Buffer)
+	assert.NoError(t, runUsersPasswd(out, fakemachine.NewClient(), "alice", "password"))
+	assert.NoError(t, runUsersRemove(out, fakemachine.NewClient(), "alice"))
+	assert.Equal(t, "Changed the password of user alice\nRemoved user alice\n", out.String())
+}
@@ -5,12 +5,30 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"time"
 
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/docker/go-units"
 	"github.com/klauspost/compress/zstd"
 )
 
-func Compress(src, dest string) (err error) {
+type Options struct {
+	// Level is the zstd compression level, between 1 (fastest) and 22 (best compression).
+	// 0 uses the zstd library default level.
+	Level int
+	// Concurrency is the number of goroutines used by the zstd encoder.
+	// 0 uses as many goroutines as there are CPUs.
+	Concurrency int
+}
+
+func Compress(src, dest string) error {
+	return CompressWithOptions(src, dest, Options{})
+}
+
+func CompressWithOptions(src, dest string, options Options) (err error) {
+	start := time.Now()
+
 	out, err := os.Create(dest)
 	if err != nil {
 		return err
@@ -22,7 +40,7 @@ func Compress(src, dest string) (err error) {
 		}
 	}()
 
-	enc, err := zstd.NewWriter(out)
+	enc, err := zstd.NewWriter(out, encoderOptions(options)...)
 	if err != nil {
 		return err
 	}
@@ -33,11 +51,12 @@ func Compress(src, dest string) (err error) {
 
 	basePath, _ := filepath.Split(src)
 
+	var totalSize int64
 	// Just use top level directory as part of tarball
 	// $ zstdcat crc_libvirt_4.7.1_custom.zstd  | tar t
 	// crc_libvirt_4.7.1_custom
 	// crc_libvirt_4.7.1_custom/test
-	return filepath.Walk(src, func(file string, fi os.FileInfo, err1 error) error {
+	err = filepath.Walk(src, func(file string, fi os.FileInfo, err1 error) error {
 		if err1 != nil {
 			return err1
 		}
@@ -52,20 +71,81 @@ func Compress(src, dest string) (err error) {
 		if err != nil {
 			return err
 		}
+		header.Name = filepath.ToSlash(header.Name)
+
+		if fi.IsDir() {
+			return tarWriter.WriteHeader(header)
+		}
+		totalSize += fi.Size()
+		return addFile(tarWriter, enc, header, file, fi)
+	})
+	if err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := enc.Close(); err != nil {
+		return err
+	}
+
+	logThroughput(dest, totalSize, time.Since(start))
+	return nil
+}
+
+func encoderOptions(options Options) []zstd.EOption {
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+	encoderOptions := []zstd.EOption{zstd.WithEncoderConcurrency(concurrency)}
+	if options.Level != 0 {
+		encoderOptions = append(encoderOptions, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(options.Level)))
+	}
+	return encoderOptions
+}
+
+func addFile(tarWriter *tar.Writer, out io.Writer, header *tar.Header, path string, fi os.FileInfo) error {
+	data, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer data.Close()
 
-		if err := tarWriter.WriteHeader(header); err != nil {
+	if isSparseCandidate(fi) {
+		fragments, err := dataFragments(data, fi.Size())
+		if err != nil {
 			return err
 		}
-		if !fi.IsDir() {
-			data, err := os.Open(file)
-			if err != nil {
-				return err
-			}
-			defer data.Close()
-			if _, err := io.Copy(tarWriter, data); err != nil {
+		var dataSize int64
+		for _, f := range fragments {
+			dataSize += f.length
+		}
+		if dataSize < fi.Size() {
+			logging.Debugf("Adding %s as a sparse file (%s of data, %s apparent size)", path, units.HumanSize(float64(dataSize)), units.HumanSize(float64(fi.Size())))
+			if err := tarWriter.Flush(); err != nil {
 				return err
 			}
+			return writeSparseEntry(out, header, data, fragments)
 		}
-		return nil
-	})
+	}
+
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tarWriter, data)
+	return err
+}
+
+func logThroughput(dest string, totalSize int64, duration time.Duration) {
+	fi, err := os.Stat(dest)
+	if err != nil {
+		logging.Debugf("Cannot stat %s: %v", dest, err)
+		return
+	}
+	logging.Infof("Compressed %s to %s in %s (%s/s)",
+		units.HumanSize(float64(totalSize)),
+		units.HumanSize(float64(fi.Size())),
+		duration.Round(time.Millisecond),
+		units.HumanSize(float64(totalSize)/duration.Seconds()))
 }
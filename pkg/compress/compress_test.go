@@ -1,7 +1,10 @@
 package compress
 
 import (
+	"archive/tar"
+	"bytes"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"runtime"
@@ -9,6 +12,7 @@ import (
 
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	"github.com/crc-org/crc/v2/pkg/extract"
+	"github.com/klauspost/compress/zstd"
 
 	"github.com/stretchr/testify/require"
 )
@@ -60,6 +64,150 @@ func TestCompressRelative(t *testing.T) {
 	testCompress(t, filepath.Join(currentDir, "testdata"))
 }
 
+const sparseFileSize = 64 * 1024 * 1024
+
+// createSparseFile creates a file with a few data blocks separated by holes
+func createSparseFile(t testing.TB, dir string) string {
+	require.NoError(t, os.MkdirAll(dir, 0750))
+	path := filepath.Join(dir, "disk.img")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	require.NoError(t, f.Truncate(sparseFileSize))
+	for _, offset := range []int64{0, 10 * 1024 * 1024, 10*1024*1024 + 8192, sparseFileSize / 2} {
+		_, err := f.WriteAt(bytes.Repeat([]byte{'x'}, 4096), offset)
+		require.NoError(t, err)
+	}
+	return path
+}
+
+// tarSize returns the size of the uncompressed tar stream in the archive
+func tarSize(t *testing.T, archive string) int64 {
+	f, err := os.Open(archive)
+	require.NoError(t, err)
+	defer f.Close()
+	dec, err := zstd.NewReader(f)
+	require.NoError(t, err)
+	defer dec.Close()
+	size, err := io.Copy(io.Discard, dec)
+	require.NoError(t, err)
+	return size
+}
+
+func TestCompressSparse(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "bundle")
+	sparseFile := createSparseFile(t, srcDir)
+	archive := filepath.Join(t.TempDir(), testArchiveName)
+	require.NoError(t, CompressWithOptions(srcDir, archive, Options{Level: 3, Concurrency: 2}))
+
+	// holes must not be stored in the archive
+	require.Less(t, tarSize(t, archive), int64(1024*1024))
+
+	destDir := t.TempDir()
+	fileList, err := extract.Uncompress(archive, destDir)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(destDir, "bundle", "disk.img")}, fileList)
+
+	expected, err := os.ReadFile(sparseFile)
+	require.NoError(t, err)
+	actual, err := os.ReadFile(fileList[0])
+	require.NoError(t, err)
+	require.True(t, bytes.Equal(expected, actual), "extracted sparse file content differs from the original file")
+}
+
+func TestCompressSparseHeaders(t *testing.T) {
+	srcDir := filepath.Join(t.TempDir(), "bundle")
+	createSparseFile(t, srcDir)
+	archive := filepath.Join(t.TempDir(), testArchiveName)
+	require.NoError(t, Compress(srcDir, archive))
+
+	f, err := os.Open(archive)
+	require.NoError(t, err)
+	defer f.Close()
+	dec, err := zstd.NewReader(f)
+	require.NoError(t, err)
+	defer dec.Close()
+
+	tarReader := tar.NewReader(dec)
+	var names []string
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, header.Name)
+		if header.Name == "bundle/disk.img" {
+			require.Equal(t, "1", header.PAXRecords["GNU.sparse.major"])
+			require.Equal(t, int64(sparseFileSize), header.Size)
+		}
+	}
+	require.Equal(t, []string{"bundle", "bundle/disk.img"}, names)
+}
+
+// largeSparseFileSize is above the 8GiB limit of the USTAR format
+const largeSparseFileSize = 9 * 1024 * 1024 * 1024
+
+func TestCompressLargeSparse(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping the compression of a 9GiB sparse file in short mode")
+	}
+	srcDir := filepath.Join(t.TempDir(), "bundle")
+	require.NoError(t, os.MkdirAll(srcDir, 0750))
+	f, err := os.Create(filepath.Join(srcDir, "disk.img"))
+	require.NoError(t, err)
+	require.NoError(t, f.Truncate(largeSparseFileSize))
+	data := bytes.Repeat([]byte{'x'}, 4096)
+	_, err = f.WriteAt(data, largeSparseFileSize-8192)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	archive := filepath.Join(t.TempDir(), testArchiveName)
+	require.NoError(t, Compress(srcDir, archive))
+
+	fileList, err := extract.Uncompress(archive, t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, fileList, 1)
+	extracted, err := os.Open(fileList[0])
+	require.NoError(t, err)
+	defer extracted.Close()
+	fi, err := extracted.Stat()
+	require.NoError(t, err)
+	require.Equal(t, int64(largeSparseFileSize), fi.Size())
+	actual := make([]byte, len(data))
+	_, err = extracted.ReadAt(actual, largeSparseFileSize-8192)
+	require.NoError(t, err)
+	require.Equal(t, data, actual)
+}
+
+func TestSparseHeadersAboveUSTARLimit(t *testing.T) {
+	headers, dataSize, err := sparseEntryHeaders(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     "bundle/disk.img",
+		Mode:     0644,
+		Size:     largeSparseFileSize,
+	}, []fragment{{offset: 0, length: largeSparseFileSize}})
+	require.NoError(t, err)
+	require.Equal(t, int64(largeSparseFileSize), dataSize)
+
+	header, err := tar.NewReader(bytes.NewReader(headers)).Next()
+	require.NoError(t, err)
+	require.Equal(t, "bundle/disk.img", header.Name)
+	require.Equal(t, int64(largeSparseFileSize), header.Size)
+}
+
+func BenchmarkCompressSparse(b *testing.B) {
+	srcDir := filepath.Join(b.TempDir(), "bundle")
+	createSparseFile(b, srcDir)
+	archive := filepath.Join(b.TempDir(), testArchiveName)
+	b.SetBytes(sparseFileSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		require.NoError(b, Compress(srcDir, archive))
+	}
+}
+
 /* The code below is duplicated from pkg/extract/extract_test.go */
 type fileMap map[string]string
 
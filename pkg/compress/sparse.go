@@ -0,0 +1,213 @@
+package compress
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	tarBlockSize = 512
+	// holes smaller than sparseBlockSize are not worth recording in the sparse map
+	sparseBlockSize = 4096
+	// files smaller than this are always stored densely
+	minSparseFileSize = 1024 * 1024
+	// largest size of a USTAR header, the size field has 11 octal digits
+	maxUSTARSize   = 1<<33 - 1
+	scanBufferSize = 1024 * 1024
+)
+
+var zeroBlock = make([]byte, sparseBlockSize)
+
+type fragment struct {
+	offset int64
+	length int64
+}
+
+// dataFragments scans file for blocks which only contain zeroes and returns
+// the list of regions of the file which contain actual data.
+func dataFragments(file io.ReaderAt, size int64) ([]fragment, error) {
+	var fragments []fragment
+	buf := make([]byte, scanBufferSize)
+
+	for offset := int64(0); offset < size; {
+		n, err := file.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, fmt.Errorf("unexpected end of file at offset %d", offset)
+		}
+		for i := 0; i < n; i += sparseBlockSize {
+			block := buf[i:min(i+sparseBlockSize, n)]
+			if bytes.HasPrefix(zeroBlock, block) {
+				continue
+			}
+			blockOffset := offset + int64(i)
+			last := len(fragments) - 1
+			if last >= 0 && fragments[last].offset+fragments[last].length == blockOffset {
+				fragments[last].length += int64(len(block))
+			} else {
+				fragments = append(fragments, fragment{offset: blockOffset, length: int64(len(block))})
+			}
+		}
+		offset += int64(n)
+	}
+
+	return fragments, nil
+}
+
+// writeSparseEntry writes file to out using the GNU PAX sparse format 1.0:
+// a PAX header carrying the GNU.sparse.* records, followed by a regular header
+// whose data starts with the sparse map and is followed by the data fragments.
+// See https://www.gnu.org/software/tar/manual/html_section/Sparse-Formats.html
+//
+// archive/tar cannot write sparse files (https://golang.org/issue/22735), so
+// the blocks are written directly to the underlying writer. tarWriter must be
+// flushed before calling this function.
+func writeSparseEntry(out io.Writer, header *tar.Header, file io.ReaderAt, fragments []fragment) error {
+	if len(fragments) == 0 || fragments[len(fragments)-1].offset+fragments[len(fragments)-1].length != header.Size {
+		// terminating empty fragment so that the trailing hole is recreated on extraction
+		fragments = append(fragments, fragment{offset: header.Size, length: 0})
+	}
+
+	headers, dataSize, err := sparseEntryHeaders(header, fragments)
+	if err != nil {
+		return err
+	}
+	if _, err := out.Write(headers); err != nil {
+		return err
+	}
+	for _, f := range fragments {
+		if _, err := io.Copy(out, io.NewSectionReader(file, f.offset, f.length)); err != nil {
+			return err
+		}
+	}
+	if remainder := dataSize % tarBlockSize; remainder != 0 {
+		if _, err := out.Write(make([]byte, tarBlockSize-remainder)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sparseEntryHeaders returns the blocks preceding the data fragments: the PAX
+// header, its records, the regular header and the sparse map. It also returns
+// the size of the data fragments.
+func sparseEntryHeaders(header *tar.Header, fragments []fragment) ([]byte, int64, error) {
+	var sparseMap strings.Builder
+	fmt.Fprintf(&sparseMap, "%d\n", len(fragments))
+	var dataSize int64
+	for _, f := range fragments {
+		fmt.Fprintf(&sparseMap, "%d\n%d\n", f.offset, f.length)
+		dataSize += f.length
+	}
+	sparseMapBlocks := padToBlock([]byte(sparseMap.String()))
+
+	dir, base := path.Split(header.Name)
+	sparseHeader := *header
+	sparseHeader.Name = path.Join(dir, "GNUSparseFile.0", base)
+	sparseHeader.Size = int64(len(sparseMapBlocks)) + dataSize
+	sparseHeader.PAXRecords = nil
+
+	paxRecords := [][2]string{
+		{"GNU.sparse.major", "1"},
+		{"GNU.sparse.minor", "0"},
+		{"GNU.sparse.name", header.Name},
+		{"GNU.sparse.realsize", strconv.FormatInt(header.Size, 10)},
+	}
+	if sparseHeader.Size > maxUSTARSize {
+		// the size record overrides the size of the USTAR header, which
+		// cannot encode more than 8GiB
+		paxRecords = append(paxRecords, [2]string{"size", strconv.FormatInt(sparseHeader.Size, 10)})
+		sparseHeader.Size = 0
+	}
+	var records strings.Builder
+	for _, kv := range paxRecords {
+		records.WriteString(paxRecord(kv[0], kv[1]))
+	}
+
+	paxHeader, err := headerBlock(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     path.Join(dir, "PaxHeaders.0", base),
+		Mode:     header.Mode,
+		Size:     int64(records.Len()),
+		ModTime:  header.ModTime,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	setTypeflag(paxHeader, tar.TypeXHeader)
+
+	fileHeader, err := headerBlock(&sparseHeader)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var blocks bytes.Buffer
+	for _, block := range [][]byte{paxHeader, padToBlock([]byte(records.String())), fileHeader, sparseMapBlocks} {
+		blocks.Write(block)
+	}
+	return blocks.Bytes(), dataSize, nil
+}
+
+// headerBlock returns the 512 bytes USTAR header block for header
+func headerBlock(header *tar.Header) ([]byte, error) {
+	var buf bytes.Buffer
+	ustarHeader := *header
+	ustarHeader.Format = tar.FormatUSTAR
+	ustarHeader.ModTime = header.ModTime.Round(time.Second)
+	ustarHeader.AccessTime = time.Time{}
+	ustarHeader.ChangeTime = time.Time{}
+	if err := tar.NewWriter(&buf).WriteHeader(&ustarHeader); err != nil {
+		return nil, err
+	}
+	return buf.Bytes()[:tarBlockSize], nil
+}
+
+// setTypeflag changes the type of a header block and updates its checksum
+func setTypeflag(block []byte, typeflag byte) {
+	const (
+		checksumOffset = 148
+		checksumSize   = 8
+		typeflagOffset = 156
+	)
+	block[typeflagOffset] = typeflag
+	copy(block[checksumOffset:checksumOffset+checksumSize], "        ")
+	var checksum int64
+	for _, c := range block {
+		checksum += int64(c)
+	}
+	copy(block[checksumOffset:], fmt.Sprintf("%06o\x00", checksum))
+}
+
+// paxRecord formats a PAX record, prefixed by its own length
+func paxRecord(key, value string) string {
+	const padding = 3 // ' ', '=' and '\n'
+	size := len(key) + len(value) + padding
+	size += len(strconv.Itoa(size))
+	record := fmt.Sprintf("%d %s=%s\n", size, key, value)
+	if len(record) != size {
+		// adding the length of size to size made size one digit longer
+		record = fmt.Sprintf("%d %s=%s\n", len(record), key, value)
+	}
+	return record
+}
+
+func padToBlock(data []byte) []byte {
+	if remainder := len(data) % tarBlockSize; remainder != 0 {
+		data = append(data, make([]byte, tarBlockSize-remainder)...)
+	}
+	return data
+}
+
+func isSparseCandidate(fi os.FileInfo) bool {
+	return fi.Mode().IsRegular() && fi.Size() >= minSparseFileSize
+}
@@ -19,7 +19,7 @@ import (
 
 type testClient struct {
 	apiClient.Client
-	config     crcConfig.Storage
+	config     *crcConfig.Config
 	httpServer *httptest.Server
 }
 
@@ -78,6 +78,17 @@ func TestStatus(t *testing.T) {
 	)
 }
 
+func TestStatusOperators(t *testing.T) {
+	client := newTestClient()
+	defer client.Close()
+	operatorsResult, err := client.StatusOperators()
+	assert.NoError(t, err)
+	assert.Equal(t, preset.OpenShift, operatorsResult.Preset)
+	assert.Len(t, operatorsResult.Operators, 2)
+	assert.Equal(t, "ingress", operatorsResult.Operators[1].Name)
+	assert.Equal(t, "Degraded", operatorsResult.Operators[1].Condition)
+}
+
 func TestStart(t *testing.T) {
 	client := newTestClient()
 	defer client.Close()
@@ -128,6 +139,9 @@ func TestConfigGet(t *testing.T) {
 			Configs: map[string]interface{}{
 				"cpus": float64(4),
 			},
+			Sources: map[string]string{
+				"cpus": "preset-default",
+			},
 		},
 		configGetResult,
 	)
@@ -159,6 +173,9 @@ func TestConfigSet(t *testing.T) {
 			Configs: map[string]interface{}{
 				"cpus": float64(5),
 			},
+			Sources: map[string]string{
+				"cpus": "file",
+			},
 		},
 		configGetAfterSetResult,
 	)
@@ -184,6 +201,7 @@ func TestConfigGetAll(t *testing.T) {
 	allConfigGetResult, err := client.GetConfig(nil)
 	assert.NoError(t, err)
 	configs := make(map[string]interface{})
+	sources := make(map[string]string)
 	for k, v := range client.config.AllConfigs() {
 		// since we filter out secret configs at the config api handler level
 		// we need exclude them from AllConfigs
@@ -192,6 +210,7 @@ func TestConfigGetAll(t *testing.T) {
 		}
 		// This is required because of https://pkg.go.dev/encoding/json#Unmarshal
 		// Unmarshal stores float64 for JSON numbers in case of interface.
+		sources[k] = string(client.config.Source(k))
 		switch v := v.Value.(type) {
 		case int:
 			configs[k] = float64(v)
@@ -208,6 +227,7 @@ func TestConfigGetAll(t *testing.T) {
 		t,
 		apiClient.GetConfigResult{
 			Configs: configs,
+			Sources: sources,
 		},
 		allConfigGetResult,
 	)
@@ -226,6 +246,10 @@ func TestConfigGetMultiple(t *testing.T) {
 				"cpus":   float64(4),
 				"memory": float64(10752),
 			},
+			Sources: map[string]string{
+				"cpus":   "preset-default",
+				"memory": "preset-default",
+			},
 		},
 		configGetMultiplePropertyResult,
 	)
@@ -244,6 +268,10 @@ func TestConfigGetEscaped(t *testing.T) {
 				"a&a":   "foo",
 				"b&&&b": "bar",
 			},
+			Sources: map[string]string{
+				"a&a":   "default",
+				"b&&&b": "default",
+			},
 		},
 		configGetSpecialPropertyResult,
 	)
@@ -28,6 +28,7 @@ func newServerWithRoutes(handler *Handler) *server {
 	server.POST("/poweroff", handler.PowerOff)
 
 	server.GET("/status", handler.Status)
+	server.GET("/status/operators", handler.StatusOperators)
 
 	server.DELETE("/delete", handler.Delete)
 	server.GET("/delete", handler.Delete)
@@ -53,7 +54,7 @@ func newServerWithRoutes(handler *Handler) *server {
 
 func setPullSecret() func(c *context) error {
 	return func(c *context) error {
-		if err := cluster.StoreInKeyring(string(c.requestBody)); err != nil {
+		if err := cluster.StorePullSecret(string(c.requestBody)); err != nil {
 			return err
 		}
 		return c.Code(http.StatusCreated)
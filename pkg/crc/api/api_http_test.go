@@ -238,6 +238,20 @@ var testCases = []testCase{
 		response: httpError(500).withBody("broken\n"),
 	},
 
+	// status of the cluster operators
+	{
+		request:  get("status/operators"),
+		response: jSon(`{"Preset":"openshift","Operators":[{"name":"authentication","condition":"Available","message":"AsExpected","lastTransitionTime":"2024-05-02T09:30:00Z"},{"name":"ingress","condition":"Degraded","message":"The default ingress controller reports Degraded=True","lastTransitionTime":"2024-05-02T09:45:00Z"}]}`),
+	},
+
+	// status of the cluster operators with failure
+	{
+		request:     get("status/operators"),
+		failRequest: true,
+		// error message comes from fakemachine
+		response: httpError(500).withBody("broken\n"),
+	},
+
 	// delete
 	{
 		request:  deleteRequest("delete"),
@@ -287,19 +301,23 @@ var testCases = []testCase{
 	// config
 	{
 		request:  get("config?cpus"),
-		response: jSon(`{"Configs":{"cpus":4}}`),
+		response: jSon(`{"Configs":{"cpus":4},"Sources":{"cpus":"preset-default"}}`),
 	},
 	{
 		request:  post("config?cpus").withBody("xx"),
 		response: httpError(500).withBody("invalid character 'x' looking for beginning of value\n"),
 	},
+	{
+		request:  post("config").withBody(`{"properties":{"ingress-http-port":8443,"ingress-https-port":8443}}`),
+		response: httpError(500).withBody("ingress-http-port, ingress-https-port: HTTP and HTTPS routes cannot both use port 8443\n"),
+	},
 	{
 		request:  deleteRequest("config?cpus"),
 		response: httpError(500).withBody("unexpected end of JSON input\n"),
 	},
 	{
 		request:  get("config?cpus").withBody("xx"),
-		response: jSon(`{"Configs":{"cpus":4}}`),
+		response: jSon(`{"Configs":{"cpus":4},"Sources":{"cpus":"preset-default"}}`),
 	},
 
 	// logs
@@ -366,7 +384,7 @@ var testCases = []testCase{
 	// config
 	{
 		request:  get("config?cpus"),
-		response: jSon(`{"Configs":{"cpus":4}}`),
+		response: jSon(`{"Configs":{"cpus":4},"Sources":{"cpus":"preset-default"}}`),
 	},
 }
 
@@ -13,6 +13,7 @@ import (
 type Client interface {
 	Version() (VersionResult, error)
 	Status() (ClusterStatusResult, error)
+	StatusOperators() (ClusterOperatorsResult, error)
 	Start(config StartConfig) (StartResult, error)
 	Stop() error
 	Delete() error
@@ -74,6 +75,19 @@ func (c *client) Status() (ClusterStatusResult, error) {
 	return sr, nil
 }
 
+func (c *client) StatusOperators() (ClusterOperatorsResult, error) {
+	var or = ClusterOperatorsResult{}
+	body, err := c.sendGetRequest("/status/operators")
+	if err != nil {
+		return or, err
+	}
+	err = json.Unmarshal(body, &or)
+	if err != nil {
+		return or, err
+	}
+	return or, nil
+}
+
 func (c *client) Start(config StartConfig) (StartResult, error) {
 	var sr = StartResult{}
 	var data = new(bytes.Buffer)
@@ -1,6 +1,7 @@
 package client
 
 import (
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
 	"github.com/crc-org/crc/v2/pkg/crc/preset"
@@ -30,6 +31,15 @@ type ClusterStatusResult struct {
 	PersistentVolumeUse  int `json:"PersistentVolumeUse,omitempty"`
 	PersistentVolumeSize int `json:"PersistentVolumeSize,omitempty"`
 	Preset               preset.Preset
+	PendingChanges       []types.PendingChange       `json:"PendingChanges,omitempty"`
+	ExpiringCertificates []cluster.CertificateStatus `json:"ExpiringCertificates,omitempty"`
+	EnabledCapabilities  []string                    `json:"EnabledCapabilities,omitempty"`
+	DisabledCapabilities []string                    `json:"DisabledCapabilities,omitempty"`
+}
+
+type ClusterOperatorsResult struct {
+	Preset    preset.Preset
+	Operators []cluster.OperatorCondition
 }
 
 type ConsoleResult struct {
@@ -46,6 +56,8 @@ type SetOrUnsetConfigResult struct {
 // GetConfigResult struct is used to return the result of getconfig command
 type GetConfigResult struct {
 	Configs map[string]interface{}
+	// Sources tells where the values come from: default, file, keyring, flag or env
+	Sources map[string]string
 }
 
 type StartConfig struct {
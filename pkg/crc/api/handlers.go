@@ -73,6 +73,29 @@ func (h *Handler) Status(c *context) error {
 		PersistentVolumeUse:  res.PersistentVolumeUse,
 		PersistentVolumeSize: res.PersistentVolumeSize,
 		Preset:               res.Preset,
+		PendingChanges:       res.PendingChanges,
+		ExpiringCertificates: res.ExpiringCertificates,
+		EnabledCapabilities:  res.EnabledCapabilities,
+		DisabledCapabilities: res.DisabledCapabilities,
+	})
+}
+
+func (h *Handler) StatusOperators(c *context) error {
+	exists, err := h.Client.Exists()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return c.String(http.StatusInternalServerError, string(errors.VMNotExist))
+	}
+
+	res, err := h.Client.GetClusterOperatorsStatus()
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, client.ClusterOperatorsResult{
+		Preset:    res.Preset,
+		Operators: res.Operators,
 	})
 }
 
@@ -124,6 +147,8 @@ func getStartConfig(cfg crcConfig.Storage, args client.StartConfig) types.StartC
 		Memory:                   cfg.Get(crcConfig.Memory).AsUInt(),
 		DiskSize:                 cfg.Get(crcConfig.DiskSize).AsUInt(),
 		CPUs:                     cfg.Get(crcConfig.CPUs).AsUInt(),
+		MaxCPUs:                  cfg.Get(crcConfig.MaxCPUs).AsUInt(),
+		MaxMemory:                cfg.Get(crcConfig.MaxMemory).AsUInt(),
 		NameServer:               cfg.Get(crcConfig.NameServer).AsString(),
 		PullSecret:               cluster.NewNonInteractivePullSecretLoader(cfg, args.PullSecretFile),
 		KubeAdminPassword:        cfg.Get(crcConfig.KubeAdminPassword).AsString(),
@@ -133,6 +158,8 @@ func getStartConfig(cfg crcConfig.Storage, args client.StartConfig) types.StartC
 		EnableSharedDirs:         cfg.Get(crcConfig.EnableSharedDirs).AsBool(),
 		EmergencyLogin:           cfg.Get(crcConfig.EmergencyLogin).AsBool(),
 		EnableBundleQuayFallback: cfg.Get(crcConfig.EnableBundleQuayFallback).AsBool(),
+		BundleTrustedKeys:        crcConfig.GetBundleTrustedKeys(cfg),
+		RequireSignedBundles:     cfg.Get(crcConfig.RequireSignedBundles).AsBool(),
 	}
 }
 
@@ -180,6 +207,15 @@ func (h *Handler) SetConfig(c *context) error {
 		return c.JSON(http.StatusBadRequest, client.SetOrUnsetConfigResult{})
 	}
 
+	// nothing is set when the new values are not compatible with the rest of the configuration
+	if problems := h.Config.ValidateValues(req.Properties); len(problems) != 0 {
+		var multiError = errors.MultiError{}
+		for _, problem := range problems {
+			multiError.Collect(problem)
+		}
+		return multiError
+	}
+
 	// successProps slice contains the properties that were successfully set
 	var successProps []string
 	var multiError = errors.MultiError{}
@@ -194,6 +230,7 @@ func (h *Handler) SetConfig(c *context) error {
 	if len(multiError.Errors) != 0 {
 		return multiError
 	}
+	machine.ApplyChangedResources(h.Client, successProps)
 	return c.JSON(http.StatusOK, client.SetOrUnsetConfigResult{
 		Properties: successProps,
 	})
@@ -222,6 +259,7 @@ func (h *Handler) UnsetConfig(c *context) error {
 	if len(multiError.Errors) != 0 {
 		return multiError
 	}
+	machine.ApplyChangedResources(h.Client, successProps)
 	return c.JSON(http.StatusOK, client.SetOrUnsetConfigResult{
 		Properties: successProps,
 	})
@@ -238,18 +276,22 @@ func (h *Handler) GetConfig(c *context) error {
 	if len(req.Properties) == 0 {
 		allConfigs := h.Config.AllConfigs()
 		configs := make(map[string]interface{})
+		sources := make(map[string]string)
 		for k, v := range allConfigs {
 			if v.IsSecret {
 				continue
 			}
 			configs[k] = v.Value
+			sources[k] = string(h.Config.Source(k))
 		}
 		return c.JSON(http.StatusOK, client.GetConfigResult{
 			Configs: configs,
+			Sources: sources,
 		})
 	}
 
 	configs := make(map[string]interface{})
+	sources := make(map[string]string)
 	for _, key := range req.Properties {
 		v := h.Config.Get(key)
 		if v.Invalid {
@@ -259,6 +301,7 @@ func (h *Handler) GetConfig(c *context) error {
 			continue
 		}
 		configs[key] = v.Value
+		sources[key] = string(h.Config.Source(key))
 	}
 
 	if len(configs) == 0 {
@@ -266,6 +309,7 @@ func (h *Handler) GetConfig(c *context) error {
 	}
 	return c.JSON(http.StatusOK, client.GetConfigResult{
 		Configs: configs,
+		Sources: sources,
 	})
 }
 
@@ -0,0 +1,162 @@
+package cache
+
+import (
+	"os"
+	"sort"
+	"time"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/bundle"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/docker/go-units"
+)
+
+// temporary items younger than this may still be used by a running download or extraction
+const staleTemporaryAge = 24 * time.Hour
+
+type GCPolicy struct {
+	// MaxSize is the maximum size of the cache in bytes, 0 disables the limit
+	MaxSize int64
+	// KeepVersions is the number of bundle versions which are kept for each
+	// preset, 0 keeps all of them
+	KeepVersions int
+	// KeepInUse prevents the removal of the bundles which are in use
+	KeepInUse bool
+}
+
+func (policy GCPolicy) IsEnabled() bool {
+	return policy.MaxSize > 0 || policy.KeepVersions > 0
+}
+
+// CollectGarbage removes from the cache the items which are not needed
+// according to policy, and returns them. Stale temporary items are always
+// removed. Bundles beyond the policy.KeepVersions most recent versions of
+// each preset are removed, then the oldest bundles are removed until the
+// cache is smaller than policy.MaxSize.
+func CollectGarbage(items []Item, policy GCPolicy) ([]Item, error) {
+	var removed []Item
+	remove := func(item Item) error {
+		logging.Infof("Removing %s from the cache (%s)", item.Name, units.HumanSize(float64(item.Size)))
+		if err := os.RemoveAll(item.Path); err != nil {
+			return err
+		}
+		removed = append(removed, item)
+		return nil
+	}
+
+	var kept []Item
+	for _, item := range items {
+		if item.Kind == TemporaryItem && time.Since(item.ModTime) > staleTemporaryAge {
+			if err := remove(item); err != nil {
+				return removed, err
+			}
+			continue
+		}
+		kept = append(kept, item)
+	}
+
+	if policy.KeepVersions > 0 {
+		outdated := outdatedBundles(kept, policy.KeepVersions)
+		var next []Item
+		for _, item := range kept {
+			if outdated[item.Name] && policy.canRemove(item) {
+				if err := remove(item); err != nil {
+					return removed, err
+				}
+				continue
+			}
+			next = append(next, item)
+		}
+		kept = next
+	}
+
+	if policy.MaxSize > 0 {
+		// oldest items are removed first
+		candidates := make([]Item, len(kept))
+		copy(candidates, kept)
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return candidates[i].ModTime.Before(candidates[j].ModTime)
+		})
+		size := TotalSize(kept)
+		for _, item := range candidates {
+			if size <= policy.MaxSize {
+				break
+			}
+			if !policy.canRemove(item) {
+				continue
+			}
+			if err := remove(item); err != nil {
+				return removed, err
+			}
+			size -= item.Size
+		}
+		if size > policy.MaxSize {
+			logging.Warnf("The cache uses %s, which is more than the %s limit, but no more items can be removed",
+				units.HumanSize(float64(size)), units.HumanSize(float64(policy.MaxSize)))
+		}
+	}
+
+	return removed, nil
+}
+
+func (policy GCPolicy) canRemove(item Item) bool {
+	if item.Kind != BundleItem && item.Kind != BundleArchiveItem {
+		return false
+	}
+	return !item.InUse || !policy.KeepInUse
+}
+
+type bundleVersion struct {
+	name    string
+	version *semver.Version
+	suffix  string
+}
+
+// outdatedBundles returns the names of the bundle and bundle archive items
+// which are not one of the keep most recent versions of their preset.
+// Bundles with an unexpected name are never outdated.
+func outdatedBundles(items []Item, keep int) map[string]bool {
+	versions := map[string][]bundleVersion{}
+	for _, item := range items {
+		if item.Kind != BundleItem && item.Kind != BundleArchiveItem {
+			continue
+		}
+		bundleName := bundle.GetBundleNameWithExtension(item.Name)
+		info, err := bundle.GetBundleInfoFromName(bundleName)
+		if err != nil {
+			continue
+		}
+		version, err := semver.NewVersion(info.Version)
+		if err != nil {
+			continue
+		}
+		key := info.Preset.String() + "_" + info.Driver + "_" + info.Arch
+		versions[key] = append(versions[key], bundleVersion{
+			name:    bundle.GetBundleNameWithoutExtension(item.Name),
+			version: version,
+			suffix:  info.CustomBundleSuffix,
+		})
+	}
+
+	outdated := map[string]bool{}
+	for _, bundles := range versions {
+		sort.SliceStable(bundles, func(i, j int) bool {
+			if !bundles[i].version.Equal(bundles[j].version) {
+				return bundles[i].version.GreaterThan(bundles[j].version)
+			}
+			return bundles[i].suffix > bundles[j].suffix
+		})
+		var seen []string
+		for _, b := range bundles {
+			if !crcstrings.Contains(seen, b.name) {
+				seen = append(seen, b.name)
+			}
+			if len(seen) > keep {
+				outdated[b.name] = true
+				outdated[bundle.GetBundleNameWithExtension(b.name)] = true
+			}
+		}
+	}
+	return outdated
+}
@@ -0,0 +1,137 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createBundle(t *testing.T, cacheDir, name string, size int, modTime time.Time) {
+	dir := filepath.Join(cacheDir, name)
+	require.NoError(t, os.Mkdir(dir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crc-bundle-info.json"), []byte("{}"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "crc.qcow2"), make([]byte, size-2), 0600))
+	require.NoError(t, os.Chtimes(dir, modTime, modTime))
+}
+
+func createFile(t *testing.T, path string, size int, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, make([]byte, size), 0600))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func testCache(t *testing.T) (string, string) {
+	cacheDir := t.TempDir()
+	binDir := t.TempDir()
+	now := time.Now()
+
+	createBundle(t, cacheDir, "crc_libvirt_4.15.2_amd64", 100, now.Add(-3*time.Hour))
+	createFile(t, filepath.Join(cacheDir, "crc_libvirt_4.15.2_amd64.crcbundle"), 50, now.Add(-3*time.Hour))
+	createBundle(t, cacheDir, "crc_libvirt_4.16.1_amd64", 200, now.Add(-2*time.Hour))
+	createBundle(t, cacheDir, "crc_libvirt_4.16.7_amd64", 300, now.Add(-time.Hour))
+	createBundle(t, cacheDir, "crc_microshift_libvirt_4.15.2_amd64", 400, now.Add(-4*time.Hour))
+	require.NoError(t, os.Mkdir(filepath.Join(cacheDir, "tmp-extract-crc_libvirt_4.16.8_amd64"), 0755))
+	createFile(t, filepath.Join(cacheDir, "tmp-extract-crc_libvirt_4.16.8_amd64", "crc.qcow2"), 10, now)
+	require.NoError(t, os.Chtimes(filepath.Join(cacheDir, "tmp-extract-crc_libvirt_4.16.8_amd64"), now.Add(-48*time.Hour), now.Add(-48*time.Hour)))
+	createFile(t, filepath.Join(binDir, "crc-admin-helper-linux"), 20, now)
+
+	return cacheDir, binDir
+}
+
+func names(items []Item) []string {
+	var names []string
+	for _, item := range items {
+		names = append(names, item.Name)
+	}
+	return names
+}
+
+func TestUsage(t *testing.T) {
+	cacheDir, binDir := testCache(t)
+
+	items, err := Usage(cacheDir, binDir, []string{"crc_libvirt_4.15.2_amd64.crcbundle"})
+	require.NoError(t, err)
+	assert.Equal(t, []Item{
+		{Name: "crc_microshift_libvirt_4.15.2_amd64", Kind: BundleItem, Size: 400},
+		{Name: "crc_libvirt_4.16.7_amd64", Kind: BundleItem, Size: 300},
+		{Name: "crc_libvirt_4.16.1_amd64", Kind: BundleItem, Size: 200},
+		{Name: "crc_libvirt_4.15.2_amd64", Kind: BundleItem, Size: 100, InUse: true},
+		{Name: "crc_libvirt_4.15.2_amd64.crcbundle", Kind: BundleArchiveItem, Size: 50},
+		{Name: "crc-admin-helper-linux", Kind: ExecutableItem, Size: 20, InUse: true},
+		{Name: "tmp-extract-crc_libvirt_4.16.8_amd64", Kind: TemporaryItem, Size: 10},
+	}, withoutPathAndTime(items))
+	assert.Equal(t, int64(1080), TotalSize(items))
+}
+
+func withoutPathAndTime(items []Item) []Item {
+	var ret []Item
+	for _, item := range items {
+		item.Path = ""
+		item.ModTime = time.Time{}
+		ret = append(ret, item)
+	}
+	return ret
+}
+
+func TestCollectGarbageKeepVersions(t *testing.T) {
+	cacheDir, binDir := testCache(t)
+	items, err := Usage(cacheDir, binDir, []string{"crc_libvirt_4.15.2_amd64"})
+	require.NoError(t, err)
+
+	removed, err := CollectGarbage(items, GCPolicy{KeepVersions: 1, KeepInUse: true})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		"tmp-extract-crc_libvirt_4.16.8_amd64",
+		"crc_libvirt_4.16.1_amd64",
+		"crc_libvirt_4.15.2_amd64.crcbundle",
+	}, names(removed))
+
+	entries, err := os.ReadDir(cacheDir)
+	require.NoError(t, err)
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	assert.ElementsMatch(t, []string{
+		"crc_libvirt_4.15.2_amd64",
+		"crc_libvirt_4.16.7_amd64",
+		"crc_microshift_libvirt_4.15.2_amd64",
+	}, remaining)
+}
+
+func TestCollectGarbageMaxSize(t *testing.T) {
+	cacheDir, binDir := testCache(t)
+	items, err := Usage(cacheDir, binDir, []string{"crc_libvirt_4.16.7_amd64"})
+	require.NoError(t, err)
+
+	removed, err := CollectGarbage(items, GCPolicy{MaxSize: 500, KeepInUse: true})
+	require.NoError(t, err)
+	// the oldest items are removed first, until the cache is smaller than 500 bytes
+	assert.Equal(t, []string{
+		"tmp-extract-crc_libvirt_4.16.8_amd64",
+		"crc_microshift_libvirt_4.15.2_amd64",
+		"crc_libvirt_4.15.2_amd64",
+		"crc_libvirt_4.15.2_amd64.crcbundle",
+		"crc_libvirt_4.16.1_amd64",
+	}, names(removed))
+}
+
+func TestCollectGarbageInUse(t *testing.T) {
+	cacheDir, binDir := testCache(t)
+	items, err := Usage(cacheDir, binDir, []string{"crc_libvirt_4.16.7_amd64"})
+	require.NoError(t, err)
+
+	removed, err := CollectGarbage(items, GCPolicy{MaxSize: 1, KeepInUse: true})
+	require.NoError(t, err)
+	assert.NotContains(t, names(removed), "crc_libvirt_4.16.7_amd64")
+	assert.NotContains(t, names(removed), "crc-admin-helper-linux")
+
+	items, err = Usage(cacheDir, binDir, []string{"crc_libvirt_4.16.7_amd64"})
+	require.NoError(t, err)
+	removed, err = CollectGarbage(items, GCPolicy{MaxSize: 1, KeepInUse: false})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"crc_libvirt_4.16.7_amd64"}, names(removed))
+}
@@ -0,0 +1,148 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/bundle"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+)
+
+type ItemKind string
+
+const (
+	// BundleItem is a bundle extracted in the cache directory
+	BundleItem ItemKind = "bundle"
+	// BundleArchiveItem is a compressed .crcbundle file
+	BundleArchiveItem ItemKind = "bundle-archive"
+	// ExecutableItem is a helper executable such as admin-helper
+	ExecutableItem ItemKind = "executable"
+	// TemporaryItem is left over by an interrupted download or extraction
+	TemporaryItem ItemKind = "temporary"
+	OtherItem     ItemKind = "other"
+)
+
+// prefixes of the temporary directories created in the cache directory
+var temporaryPrefixes = []string{
+	"tmp-extract",
+	"tmpBundleImage",
+	"crc_custom_bundle",
+	"crc_delta_bundle",
+}
+
+type Item struct {
+	Name    string    `json:"name"`
+	Path    string    `json:"path"`
+	Kind    ItemKind  `json:"kind"`
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"modTime"`
+	InUse   bool      `json:"inUse"`
+}
+
+// Usage returns the items stored in cacheDir and binDir along with their
+// size, largest first. inUseBundles are the names of the bundles which are
+// used by the instance or by the configuration.
+func Usage(cacheDir, binDir string, inUseBundles []string) ([]Item, error) {
+	var inUse []string
+	for _, bundleName := range inUseBundles {
+		inUse = append(inUse, bundle.GetBundleNameWithoutExtension(bundleName))
+	}
+
+	items, err := listItems(cacheDir, func(path string, fi os.FileInfo) Item {
+		item := Item{Kind: kindOf(path, fi)}
+		if item.Kind == BundleItem {
+			item.InUse = crcstrings.Contains(inUse, fi.Name())
+		}
+		return item
+	})
+	if err != nil {
+		return nil, err
+	}
+	executables, err := listItems(binDir, func(_ string, _ os.FileInfo) Item {
+		// executables are needed by crc and are never collected
+		return Item{Kind: ExecutableItem, InUse: true}
+	})
+	if err != nil {
+		return nil, err
+	}
+	items = append(items, executables...)
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].Size > items[j].Size
+	})
+	return items, nil
+}
+
+// TotalSize returns the sum of the sizes of items
+func TotalSize(items []Item) int64 {
+	var size int64
+	for _, item := range items {
+		size += item.Size
+	}
+	return size
+}
+
+func listItems(dir string, newItem func(string, os.FileInfo) Item) ([]Item, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var items []Item
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		fi, err := os.Lstat(path)
+		if err != nil {
+			return nil, err
+		}
+		size, err := diskUsage(path)
+		if err != nil {
+			return nil, err
+		}
+		item := newItem(path, fi)
+		item.Name = entry.Name()
+		item.Path = path
+		item.Size = size
+		item.ModTime = fi.ModTime()
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+func kindOf(path string, fi os.FileInfo) ItemKind {
+	for _, prefix := range temporaryPrefixes {
+		if strings.HasPrefix(fi.Name(), prefix) {
+			return TemporaryItem
+		}
+	}
+	if fi.IsDir() {
+		if _, err := os.Stat(filepath.Join(path, "crc-bundle-info.json")); err == nil {
+			return BundleItem
+		}
+		return OtherItem
+	}
+	if strings.HasSuffix(fi.Name(), ".crcbundle") {
+		return BundleArchiveItem
+	}
+	return OtherItem
+}
+
+// diskUsage returns the size of the files in path, symlinks are not followed
+func diskUsage(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.Mode().IsRegular() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
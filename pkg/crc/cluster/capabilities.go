@@ -0,0 +1,270 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	v1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type workload struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+func (w workload) scale(ocConfig oc.Config, replicas int) error {
+	_, stderr, err := ocConfig.RunOcCommand("scale", "--replicas", strconv.Itoa(replicas),
+		"--namespace", w.namespace, fmt.Sprintf("%s/%s", w.kind, w.name))
+	if err != nil {
+		return fmt.Errorf("Failed to scale %s/%s %v: %s", w.kind, w.name, err, stderr)
+	}
+	return nil
+}
+
+// capability is an optional component of the cluster. It is disabled by
+// making the cluster version operator stop managing its operator, and by
+// scaling down the operator and its operands. The default catalog sources
+// of OLM are disabled in the OperatorHub configuration instead.
+type capability struct {
+	name                  string
+	operator              *workload
+	clusterOperator       string
+	operands              []workload
+	defaultCatalogSources bool
+}
+
+var capabilities = []capability{
+	{
+		name:            crcConfig.CapabilityMonitoring,
+		operator:        &workload{"deployment", "openshift-monitoring", "cluster-monitoring-operator"},
+		clusterOperator: "monitoring",
+		operands: []workload{
+			{"statefulset", "openshift-monitoring", "prometheus-k8s"},
+			{"statefulset", "openshift-monitoring", "alertmanager-main"},
+			{"deployment", "openshift-monitoring", "prometheus-operator"},
+			{"deployment", "openshift-monitoring", "thanos-querier"},
+			{"deployment", "openshift-monitoring", "kube-state-metrics"},
+		},
+	},
+	{
+		name:            crcConfig.CapabilityConsole,
+		operator:        &workload{"deployment", "openshift-console-operator", "console-operator"},
+		clusterOperator: "console",
+		operands: []workload{
+			{"deployment", "openshift-console", "console"},
+			{"deployment", "openshift-console", "downloads"},
+		},
+	},
+	{
+		name:            crcConfig.CapabilityImageRegistry,
+		operator:        &workload{"deployment", "openshift-image-registry", "cluster-image-registry-operator"},
+		clusterOperator: "image-registry",
+		operands: []workload{
+			{"deployment", "openshift-image-registry", "image-registry"},
+		},
+	},
+	{
+		name:                  crcConfig.CapabilityOLMCatalogs,
+		defaultCatalogSources: true,
+	},
+	{
+		name:            crcConfig.CapabilityInsights,
+		operator:        &workload{"deployment", "openshift-insights", "insights-operator"},
+		clusterOperator: "insights",
+	},
+	{
+		name:            crcConfig.CapabilitySamples,
+		operator:        &workload{"deployment", "openshift-cluster-samples-operator", "cluster-samples-operator"},
+		clusterOperator: "openshift-samples",
+	},
+}
+
+func (c capability) overrides() []v1.ComponentOverride {
+	if c.operator == nil {
+		return nil
+	}
+	return []v1.ComponentOverride{
+		{
+			Kind:      "Deployment",
+			Group:     "apps",
+			Namespace: c.operator.namespace,
+			Name:      c.operator.name,
+			Unmanaged: true,
+		},
+		{
+			Kind:      "ClusterOperator",
+			Group:     "config.openshift.io",
+			Name:      c.clusterOperator,
+			Unmanaged: true,
+		},
+	}
+}
+
+func getCapabilities(names []string) []capability {
+	var ret []capability
+	for _, c := range capabilities {
+		for _, name := range names {
+			if c.name == name {
+				ret = append(ret, c)
+			}
+		}
+	}
+	return ret
+}
+
+func sameComponent(a, b v1.ComponentOverride) bool {
+	return a.Kind == b.Kind && a.Namespace == b.Namespace && a.Name == b.Name
+}
+
+// reconcileOverrides returns the ClusterVersion overrides without the ones of
+// the enabled capabilities, and with the ones of the disabled capabilities
+func reconcileOverrides(current []v1.ComponentOverride, enabled, disabled []capability) []v1.ComponentOverride {
+	var reconciled []v1.ComponentOverride
+	var toDisable []v1.ComponentOverride
+	for _, c := range disabled {
+		toDisable = append(toDisable, c.overrides()...)
+	}
+	var toEnable []v1.ComponentOverride
+	for _, c := range enabled {
+		toEnable = append(toEnable, c.overrides()...)
+	}
+	for _, override := range current {
+		keep := true
+		for _, other := range append(toEnable, toDisable...) {
+			if sameComponent(override, other) {
+				keep = false
+			}
+		}
+		if keep {
+			reconciled = append(reconciled, override)
+		}
+	}
+	return append(reconciled, toDisable...)
+}
+
+// ReconcileCapabilities enables and disables the optional components of the
+// cluster, the components which are not listed are left untouched
+func ReconcileCapabilities(ocConfig oc.Config, enabledNames, disabledNames []string) error {
+	enabled := getCapabilities(enabledNames)
+	disabled := getCapabilities(disabledNames)
+
+	data, stderr, err := ocConfig.RunOcCommand("get", "clusterversion/version", "-o", "json")
+	if err != nil {
+		return fmt.Errorf("%s:%v", stderr, err)
+	}
+	var cv v1.ClusterVersion
+	if err := json.Unmarshal([]byte(data), &cv); err != nil {
+		return err
+	}
+	overrides := reconcileOverrides(cv.Spec.Overrides, enabled, disabled)
+	if !reflect.DeepEqual(overrides, cv.Spec.Overrides) {
+		patch, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"overrides": overrides,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if _, stderr, err := ocConfig.RunOcCommand("patch", "clusterversion/version", "--type", "merge", "--patch", fmt.Sprintf("'%s'", patch)); err != nil {
+			return fmt.Errorf("Failed to update the cluster version overrides %v: %s", err, stderr)
+		}
+	}
+
+	for _, c := range enabled {
+		logging.Debugf("Enabling %s", c.name)
+		if err := c.enable(ocConfig); err != nil {
+			return err
+		}
+	}
+	for _, c := range disabled {
+		logging.Debugf("Disabling %s", c.name)
+		if err := c.disable(ocConfig); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// enable scales up the operator, which scales up its operands
+func (c capability) enable(ocConfig oc.Config) error {
+	if c.defaultCatalogSources {
+		return setDefaultCatalogSources(ocConfig, true)
+	}
+	if err := c.operator.scale(ocConfig, 1); err != nil {
+		logging.Warnf("Cannot enable %s: %v", c.name, err)
+	}
+	return nil
+}
+
+// disable scales down the operator first, so that it does not scale up its operands
+func (c capability) disable(ocConfig oc.Config) error {
+	if c.defaultCatalogSources {
+		return setDefaultCatalogSources(ocConfig, false)
+	}
+	for _, w := range append([]workload{*c.operator}, c.operands...) {
+		if err := w.scale(ocConfig, 0); err != nil {
+			logging.Warnf("Cannot disable %s: %v", c.name, err)
+		}
+	}
+	return nil
+}
+
+func setDefaultCatalogSources(ocConfig oc.Config, enabled bool) error {
+	patch := fmt.Sprintf(`'{"spec":{"disableAllDefaultSources":%t}}'`, !enabled)
+	if _, stderr, err := ocConfig.RunOcCommand("patch", "operatorhub/cluster", "--type", "merge", "--patch", patch); err != nil {
+		return fmt.Errorf("Failed to update the OperatorHub configuration %v: %s", err, stderr)
+	}
+	return nil
+}
+
+// GetClusterCapabilities returns the enabled and the disabled optional components of the cluster
+func GetClusterCapabilities(ctx context.Context, ip string, kubeconfigFilePath string) ([]string, []string, error) {
+	client, err := openshiftClient(ip, kubeconfigFilePath)
+	if err != nil {
+		return nil, nil, err
+	}
+	cv, err := client.ConfigV1().ClusterVersions().Get(ctx, "version", metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	hub, err := client.ConfigV1().OperatorHubs().Get(ctx, "cluster", metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, err
+	}
+	enabled, disabled := capabilitiesState(cv.Spec.Overrides, hub.Spec.DisableAllDefaultSources)
+	return enabled, disabled, nil
+}
+
+func capabilitiesState(overrides []v1.ComponentOverride, disableAllDefaultSources bool) ([]string, []string) {
+	var enabled, disabled []string
+	for _, c := range capabilities {
+		isDisabled := disableAllDefaultSources
+		if !c.defaultCatalogSources {
+			operatorOverride := c.overrides()[0]
+			isDisabled = false
+			for _, override := range overrides {
+				if sameComponent(override, operatorOverride) && override.Unmanaged {
+					isDisabled = true
+				}
+			}
+		}
+		if isDisabled {
+			disabled = append(disabled, c.name)
+		} else {
+			enabled = append(enabled, c.name)
+		}
+	}
+	sort.Strings(enabled)
+	sort.Strings(disabled)
+	return enabled, disabled
+}
@@ -0,0 +1,41 @@
+package cluster
+
+import (
+	"testing"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	v1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+// bundleOverrides are the overrides of the bundles, monitoring is disabled
+var bundleOverrides = []v1.ComponentOverride{
+	{Kind: "Deployment", Group: "apps", Namespace: "openshift-monitoring", Name: "cluster-monitoring-operator", Unmanaged: true},
+	{Kind: "ClusterOperator", Group: "config.openshift.io", Name: "monitoring", Unmanaged: true},
+	{Kind: "Deployment", Group: "apps", Namespace: "openshift-machine-api", Name: "machine-api-operator", Unmanaged: true},
+}
+
+func TestReconcileOverrides(t *testing.T) {
+	overrides := reconcileOverrides(bundleOverrides,
+		getCapabilities([]string{crcConfig.CapabilityMonitoring}),
+		getCapabilities([]string{crcConfig.CapabilityConsole, crcConfig.CapabilityOLMCatalogs}))
+	assert.Equal(t, []v1.ComponentOverride{
+		{Kind: "Deployment", Group: "apps", Namespace: "openshift-machine-api", Name: "machine-api-operator", Unmanaged: true},
+		{Kind: "Deployment", Group: "apps", Namespace: "openshift-console-operator", Name: "console-operator", Unmanaged: true},
+		{Kind: "ClusterOperator", Group: "config.openshift.io", Name: "console", Unmanaged: true},
+	}, overrides)
+
+	// nothing changes when the capabilities are already in the expected state
+	assert.Equal(t, bundleOverrides[2:], reconcileOverrides(bundleOverrides[2:],
+		getCapabilities([]string{crcConfig.CapabilityMonitoring}), nil))
+}
+
+func TestCapabilitiesState(t *testing.T) {
+	enabled, disabled := capabilitiesState(bundleOverrides, false)
+	assert.Equal(t, []string{"console", "image-registry", "insights", "olm-catalogs", "samples"}, enabled)
+	assert.Equal(t, []string{"monitoring"}, disabled)
+
+	enabled, disabled = capabilitiesState(nil, true)
+	assert.Equal(t, []string{"console", "image-registry", "insights", "monitoring", "samples"}, enabled)
+	assert.Equal(t, []string{"olm-catalogs"}, disabled)
+}
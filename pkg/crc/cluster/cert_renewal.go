@@ -13,6 +13,11 @@ import (
 	k8scerts "k8s.io/api/certificates/v1beta1"
 )
 
+const (
+	kubeletClientSignerName  = "kubernetes.io/kube-apiserver-client-kubelet"
+	kubeletServingSignerName = "kubernetes.io/kubelet-serving"
+)
+
 func isPending(csr *k8scerts.CertificateSigningRequest) bool {
 	return len(csr.Status.Conditions) == 0 && len(csr.Status.Certificate) == 0
 }
@@ -44,11 +49,6 @@ func approvePendingCSRs(ctx context.Context, ocConfig oc.Config, expectedSignerN
 }
 
 func ApproveCSRAndWaitForCertsRenewal(ctx context.Context, sshRunner *ssh.Runner, ocConfig oc.Config, client, server, aggregratorClient bool) error {
-	const (
-		kubeletClientSignerName  = "kubernetes.io/kube-apiserver-client-kubelet"
-		kubeletServingSignerName = "kubernetes.io/kubelet-serving"
-	)
-
 	// First, kubelet starts and tries to connect to API server. If its certificate is expired, it asks for a new one
 	// Admin needs to approve it. The Kubernetes controller manager will then issue the cert, kubelet will fetch it and use it.
 	// Kubelet stores the cert in /var/lib/kubelet/pki/kubelet-client-current.pem
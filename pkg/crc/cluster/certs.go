@@ -0,0 +1,161 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	crcerrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	"github.com/crc-org/crc/v2/pkg/crc/systemd"
+)
+
+const (
+	APIServingCert = "/etc/kubernetes/static-pod-resources/kube-apiserver-certs/secrets/external-loadbalancer-serving-certkey/tls.crt"
+
+	// notAfterLayout is the format of the dates printed by 'openssl x509 -enddate'
+	notAfterLayout = "Jan _2 15:04:05 2006 MST"
+)
+
+// certificate is a certificate of the cluster. It is read from a file of the
+// VM, or from a TLS endpoint when it is only stored in the cluster.
+type certificate struct {
+	name       string
+	path       string
+	endpoint   string
+	serverName string
+	// secret is the namespace/name of the secret which an operator
+	// regenerates when it is deleted
+	secret string
+}
+
+var certificates = []certificate{
+	{name: "kubelet-client", path: KubeletClientCert},
+	{name: "kubelet-server", path: KubeletServerCert},
+	{name: "aggregator-client", path: AggregatorClientCert, secret: "openshift-kube-apiserver/aggregator-client"},
+	{name: "api-serving", path: APIServingCert, secret: "openshift-kube-apiserver/external-loadbalancer-serving-certkey"},
+	{name: "ingress", endpoint: "127.0.0.1:443", serverName: "console-openshift-console" + constants.AppsDomain, secret: "openshift-ingress/router-certs-default"},
+}
+
+func (c certificate) command() string {
+	if c.path != "" {
+		return fmt.Sprintf("sudo openssl x509 -in %s -noout -enddate", c.path)
+	}
+	return fmt.Sprintf("openssl s_client -connect %s -servername %s </dev/null 2>/dev/null | openssl x509 -noout -enddate", c.endpoint, c.serverName)
+}
+
+// CertificateStatus is the expiry date of a certificate of the cluster, or
+// the reason why it could not be read
+type CertificateStatus struct {
+	Name     string     `json:"name"`
+	Path     string     `json:"path,omitempty"`
+	NotAfter *time.Time `json:"notAfter,omitempty"`
+	Error    string     `json:"error,omitempty"`
+}
+
+// ExpiresWithin returns true when the certificate expires in less than duration, or has expired
+func (c CertificateStatus) ExpiresWithin(duration time.Duration) bool {
+	return c.NotAfter != nil && c.NotAfter.Before(time.Now().Add(duration))
+}
+
+func GetCertificatesStatus(sshRunner *ssh.Runner) []CertificateStatus {
+	var statuses []CertificateStatus
+	for _, cert := range certificates {
+		status := CertificateStatus{
+			Name: cert.name,
+			Path: cert.path,
+		}
+		notAfter, err := certNotAfter(sshRunner, cert)
+		if err != nil {
+			status.Error = err.Error()
+		} else {
+			status.NotAfter = &notAfter
+		}
+		statuses = append(statuses, status)
+	}
+	return statuses
+}
+
+func certNotAfter(sshRunner *ssh.Runner, cert certificate) (time.Time, error) {
+	output, _, err := sshRunner.Run(cert.command())
+	if err != nil {
+		return time.Time{}, err
+	}
+	return parseNotAfter(output)
+}
+
+func parseNotAfter(output string) (time.Time, error) {
+	date, ok := strings.CutPrefix(strings.TrimSpace(output), "notAfter=")
+	if !ok {
+		return time.Time{}, fmt.Errorf("unexpected openssl output: %q", output)
+	}
+	return time.Parse(notAfterLayout, date)
+}
+
+// RenewCertificates forces the renewal of the kubelet certificates, and of
+// the certificates the cluster operators regenerate, then waits for the new
+// certificates to be in use
+func RenewCertificates(ctx context.Context, sshRunner *ssh.Runner, ocConfig oc.Config) error {
+	previous := map[string]*time.Time{}
+	for _, status := range GetCertificatesStatus(sshRunner) {
+		previous[status.Name] = status.NotAfter
+	}
+
+	var renewed []certificate
+	for _, cert := range certificates {
+		if cert.secret == "" {
+			renewed = append(renewed, cert)
+			continue
+		}
+		namespace, name, _ := strings.Cut(cert.secret, "/")
+		stdout, stderr, err := ocConfig.RunOcCommand("delete", "secret", "--namespace", namespace, name, "--ignore-not-found", "--output", "name")
+		if err != nil {
+			return fmt.Errorf("Cannot delete secret %s (%v : %s)", cert.secret, err, stderr)
+		}
+		if strings.TrimSpace(stdout) == "" {
+			logging.Debugf("Secret %s does not exist, %s certificate is not managed by the cluster", cert.secret, cert.name)
+			continue
+		}
+		renewed = append(renewed, cert)
+	}
+
+	logging.Info("Renewing the kubelet certificates...")
+	if _, _, err := sshRunner.RunPrivileged("Removing the kubelet certificates", "rm", "-f", KubeletClientCert, KubeletServerCert); err != nil {
+		return err
+	}
+	if err := systemd.NewInstanceSystemdCommander(sshRunner).Restart("kubelet"); err != nil {
+		return err
+	}
+	if err := approvePendingCSRs(ctx, ocConfig, kubeletClientSignerName); err != nil {
+		return err
+	}
+	if err := approvePendingCSRs(ctx, ocConfig, kubeletServingSignerName); err != nil {
+		return err
+	}
+
+	for _, cert := range renewed {
+		logging.Infof("Waiting for the renewal of the %s certificate... [will take up to 10 minutes]", cert.name)
+		if err := crcerrors.Retry(ctx, 10*time.Minute, waitForCertUpdate(sshRunner, cert, previous[cert.name]), 5*time.Second); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForCertUpdate waits for cert to expire after previous
+func waitForCertUpdate(sshRunner *ssh.Runner, cert certificate, previous *time.Time) func() error {
+	return func() error {
+		notAfter, err := certNotAfter(sshRunner, cert)
+		if err != nil {
+			return &crcerrors.RetriableError{Err: err}
+		}
+		if previous == nil || notAfter.After(*previous) {
+			return nil
+		}
+		return &crcerrors.RetriableError{Err: fmt.Errorf("certificate %s has not been renewed yet", cert.name)}
+	}
+}
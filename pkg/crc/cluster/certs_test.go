@@ -0,0 +1,33 @@
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseNotAfter(t *testing.T) {
+	notAfter, err := parseNotAfter("notAfter=Jun  5 10:04:12 2025 GMT\n")
+	require.NoError(t, err)
+	assert.True(t, notAfter.Equal(time.Date(2025, time.June, 5, 10, 4, 12, 0, time.UTC)))
+
+	notAfter, err = parseNotAfter("notAfter=Dec 15 08:00:00 2024 GMT")
+	require.NoError(t, err)
+	assert.True(t, notAfter.Equal(time.Date(2024, time.December, 15, 8, 0, 0, 0, time.UTC)))
+
+	_, err = parseNotAfter("Could not open file or uri for loading certificate")
+	assert.Error(t, err)
+}
+
+func TestCertificateExpiresWithin(t *testing.T) {
+	inTwoDays := time.Now().Add(48 * time.Hour)
+	cert := CertificateStatus{Name: "kubelet-client", NotAfter: &inTwoDays}
+	assert.True(t, cert.ExpiresWithin(7*24*time.Hour))
+	assert.False(t, cert.ExpiresWithin(24*time.Hour))
+
+	expired := time.Now().Add(-time.Hour)
+	assert.True(t, CertificateStatus{Name: "ingress", NotAfter: &expired}.ExpiresWithin(0))
+	assert.False(t, CertificateStatus{Name: "ingress", Error: "connection refused"}.ExpiresWithin(7*24*time.Hour))
+}
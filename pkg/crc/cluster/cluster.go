@@ -48,11 +48,7 @@ func CheckCertsValidity(sshRunner *ssh.Runner) (map[string]bool, error) {
 }
 
 func checkCertValidity(sshRunner *ssh.Runner, cert string) (bool, error) {
-	output, _, err := sshRunner.Run(fmt.Sprintf(`date --date="$(sudo openssl x509 -in %s -noout -enddate | cut -d= -f 2)" --iso-8601=seconds`, cert))
-	if err != nil {
-		return false, err
-	}
-	expiryDate, err := time.Parse(time.RFC3339, strings.TrimSpace(output))
+	expiryDate, err := certNotAfter(sshRunner, certificate{path: cert})
 	if err != nil {
 		return false, err
 	}
@@ -202,12 +198,17 @@ func EnsurePullSecretPresentInTheCluster(ctx context.Context, ocConfig oc.Config
 	if err != nil {
 		return err
 	}
-	base64OfPullSec := base64.StdEncoding.EncodeToString([]byte(content))
+	return UpdatePullSecretInTheCluster(ocConfig, content)
+}
+
+// UpdatePullSecretInTheCluster replaces the pull secret of the cluster
+func UpdatePullSecretInTheCluster(ocConfig oc.Config, pullSecret string) error {
+	base64OfPullSec := base64.StdEncoding.EncodeToString([]byte(pullSecret))
 	cmdArgs := []string{"patch", "secret", "pull-secret", "-p",
 		fmt.Sprintf(`'{"data":{".dockerconfigjson":"%s"}}'`, base64OfPullSec),
 		"-n", "openshift-config", "--type", "merge"}
 
-	_, stderr, err = ocConfig.RunOcCommandPrivate(cmdArgs...)
+	_, stderr, err := ocConfig.RunOcCommandPrivate(cmdArgs...)
 	if err != nil {
 		return fmt.Errorf("Failed to add Pull secret %v: %s", err, stderr)
 	}
@@ -33,6 +33,7 @@ type Status struct {
 	progressing []string
 	degraded    []string
 	unavailable []string
+	disabled    []string
 }
 
 const maxNames = 5
@@ -82,15 +83,32 @@ func (status *Status) IsReady() bool {
 	return status.Available && !status.Progressing && !status.Degraded && !status.Disabled
 }
 
+// NotReady returns the sorted names of the operators which are progressing,
+// degraded, unavailable or disabled
+func (status *Status) NotReady() []string {
+	var names []string
+	for _, list := range [][]string{status.progressing, status.degraded, status.unavailable, status.disabled} {
+		for _, name := range list {
+			if !crcstrings.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
 func GetClusterOperatorsStatus(ctx context.Context, ip string, kubeconfigFilePath string) (*Status, error) {
 	lister, err := openshiftClient(ip, kubeconfigFilePath)
 	if err != nil {
 		return nil, err
 	}
-	return getStatus(ctx, lister.ConfigV1().ClusterOperators(), []string{})
+	return getStatus(ctx, lister.ConfigV1().ClusterOperators(), []string{}, []string{})
 }
 
-func getStatus(ctx context.Context, lister operatorLister, selector []string) (*Status, error) {
+// getStatus aggregates the conditions of the operators in selector, or of all
+// the operators when it is empty, except the ignored ones
+func getStatus(ctx context.Context, lister operatorLister, selector []string, ignored []string) (*Status, error) {
 	cs := &Status{
 		Available: true,
 	}
@@ -105,6 +123,9 @@ func getStatus(ctx context.Context, lister operatorLister, selector []string) (*
 		if len(selector) > 0 && !crcstrings.Contains(selector, c.ObjectMeta.Name) {
 			continue
 		}
+		if crcstrings.Contains(ignored, c.ObjectMeta.Name) {
+			continue
+		}
 		found = true
 		for _, con := range c.Status.Conditions {
 			switch con.Type {
@@ -133,6 +154,7 @@ func getStatus(ctx context.Context, lister operatorLister, selector []string) (*
 			case "Disabled": // non official status, used by insights and cluster baremetal operators
 				if con.Status == openshiftapi.ConditionTrue {
 					logging.Debug(c.ObjectMeta.Name, " operator is disabled, Reason: ", con.Reason)
+					cs.disabled = append(cs.disabled, c.ObjectMeta.Name)
 					cs.Disabled = true
 				}
 			case "ManagementStateDegraded": // only for the network operator
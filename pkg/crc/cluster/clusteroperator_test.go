@@ -6,9 +6,11 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	v1 "github.com/openshift/api/config/v1"
 	"github.com/stretchr/testify/assert"
+	k8sapi "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -24,13 +26,13 @@ var (
 )
 
 func TestGetClusterOperatorsStatus(t *testing.T) {
-	status, err := getStatus(context.Background(), lister("co.json"), []string{})
+	status, err := getStatus(context.Background(), lister("co.json"), []string{}, []string{})
 	assert.NoError(t, err)
 	assert.Equal(t, available, status)
 }
 
 func TestGetClusterOperatorsStatusProgressing(t *testing.T) {
-	status, err := getStatus(context.Background(), lister("co-progressing.json"), []string{})
+	status, err := getStatus(context.Background(), lister("co-progressing.json"), []string{}, []string{})
 	assert.NoError(t, err)
 	assert.Equal(t, progressing, status)
 }
@@ -53,3 +55,56 @@ func lister(s string) *mockLister {
 		file: filepath.Join("testdata", s),
 	}
 }
+
+func TestGetOperatorsConditions(t *testing.T) {
+	conditions, err := getOperatorsConditions(context.Background(), lister("co-progressing.json"))
+	assert.NoError(t, err)
+	assert.Len(t, conditions, 3)
+	assert.Equal(t, "authentication", conditions[0].Name)
+	assert.Equal(t, ConditionProgressing, conditions[0].Condition)
+	assert.Equal(t, "AsExpected", conditions[0].Message)
+	assert.True(t, time.Date(2020, 6, 21, 10, 40, 40, 0, time.UTC).Equal(*conditions[0].LastTransitionTime))
+	for _, condition := range conditions[1:] {
+		assert.Equal(t, ConditionAvailable, condition.Condition)
+	}
+}
+
+func TestMicroShiftConditions(t *testing.T) {
+	node := k8sapi.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "api.crc.testing"},
+		Status: k8sapi.NodeStatus{
+			Conditions: []k8sapi.NodeCondition{
+				{Type: k8sapi.NodeReady, Status: k8sapi.ConditionTrue, Reason: "KubeletReady"},
+				{Type: k8sapi.NodeDiskPressure, Status: k8sapi.ConditionFalse},
+			},
+		},
+	}
+	running := k8sapi.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-dns", Name: "dns-default-x7k2p"},
+		Status: k8sapi.PodStatus{
+			Phase: k8sapi.PodRunning,
+			Conditions: []k8sapi.PodCondition{
+				{Type: k8sapi.PodReady, Status: k8sapi.ConditionTrue},
+			},
+		},
+	}
+	crashing := k8sapi.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "openshift-ingress", Name: "router-default-5d9f8"},
+		Status: k8sapi.PodStatus{
+			Phase: k8sapi.PodRunning,
+			ContainerStatuses: []k8sapi.ContainerStatus{
+				{Name: "router", State: k8sapi.ContainerState{Waiting: &k8sapi.ContainerStateWaiting{Reason: "CrashLoopBackOff"}}},
+			},
+		},
+	}
+	completed := k8sapi.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "kube-system", Name: "csi-snapshot-job"},
+		Status:     k8sapi.PodStatus{Phase: k8sapi.PodSucceeded},
+	}
+
+	assert.Equal(t, []OperatorCondition{
+		{Name: "node/api.crc.testing", Condition: ConditionAvailable, Message: "KubeletReady"},
+		{Name: "pod/openshift-dns/dns-default-x7k2p", Condition: ConditionAvailable},
+		{Name: "pod/openshift-ingress/router-default-5d9f8", Condition: ConditionDegraded, Message: "container router: CrashLoopBackOff"},
+	}, microShiftConditions([]k8sapi.Node{node}, []k8sapi.Pod{running, crashing, completed}))
+}
@@ -0,0 +1,201 @@
+package cluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	openshiftapi "github.com/openshift/api/config/v1"
+	k8sapi "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ConditionAvailable   = "Available"
+	ConditionProgressing = "Progressing"
+	ConditionDegraded    = "Degraded"
+	ConditionUnavailable = "Unavailable"
+	ConditionDisabled    = "Disabled"
+)
+
+// OperatorCondition is the health of a cluster operator, or of a MicroShift node or pod
+type OperatorCondition struct {
+	Name               string     `json:"name"`
+	Condition          string     `json:"condition"`
+	Message            string     `json:"message,omitempty"`
+	LastTransitionTime *time.Time `json:"lastTransitionTime,omitempty"`
+}
+
+// GetClusterOperatorsConditions returns the most relevant condition of each cluster operator
+func GetClusterOperatorsConditions(ctx context.Context, ip string, kubeconfigFilePath string) ([]OperatorCondition, error) {
+	lister, err := openshiftClient(ip, kubeconfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+	return getOperatorsConditions(ctx, lister.ConfigV1().ClusterOperators())
+}
+
+func getOperatorsConditions(ctx context.Context, lister operatorLister) ([]OperatorCondition, error) {
+	co, err := lister.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if len(co.Items) == 0 {
+		return nil, errors.New("no cluster operator found")
+	}
+	var conditions []OperatorCondition
+	for _, c := range co.Items {
+		conditions = append(conditions, operatorCondition(c))
+	}
+	sort.Slice(conditions, func(i, j int) bool {
+		return conditions[i].Name < conditions[j].Name
+	})
+	return conditions, nil
+}
+
+// operatorCondition picks the condition explaining why the operator is not
+// ready, a degraded operator is often progressing too
+func operatorCondition(co openshiftapi.ClusterOperator) OperatorCondition {
+	byType := map[string]openshiftapi.ClusterOperatorStatusCondition{}
+	for _, con := range co.Status.Conditions {
+		byType[string(con.Type)] = con
+	}
+	candidates := []struct {
+		conditionType string
+		status        openshiftapi.ConditionStatus
+		condition     string
+	}{
+		{string(openshiftapi.OperatorDegraded), openshiftapi.ConditionTrue, ConditionDegraded},
+		{string(openshiftapi.OperatorProgressing), openshiftapi.ConditionTrue, ConditionProgressing},
+		{string(openshiftapi.OperatorAvailable), openshiftapi.ConditionFalse, ConditionUnavailable},
+		{"Disabled", openshiftapi.ConditionTrue, ConditionDisabled},
+		{string(openshiftapi.OperatorAvailable), openshiftapi.ConditionTrue, ConditionAvailable},
+	}
+	for _, candidate := range candidates {
+		con, ok := byType[candidate.conditionType]
+		if !ok || con.Status != candidate.status {
+			continue
+		}
+		return OperatorCondition{
+			Name:               co.Name,
+			Condition:          candidate.condition,
+			Message:            conditionMessage(con.Message, con.Reason),
+			LastTransitionTime: transitionTime(con.LastTransitionTime),
+		}
+	}
+	return OperatorCondition{
+		Name:      co.Name,
+		Condition: ConditionUnavailable,
+		Message:   "The operator does not report its status",
+	}
+}
+
+// GetMicroShiftConditions returns the condition of the MicroShift node and of its pods
+func GetMicroShiftConditions(ctx context.Context, ip string, kubeconfigFilePath string) ([]OperatorCondition, error) {
+	clientSet, err := kubernetesClient(ip, kubeconfigFilePath)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	pods, err := clientSet.CoreV1().Pods("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return microShiftConditions(nodes.Items, pods.Items), nil
+}
+
+func microShiftConditions(nodes []k8sapi.Node, pods []k8sapi.Pod) []OperatorCondition {
+	var conditions []OperatorCondition
+	for _, node := range nodes {
+		conditions = append(conditions, nodeCondition(node))
+	}
+	for _, pod := range pods {
+		// completed jobs
+		if pod.Status.Phase == k8sapi.PodSucceeded {
+			continue
+		}
+		conditions = append(conditions, podCondition(pod))
+	}
+	return conditions
+}
+
+func nodeCondition(node k8sapi.Node) OperatorCondition {
+	condition := OperatorCondition{
+		Name:      fmt.Sprintf("node/%s", node.Name),
+		Condition: ConditionUnavailable,
+	}
+	for _, c := range node.Status.Conditions {
+		switch c.Type {
+		case k8sapi.NodeReady:
+			condition.LastTransitionTime = transitionTime(c.LastTransitionTime)
+			condition.Message = conditionMessage(c.Message, c.Reason)
+			switch c.Status {
+			case k8sapi.ConditionTrue:
+				condition.Condition = ConditionAvailable
+			case k8sapi.ConditionFalse:
+				condition.Condition = ConditionProgressing
+			default:
+				condition.Condition = ConditionDegraded
+			}
+		case k8sapi.NodeMemoryPressure, k8sapi.NodeDiskPressure, k8sapi.NodePIDPressure:
+			if c.Status == k8sapi.ConditionTrue {
+				return OperatorCondition{
+					Name:               condition.Name,
+					Condition:          ConditionDegraded,
+					Message:            conditionMessage(c.Message, c.Reason),
+					LastTransitionTime: transitionTime(c.LastTransitionTime),
+				}
+			}
+		}
+	}
+	return condition
+}
+
+func podCondition(pod k8sapi.Pod) OperatorCondition {
+	condition := OperatorCondition{
+		Name:      fmt.Sprintf("pod/%s/%s", pod.Namespace, pod.Name),
+		Condition: ConditionProgressing,
+		Message:   pod.Status.Message,
+	}
+	for _, c := range pod.Status.Conditions {
+		if c.Type != k8sapi.PodReady {
+			continue
+		}
+		condition.LastTransitionTime = transitionTime(c.LastTransitionTime)
+		if c.Status == k8sapi.ConditionTrue {
+			condition.Condition = ConditionAvailable
+		}
+	}
+	if pod.Status.Phase == k8sapi.PodFailed {
+		condition.Condition = ConditionDegraded
+		condition.Message = conditionMessage(pod.Status.Message, pod.Status.Reason)
+	}
+	for _, status := range pod.Status.ContainerStatuses {
+		if waiting := status.State.Waiting; waiting != nil && waiting.Reason != "ContainerCreating" {
+			condition.Condition = ConditionDegraded
+			condition.Message = fmt.Sprintf("container %s: %s", status.Name, conditionMessage(waiting.Message, waiting.Reason))
+			break
+		}
+	}
+	return condition
+}
+
+// conditionMessage returns the message of a condition, or its reason when it has none
+func conditionMessage(message, reason string) string {
+	if message != "" {
+		return message
+	}
+	return reason
+}
+
+func transitionTime(t metav1.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t.Time
+}
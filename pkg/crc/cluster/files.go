@@ -0,0 +1,65 @@
+package cluster
+
+import (
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	crcos "github.com/crc-org/crc/v2/pkg/os"
+)
+
+// fileRunner runs commands and writes files in the instance, it is
+// implemented by ssh.Runner
+type fileRunner interface {
+	crcos.CommandRunner
+	CopyDataPrivileged(data []byte, destFilename string, mode os.FileMode) error
+}
+
+// ensureRenderedFile writes the result of render to file, render is given
+// the content file had in the bundle, which is kept in backup the first
+// time. The content of the bundle is restored when render is nil.
+// It returns true when file has changed.
+func ensureRenderedFile(sshRunner *ssh.Runner, file, backup string, render func([]byte) ([]byte, error)) (bool, error) {
+	if _, _, err := sshRunner.RunPrivileged(fmt.Sprintf("Saving %s of the bundle", file), "sh", "-c",
+		fmt.Sprintf("'test -e %[2]s || cp %[1]s %[2]s 2>/dev/null || touch %[2]s'", file, backup)); err != nil {
+		return false, err
+	}
+	bundleContent, _, err := sshRunner.RunPrivate("sudo", "cat", backup)
+	if err != nil {
+		return false, err
+	}
+	content := []byte(bundleContent)
+	if render != nil {
+		if content, err = render(content); err != nil {
+			return false, err
+		}
+	}
+	return ensureFile(sshRunner, file, content)
+}
+
+// ensureFile writes content to file, or removes file when content is nil.
+// It returns true when file has changed.
+func ensureFile(sshRunner fileRunner, file string, content []byte) (bool, error) {
+	current, _, err := sshRunner.RunPrivate("sudo", "cat", file)
+	exists := err == nil
+	if content == nil {
+		if !exists {
+			return false, nil
+		}
+		if _, _, err := sshRunner.RunPrivileged(fmt.Sprintf("Removing %s", file), "rm", "-f", file); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+	if exists && current == string(content) {
+		return false, nil
+	}
+	if _, _, err := sshRunner.RunPrivileged(fmt.Sprintf("Creating %s", path.Dir(file)), "mkdir", "-p", path.Dir(file)); err != nil {
+		return false, err
+	}
+	if err := sshRunner.CopyDataPrivileged(content, file, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -0,0 +1,249 @@
+package cluster
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	microshiftConfigFile       = "/etc/microshift/config.yaml"
+	microshiftBundleConfigFile = "/etc/microshift/config.yaml.crc-bundle"
+	microshiftManifestsDir     = "/etc/microshift/manifests.d/crc"
+	manifestsChecksumFile      = ".crc-checksum"
+)
+
+var manifestNameRegexp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*\.ya?ml$`)
+
+// MicroShiftOptions are the values set in the MicroShift configuration file,
+// the empty ones keep the value of the bundle
+type MicroShiftOptions struct {
+	LogLevel        string
+	SubjectAltNames []string
+	BaseDomain      string
+	ClusterNetwork  string
+	ServiceNetwork  string
+	ManifestsDir    string
+}
+
+func (o MicroShiftOptions) isEmpty() bool {
+	return o.LogLevel == "" && len(o.SubjectAltNames) == 0 && o.BaseDomain == "" &&
+		o.ClusterNetwork == "" && o.ServiceNetwork == ""
+}
+
+// EnsureMicroShiftConfig writes the MicroShift configuration file, the
+// options are applied over the configuration file of the bundle, which is
+// kept aside the first time. The networks of the cluster cannot change once
+// MicroShift has started, they are only set when the instance is created.
+// It returns true when the file has changed.
+func EnsureMicroShiftConfig(sshRunner *ssh.Runner, options MicroShiftOptions, created bool) (bool, error) {
+	if !created {
+		currentConfig, _, err := sshRunner.RunPrivate("sudo", "cat", microshiftConfigFile)
+		if err != nil {
+			return false, fmt.Errorf("Cannot read the MicroShift configuration: %w", err)
+		}
+		options, err = keepMicroShiftNetworks([]byte(currentConfig), options)
+		if err != nil {
+			return false, err
+		}
+	}
+	var render func([]byte) ([]byte, error)
+	if !options.isEmpty() {
+		render = func(bundleConfig []byte) ([]byte, error) {
+			return renderMicroShiftConfig(bundleConfig, options)
+		}
+	}
+	changed, err := ensureRenderedFile(sshRunner, microshiftConfigFile, microshiftBundleConfigFile, render)
+	if changed {
+		logging.Info("Updated the MicroShift configuration")
+	}
+	return changed, err
+}
+
+// keepMicroShiftNetworks returns options with the networks of the current
+// configuration file, it fails when they were changed in the configuration
+// of crc after the instance was created
+func keepMicroShiftNetworks(currentConfig []byte, options MicroShiftOptions) (MicroShiftOptions, error) {
+	var current struct {
+		Network struct {
+			ClusterNetwork []string `yaml:"clusterNetwork"`
+			ServiceNetwork []string `yaml:"serviceNetwork"`
+		} `yaml:"network"`
+	}
+	if err := yaml.Unmarshal(currentConfig, &current); err != nil {
+		return options, fmt.Errorf("Cannot parse the MicroShift configuration: %w", err)
+	}
+	keep := func(key, configured string, networks []string) (string, error) {
+		var network string
+		if len(networks) != 0 {
+			network = networks[0]
+		}
+		if configured != "" && configured != network {
+			return "", fmt.Errorf("%s was changed after the CRC instance was created, "+
+				"delete the instance with 'crc delete' to use %s, or unset it", key, configured)
+		}
+		return network, nil
+	}
+	var err error
+	if options.ClusterNetwork, err = keep(crcConfig.MicroShiftClusterNetwork, options.ClusterNetwork, current.Network.ClusterNetwork); err != nil {
+		return options, err
+	}
+	if options.ServiceNetwork, err = keep(crcConfig.MicroShiftServiceNetwork, options.ServiceNetwork, current.Network.ServiceNetwork); err != nil {
+		return options, err
+	}
+	return options, nil
+}
+
+func renderMicroShiftConfig(bundleConfig []byte, options MicroShiftOptions) ([]byte, error) {
+	config := map[string]interface{}{}
+	if err := yaml.Unmarshal(bundleConfig, &config); err != nil {
+		return nil, fmt.Errorf("Cannot parse the MicroShift configuration of the bundle: %w", err)
+	}
+	section := func(name string) map[string]interface{} {
+		if s, ok := config[name].(map[string]interface{}); ok {
+			return s
+		}
+		s := map[string]interface{}{}
+		config[name] = s
+		return s
+	}
+	if options.LogLevel != "" {
+		section("debugging")["logLevel"] = options.LogLevel
+	}
+	if len(options.SubjectAltNames) != 0 {
+		apiServer := section("apiServer")
+		var names []string
+		if existing, ok := apiServer["subjectAltNames"].([]interface{}); ok {
+			for _, name := range existing {
+				names = append(names, fmt.Sprint(name))
+			}
+		}
+		for _, name := range options.SubjectAltNames {
+			if !crcstrings.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+		apiServer["subjectAltNames"] = names
+	}
+	if options.BaseDomain != "" {
+		section("dns")["baseDomain"] = options.BaseDomain
+	}
+	if options.ClusterNetwork != "" {
+		section("network")["clusterNetwork"] = []string{options.ClusterNetwork}
+	}
+	if options.ServiceNetwork != "" {
+		section("network")["serviceNetwork"] = []string{options.ServiceNetwork}
+	}
+	return yaml.Marshal(config)
+}
+
+// EnsureMicroShiftManifests copies the manifests of dir to the manifests
+// directory of MicroShift, or removes them when dir is empty. It returns true
+// when the manifests have changed.
+func EnsureMicroShiftManifests(sshRunner *ssh.Runner, dir string) (bool, error) {
+	checksumFile := path.Join(microshiftManifestsDir, manifestsChecksumFile)
+	if dir == "" {
+		if _, _, err := sshRunner.RunPrivileged("Checking the MicroShift manifests", "test", "-d", microshiftManifestsDir); err != nil {
+			return false, nil
+		}
+		logging.Info("Removing the MicroShift manifests...")
+		if _, _, err := sshRunner.RunPrivileged("Removing the MicroShift manifests", "rm", "-rf", microshiftManifestsDir); err != nil {
+			return false, err
+		}
+		return true, nil
+	}
+
+	manifests, err := readManifests(dir)
+	if err != nil {
+		return false, err
+	}
+	checksum := manifestsChecksum(manifests)
+	if current, _, err := sshRunner.RunPrivate("sudo", "cat", checksumFile); err == nil && strings.TrimSpace(current) == checksum {
+		return false, nil
+	}
+
+	logging.Infof("Copying the MicroShift manifests of %s...", dir)
+	if _, _, err := sshRunner.RunPrivileged("Creating the MicroShift manifests directory", "sh", "-c",
+		fmt.Sprintf("'rm -rf %[1]s && mkdir -p %[1]s'", microshiftManifestsDir)); err != nil {
+		return false, err
+	}
+	for name, data := range manifests {
+		if err := sshRunner.CopyDataPrivileged(data, path.Join(microshiftManifestsDir, name), 0644); err != nil {
+			return false, err
+		}
+	}
+	if err := sshRunner.CopyDataPrivileged([]byte(checksum), checksumFile, 0644); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// readManifests reads the YAML files of dir, a kustomization.yaml listing
+// them is added when dir has no kustomization file, as MicroShift only
+// applies the directories which have one
+func readManifests(dir string) (map[string][]byte, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	manifests := map[string][]byte{}
+	var resources []string
+	hasKustomization := false
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !manifestNameRegexp.MatchString(name) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		manifests[name] = data
+		if name == "kustomization.yaml" || name == "kustomization.yml" {
+			hasKustomization = true
+		} else {
+			resources = append(resources, name)
+		}
+	}
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("%s has no manifests", dir)
+	}
+	if !hasKustomization {
+		sort.Strings(resources)
+		data, err := yaml.Marshal(map[string]interface{}{
+			"apiVersion": "kustomize.config.k8s.io/v1beta1",
+			"kind":       "Kustomization",
+			"resources":  resources,
+		})
+		if err != nil {
+			return nil, err
+		}
+		manifests["kustomization.yaml"] = data
+	}
+	return manifests, nil
+}
+
+func manifestsChecksum(manifests map[string][]byte) string {
+	var names []string
+	for name := range manifests {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	hash := sha256.New()
+	for _, name := range names {
+		fmt.Fprintf(hash, "%s\n%d\n", name, len(manifests[name]))
+		hash.Write(manifests[name])
+	}
+	return fmt.Sprintf("%x", hash.Sum(nil))
+}
@@ -0,0 +1,91 @@
+package cluster
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMicroShiftConfig(t *testing.T) {
+	bundleConfig := `dns:
+  baseDomain: crc.testing
+apiServer:
+  subjectAltNames:
+    - api.crc.testing
+`
+	config, err := renderMicroShiftConfig([]byte(bundleConfig), MicroShiftOptions{
+		LogLevel:        "Debug",
+		SubjectAltNames: []string{"api.crc.testing", "192.168.1.10"},
+		ServiceNetwork:  "10.96.0.0/16",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, `apiServer:
+    subjectAltNames:
+        - api.crc.testing
+        - 192.168.1.10
+debugging:
+    logLevel: Debug
+dns:
+    baseDomain: crc.testing
+network:
+    serviceNetwork:
+        - 10.96.0.0/16
+`, string(config))
+
+	config, err = renderMicroShiftConfig(nil, MicroShiftOptions{BaseDomain: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "dns:\n    baseDomain: example.com\n", string(config))
+}
+
+func TestKeepMicroShiftNetworks(t *testing.T) {
+	currentConfig := []byte(`network:
+    clusterNetwork:
+        - 10.128.0.0/14
+`)
+	options, err := keepMicroShiftNetworks(currentConfig, MicroShiftOptions{LogLevel: "Debug"})
+	require.NoError(t, err)
+	assert.Equal(t, MicroShiftOptions{LogLevel: "Debug", ClusterNetwork: "10.128.0.0/14"}, options)
+
+	options, err = keepMicroShiftNetworks(currentConfig, MicroShiftOptions{ClusterNetwork: "10.128.0.0/14"})
+	require.NoError(t, err)
+	assert.Equal(t, MicroShiftOptions{ClusterNetwork: "10.128.0.0/14"}, options)
+
+	_, err = keepMicroShiftNetworks(currentConfig, MicroShiftOptions{ClusterNetwork: "10.42.0.0/16"})
+	assert.ErrorContains(t, err, "microshift-cluster-network was changed after the CRC instance was created")
+	_, err = keepMicroShiftNetworks(currentConfig, MicroShiftOptions{ServiceNetwork: "10.96.0.0/16"})
+	assert.ErrorContains(t, err, "microshift-service-network was changed after the CRC instance was created")
+}
+
+func TestReadManifests(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "namespace.yaml"), []byte("kind: Namespace\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yml"), []byte("kind: Deployment\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("ignored\n"), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "overlays"), 0700))
+
+	manifests, err := readManifests(dir)
+	require.NoError(t, err)
+	assert.Len(t, manifests, 3)
+	assert.Equal(t, `apiVersion: kustomize.config.k8s.io/v1beta1
+kind: Kustomization
+resources:
+    - app.yml
+    - namespace.yaml
+`, string(manifests["kustomization.yaml"]))
+
+	checksum := manifestsChecksum(manifests)
+	assert.Equal(t, checksum, manifestsChecksum(manifests))
+	manifests["app.yml"] = []byte("kind: StatefulSet\n")
+	assert.NotEqual(t, checksum, manifestsChecksum(manifests))
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "kustomization.yaml"), []byte("resources: [namespace.yaml]\n"), 0600))
+	manifests, err = readManifests(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "resources: [namespace.yaml]\n", string(manifests["kustomization.yaml"]))
+
+	_, err = readManifests(t.TempDir())
+	assert.ErrorContains(t, err, "has no manifests")
+}
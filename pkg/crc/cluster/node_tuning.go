@@ -0,0 +1,134 @@
+package cluster
+
+import (
+	"fmt"
+
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/systemd"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kubeletNodeTuningDropIn     = "/etc/openshift/kubelet.conf.d/99-crc-node-tuning.yaml"
+	microshiftNodeTuningDropIn  = "/etc/microshift/config.d/90-crc-node-tuning.yaml"
+	crioNodeTuningDropIn        = "/etc/crio/crio.conf.d/99-crc-node-tuning.conf"
+	kubeletEvictionHard         = "evictionHard"
+	kubeletMaxPods              = "maxPods"
+	kubeletImageGCHighThreshold = "imageGCHighThresholdPercent"
+	kubeletImageGCLowThreshold  = "imageGCLowThresholdPercent"
+)
+
+// NodeTuning are the kubelet and cri-o settings of the node, the zero
+// values keep the settings of the bundle
+type NodeTuning struct {
+	MaxPods              uint
+	EvictionHard         map[string]string
+	ImageGCHighThreshold uint
+	ImageGCLowThreshold  uint
+	CrioLogSizeMax       int64
+}
+
+// kubeletConfig returns the fields of the KubeletConfiguration to set
+func (t NodeTuning) kubeletConfig() map[string]interface{} {
+	config := map[string]interface{}{}
+	if t.MaxPods != 0 {
+		config[kubeletMaxPods] = t.MaxPods
+	}
+	if len(t.EvictionHard) != 0 {
+		config[kubeletEvictionHard] = t.EvictionHard
+	}
+	if t.ImageGCHighThreshold != 0 {
+		config[kubeletImageGCHighThreshold] = t.ImageGCHighThreshold
+	}
+	if t.ImageGCLowThreshold != 0 {
+		config[kubeletImageGCLowThreshold] = t.ImageGCLowThreshold
+	}
+	return config
+}
+
+func (t NodeTuning) isEmpty() bool {
+	return len(t.kubeletConfig()) == 0 && t.CrioLogSizeMax == 0
+}
+
+// ApplyNodeTuning updates the cri-o configuration, restarting cri-o when it
+// has changed, and the kubelet configuration with ensureKubeletConfig. It
+// returns true when the kubelet configuration has changed, the kubelet must
+// then be restarted. The files are not checked when no setting is set and
+// none of them was written before.
+func ApplyNodeTuning(runner fileRunner, tuning NodeTuning, ensureKubeletConfig func(fileRunner, NodeTuning) (bool, error)) (bool, error) {
+	if tuning.isEmpty() {
+		if _, _, err := runner.Run("test", "-e", crioNodeTuningDropIn, "-o", "-e", kubeletNodeTuningDropIn, "-o", "-e", microshiftNodeTuningDropIn); err != nil {
+			return false, nil
+		}
+	}
+	crioChanged, err := EnsureCrioConfig(runner, tuning)
+	if err != nil {
+		return false, fmt.Errorf("Failed to update the cri-o configuration: %w", err)
+	}
+	if crioChanged {
+		if err := systemd.NewInstanceSystemdCommander(runner).Restart("crio"); err != nil {
+			return false, fmt.Errorf("Error restarting cri-o: %w", err)
+		}
+	}
+	kubeletChanged, err := ensureKubeletConfig(runner, tuning)
+	if err != nil {
+		return false, fmt.Errorf("Failed to update the kubelet configuration: %w", err)
+	}
+	return kubeletChanged, nil
+}
+
+// EnsureKubeletConfig applies the node tuning of OpenShift with a drop-in of
+// the kubelet configuration. The kubelet configuration file is managed by the
+// machine config operator, which reports the node as degraded when it is
+// modified, its drop-in directory is not. It returns true when the drop-in
+// has changed.
+func EnsureKubeletConfig(runner fileRunner, tuning NodeTuning) (bool, error) {
+	var dropIn []byte
+	if settings := tuning.kubeletConfig(); len(settings) != 0 {
+		settings["apiVersion"] = "kubelet.config.k8s.io/v1beta1"
+		settings["kind"] = "KubeletConfiguration"
+		var err error
+		if dropIn, err = yaml.Marshal(settings); err != nil {
+			return false, err
+		}
+	}
+	changed, err := ensureFile(runner, kubeletNodeTuningDropIn, dropIn)
+	if changed {
+		logging.Info("Updated the kubelet configuration")
+	}
+	return changed, err
+}
+
+// EnsureMicroShiftKubeletConfig applies the node tuning with a drop-in of
+// the MicroShift configuration. It returns true when the drop-in has changed.
+func EnsureMicroShiftKubeletConfig(runner fileRunner, tuning NodeTuning) (bool, error) {
+	var dropIn []byte
+	if settings := tuning.kubeletConfig(); len(settings) != 0 {
+		var err error
+		if dropIn, err = yaml.Marshal(map[string]interface{}{"kubelet": settings}); err != nil {
+			return false, err
+		}
+	}
+	changed, err := ensureFile(runner, microshiftNodeTuningDropIn, dropIn)
+	if changed {
+		logging.Info("Updated the kubelet configuration of MicroShift")
+	}
+	return changed, err
+}
+
+// EnsureCrioConfig applies the node tuning with a drop-in of the cri-o
+// configuration. It returns true when the drop-in has changed.
+func EnsureCrioConfig(runner fileRunner, tuning NodeTuning) (bool, error) {
+	changed, err := ensureFile(runner, crioNodeTuningDropIn, crioConfig(tuning))
+	if changed {
+		logging.Info("Updated the cri-o configuration")
+	}
+	return changed, err
+}
+
+func crioConfig(tuning NodeTuning) []byte {
+	if tuning.CrioLogSizeMax == 0 {
+		return nil
+	}
+	return []byte(fmt.Sprintf("[crio.runtime]\nlog_size_max = %d\n", tuning.CrioLogSizeMax))
+}
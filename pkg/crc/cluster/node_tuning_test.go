@@ -0,0 +1,108 @@
+package cluster
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// nodeFilesRunner keeps the files of the instance in memory and records the
+// other commands
+type nodeFilesRunner struct {
+	files    map[string]string
+	commands []string
+}
+
+func (r *nodeFilesRunner) Run(command string, args ...string) (string, string, error) {
+	if command == "test" {
+		for _, arg := range args {
+			if _, ok := r.files[arg]; ok {
+				return "", "", nil
+			}
+		}
+		return "", "", errors.New("exit status 1")
+	}
+	r.commands = append(r.commands, strings.Join(append([]string{command}, args...), " "))
+	return "", "", nil
+}
+
+func (r *nodeFilesRunner) RunPrivate(command string, args ...string) (string, string, error) {
+	if command == "sudo" && args[0] == "cat" {
+		if content, ok := r.files[args[1]]; ok {
+			return content, "", nil
+		}
+		return "", "No such file or directory", errors.New("exit status 1")
+	}
+	return r.Run(command, args...)
+}
+
+func (r *nodeFilesRunner) RunPrivileged(_ string, cmdAndArgs ...string) (string, string, error) {
+	switch cmdAndArgs[0] {
+	case "mkdir":
+		return "", "", nil
+	case "rm":
+		delete(r.files, cmdAndArgs[2])
+		return "", "", nil
+	}
+	return r.Run(cmdAndArgs[0], cmdAndArgs[1:]...)
+}
+
+func (r *nodeFilesRunner) CopyDataPrivileged(data []byte, destFilename string, _ os.FileMode) error {
+	r.files[destFilename] = string(data)
+	return nil
+}
+
+func TestApplyNodeTuning(t *testing.T) {
+	runner := &nodeFilesRunner{files: map[string]string{}}
+	tuning := NodeTuning{
+		MaxPods:        110,
+		EvictionHard:   map[string]string{"nodefs.available": "5%"},
+		CrioLogSizeMax: 52428800,
+	}
+
+	kubeletChanged, err := ApplyNodeTuning(runner, tuning, EnsureKubeletConfig)
+	require.NoError(t, err)
+	assert.True(t, kubeletChanged)
+	assert.Equal(t, `apiVersion: kubelet.config.k8s.io/v1beta1
+evictionHard:
+    nodefs.available: 5%
+kind: KubeletConfiguration
+maxPods: 110
+`, runner.files[kubeletNodeTuningDropIn])
+	assert.Equal(t, "[crio.runtime]\nlog_size_max = 52428800\n", runner.files[crioNodeTuningDropIn])
+	// the settings are applied by restarting the services, the node is not rebooted
+	assert.Equal(t, []string{"systemctl daemon-reload", "systemctl restart crio"}, runner.commands)
+
+	runner.commands = nil
+	kubeletChanged, err = ApplyNodeTuning(runner, tuning, EnsureKubeletConfig)
+	require.NoError(t, err)
+	assert.False(t, kubeletChanged)
+	assert.Empty(t, runner.commands)
+
+	kubeletChanged, err = ApplyNodeTuning(runner, NodeTuning{}, EnsureKubeletConfig)
+	require.NoError(t, err)
+	assert.True(t, kubeletChanged)
+	assert.Empty(t, runner.files)
+	assert.Equal(t, []string{"systemctl daemon-reload", "systemctl restart crio"}, runner.commands)
+}
+
+func TestApplyNodeTuningDefaults(t *testing.T) {
+	runner := &nodeFilesRunner{files: map[string]string{}}
+	kubeletChanged, err := ApplyNodeTuning(runner, NodeTuning{}, func(fileRunner, NodeTuning) (bool, error) {
+		return false, errors.New("the kubelet configuration must not be checked")
+	})
+	require.NoError(t, err)
+	assert.False(t, kubeletChanged)
+	assert.Empty(t, runner.commands)
+}
+
+func TestNodeTuningDefaults(t *testing.T) {
+	assert.Empty(t, NodeTuning{}.kubeletConfig())
+	assert.True(t, NodeTuning{}.isEmpty())
+	assert.Nil(t, crioConfig(NodeTuning{}))
+	assert.Equal(t, "[crio.runtime]\nlog_size_max = 52428800\n", string(crioConfig(NodeTuning{CrioLogSizeMax: 52428800})))
+}
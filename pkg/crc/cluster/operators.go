@@ -0,0 +1,186 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	crcerrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+)
+
+const marketplaceNamespace = "openshift-marketplace"
+
+type packageManifest struct {
+	Status struct {
+		CatalogSource          string `json:"catalogSource"`
+		CatalogSourceNamespace string `json:"catalogSourceNamespace"`
+		DefaultChannel         string `json:"defaultChannel"`
+		Channels               []struct {
+			Name string `json:"name"`
+		} `json:"channels"`
+	} `json:"status"`
+}
+
+// InstallOperator subscribes to an OLM package and waits for its cluster
+// service version to succeed. The manifests are applied, so this can be run
+// again for an operator which is already installed.
+// It returns the name of the installed cluster service version.
+func InstallOperator(ctx context.Context, sshRunner *ssh.Runner, ocConfig oc.Config, operator crcConfig.Operator) (string, error) {
+	manifest, err := getPackageManifest(ctx, ocConfig, operator.Package)
+	if err != nil {
+		return "", err
+	}
+	createOperatorGroup := false
+	if operator.Namespace != crcConfig.DefaultOperatorsNamespace {
+		stdout, stderr, err := ocConfig.RunOcCommand("get", "operatorgroup", "-n", operator.Namespace, "-o", "name")
+		if err != nil {
+			return "", fmt.Errorf("Failed to get the operator groups of %s: %v: %s", operator.Namespace, err, stderr)
+		}
+		createOperatorGroup = strings.TrimSpace(stdout) == ""
+	}
+	data, err := operatorManifests(operator, manifest, createOperatorGroup)
+	if err != nil {
+		return "", err
+	}
+
+	logging.Infof("Installing operator %s in namespace %s...", operator.Package, operator.Namespace)
+	fileName := fmt.Sprintf("/tmp/operator-%s.json", operator.Package)
+	if err := sshRunner.CopyDataPrivileged(data, fileName, 0644); err != nil {
+		return "", err
+	}
+	if _, stderr, err := ocConfig.RunOcCommand("apply", "-f", fileName); err != nil {
+		return "", fmt.Errorf("Failed to subscribe to operator %s: %v: %s", operator.Package, err, stderr)
+	}
+	return waitForOperator(ctx, ocConfig, operator)
+}
+
+// getPackageManifest returns the package manifest of the operator name. The
+// catalogs are still loading for a few minutes after the cluster starts,
+// during this time the package manifests are missing or cannot be read.
+func getPackageManifest(ctx context.Context, ocConfig oc.Config, name string) (*packageManifest, error) {
+	var manifest packageManifest
+	getManifest := func() error {
+		stdout, stderr, err := ocConfig.RunOcCommand("get", "packagemanifest", name, "-n", marketplaceNamespace, "-o", "json")
+		if err != nil {
+			if strings.Contains(stderr, "NotFound") {
+				return &crcerrors.RetriableError{Err: fmt.Errorf("Operator %s is not provided by the catalogs of the cluster", name)}
+			}
+			return &crcerrors.RetriableError{Err: fmt.Errorf("Failed to get the package manifest of %s: %v: %s", name, err, stderr)}
+		}
+		return json.Unmarshal([]byte(stdout), &manifest)
+	}
+	if err := crcerrors.Retry(ctx, 3*time.Minute, getManifest, 5*time.Second); err != nil {
+		return nil, err
+	}
+	return &manifest, nil
+}
+
+// operatorManifests returns the list of objects to apply to subscribe to the
+// operator: the namespace and its operator group when the namespace is not
+// openshift-operators, and the subscription
+func operatorManifests(operator crcConfig.Operator, manifest *packageManifest, createOperatorGroup bool) ([]byte, error) {
+	channel := operator.Channel
+	if channel == "" {
+		channel = manifest.Status.DefaultChannel
+	}
+	var channels []string
+	for _, c := range manifest.Status.Channels {
+		channels = append(channels, c.Name)
+	}
+	if !crcstrings.Contains(channels, channel) {
+		return nil, fmt.Errorf("Operator %s has no channel '%s', available channels are %s", operator.Package, channel, strings.Join(channels, ", "))
+	}
+	sourceNamespace := manifest.Status.CatalogSourceNamespace
+	if sourceNamespace == "" {
+		sourceNamespace = marketplaceNamespace
+	}
+
+	var items []interface{}
+	if operator.Namespace != crcConfig.DefaultOperatorsNamespace {
+		items = append(items, map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Namespace",
+			"metadata": map[string]interface{}{
+				"name": operator.Namespace,
+			},
+		})
+		if createOperatorGroup {
+			items = append(items, map[string]interface{}{
+				"apiVersion": "operators.coreos.com/v1",
+				"kind":       "OperatorGroup",
+				"metadata": map[string]interface{}{
+					"name":      operator.Namespace,
+					"namespace": operator.Namespace,
+				},
+				"spec": map[string]interface{}{
+					"targetNamespaces": []string{operator.Namespace},
+				},
+			})
+		}
+	}
+	items = append(items, map[string]interface{}{
+		"apiVersion": "operators.coreos.com/v1alpha1",
+		"kind":       "Subscription",
+		"metadata": map[string]interface{}{
+			"name":      operator.Package,
+			"namespace": operator.Namespace,
+		},
+		"spec": map[string]interface{}{
+			"name":                operator.Package,
+			"channel":             channel,
+			"source":              manifest.Status.CatalogSource,
+			"sourceNamespace":     sourceNamespace,
+			"installPlanApproval": "Automatic",
+		},
+	})
+	return json.MarshalIndent(map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "List",
+		"items":      items,
+	}, "", "  ")
+}
+
+// waitForOperator waits for the subscription to have an installed cluster
+// service version and for this version to succeed
+func waitForOperator(ctx context.Context, ocConfig oc.Config, operator crcConfig.Operator) (string, error) {
+	var csv, phase string
+	waitForCSV := func() error {
+		stdout, stderr, err := ocConfig.RunOcCommand("get", "subscription", operator.Package, "-n", operator.Namespace, "-o", "jsonpath={.status.installedCSV}")
+		if err != nil {
+			return &crcerrors.RetriableError{Err: fmt.Errorf("%v: %s", err, stderr)}
+		}
+		csv = strings.TrimSpace(stdout)
+		if csv == "" {
+			return &crcerrors.RetriableError{Err: fmt.Errorf("operator %s is not installed yet", operator.Package)}
+		}
+		stdout, stderr, err = ocConfig.RunOcCommand("get", "csv", csv, "-n", operator.Namespace, "-o", "jsonpath={.status.phase}")
+		if err != nil {
+			return &crcerrors.RetriableError{Err: fmt.Errorf("%v: %s", err, stderr)}
+		}
+		if current := strings.TrimSpace(stdout); current != phase {
+			phase = current
+			if phase != "" {
+				logging.Infof("Operator %s: %s is in phase %s", operator.Package, csv, phase)
+			}
+		}
+		switch phase {
+		case "Succeeded":
+			return nil
+		case "Failed":
+			return fmt.Errorf("Installation of %s failed, check 'oc describe csv %s -n %s'", csv, csv, operator.Namespace)
+		default:
+			return &crcerrors.RetriableError{Err: fmt.Errorf("%s is not ready yet", csv)}
+		}
+	}
+	if err := crcerrors.Retry(ctx, 10*time.Minute, waitForCSV, 5*time.Second); err != nil {
+		return "", err
+	}
+	return csv, nil
+}
@@ -0,0 +1,72 @@
+package cluster
+
+import (
+	"encoding/json"
+	"testing"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const serverlessPackageManifest = `{
+  "status": {
+    "catalogSource": "redhat-operators",
+    "catalogSourceNamespace": "openshift-marketplace",
+    "defaultChannel": "stable",
+    "channels": [{"name": "stable"}, {"name": "stable-1.33"}]
+  }
+}`
+
+type manifestList struct {
+	Items []struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Name      string `json:"name"`
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		Spec map[string]interface{} `json:"spec"`
+	} `json:"items"`
+}
+
+func parseManifests(t *testing.T, data []byte) manifestList {
+	var list manifestList
+	require.NoError(t, json.Unmarshal(data, &list))
+	return list
+}
+
+func TestOperatorManifests(t *testing.T) {
+	var manifest packageManifest
+	require.NoError(t, json.Unmarshal([]byte(serverlessPackageManifest), &manifest))
+
+	data, err := operatorManifests(crcConfig.Operator{Package: "serverless-operator", Namespace: crcConfig.DefaultOperatorsNamespace}, &manifest, false)
+	require.NoError(t, err)
+	list := parseManifests(t, data)
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, "Subscription", list.Items[0].Kind)
+	assert.Equal(t, "openshift-operators", list.Items[0].Metadata.Namespace)
+	assert.Equal(t, map[string]interface{}{
+		"name":                "serverless-operator",
+		"channel":             "stable",
+		"source":              "redhat-operators",
+		"sourceNamespace":     "openshift-marketplace",
+		"installPlanApproval": "Automatic",
+	}, list.Items[0].Spec)
+
+	data, err = operatorManifests(crcConfig.Operator{Package: "serverless-operator", Channel: "stable-1.33", Namespace: "serverless"}, &manifest, true)
+	require.NoError(t, err)
+	list = parseManifests(t, data)
+	require.Len(t, list.Items, 3)
+	assert.Equal(t, "Namespace", list.Items[0].Kind)
+	assert.Equal(t, "OperatorGroup", list.Items[1].Kind)
+	assert.Equal(t, []interface{}{"serverless"}, list.Items[1].Spec["targetNamespaces"])
+	assert.Equal(t, "serverless", list.Items[2].Metadata.Namespace)
+	assert.Equal(t, "stable-1.33", list.Items[2].Spec["channel"])
+
+	data, err = operatorManifests(crcConfig.Operator{Package: "serverless-operator", Namespace: "serverless"}, &manifest, false)
+	require.NoError(t, err)
+	assert.Len(t, parseManifests(t, data).Items, 2)
+
+	_, err = operatorManifests(crcConfig.Operator{Package: "serverless-operator", Channel: "fast", Namespace: crcConfig.DefaultOperatorsNamespace}, &manifest, false)
+	assert.EqualError(t, err, "Operator serverless-operator has no channel 'fast', available channels are stable, stable-1.33")
+}
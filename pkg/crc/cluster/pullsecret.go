@@ -18,13 +18,19 @@ import (
 	crcTerminal "github.com/crc-org/crc/v2/pkg/os/terminal"
 
 	"github.com/AlecAivazis/survey/v2"
-	"github.com/zalando/go-keyring"
+	"github.com/spf13/cast"
 )
 
-const (
-	keyringService = "crc"
-	keyringUser    = "compressed-pull-secret"
-)
+// PullSecretKey is the name of the pull secret in the secret storage
+const PullSecretKey = "compressed-pull-secret"
+
+// secretStorage stores the pull secret, it is the system keyring until UseSecretStorage is called
+var secretStorage crcConfig.RawStorage = crcConfig.NewKeyringStorage()
+
+// UseSecretStorage makes the pull secret use the same storage as the secret settings
+func UseSecretStorage(storage crcConfig.RawStorage) {
+	secretStorage = storage
+}
 
 type PullSecretLoader interface {
 	Value() (string, error)
@@ -55,8 +61,8 @@ func (loader *interactivePullSecretLoader) Value() (string, error) {
 		return "", err
 	}
 
-	if err := StoreInKeyring(pullSecret); err != nil {
-		logging.Warnf("Cannot add pull secret to keyring: %v", err)
+	if err := StorePullSecret(pullSecret); err != nil {
+		logging.Warnf("Cannot store the pull secret: %v", err)
 	}
 	return pullSecret, nil
 }
@@ -96,22 +102,22 @@ func (loader *nonInteractivePullSecretLoader) Value() (string, error) {
 	}
 	logging.Debugf("Cannot load secret from configuration: %v", err)
 
-	fromKeyring, err := loadFromKeyring()
+	fromSecretStorage, err := loadFromSecretStorage()
 	if err == nil {
-		logging.Debugf("Using secret from keyring")
-		return fromKeyring, nil
+		logging.Debugf("Using secret from the secret storage")
+		return fromSecretStorage, nil
 	}
-	logging.Debugf("Cannot load secret from keyring: %v", err)
+	logging.Debugf("Cannot load secret from the secret storage: %v", err)
 
 	return "", fmt.Errorf("unable to load pull secret from path %q or from configuration", loader.path)
 }
 
-func loadFromKeyring() (string, error) {
-	pullsecret, err := keyring.Get(keyringService, keyringUser)
-	if err != nil {
-		return "", err
+func loadFromSecretStorage() (string, error) {
+	pullsecret := secretStorage.Get(PullSecretKey)
+	if pullsecret == nil {
+		return "", errors.New("pull secret not found")
 	}
-	decoded, err := base64.StdEncoding.DecodeString(pullsecret)
+	decoded, err := base64.StdEncoding.DecodeString(cast.ToString(pullsecret))
 	if err != nil {
 		return "", err
 	}
@@ -131,7 +137,8 @@ func loadFromKeyring() (string, error) {
 	return b.String(), validation.ImagePullSecret(b.String())
 }
 
-func StoreInKeyring(pullSecret string) error {
+// StorePullSecret stores pullSecret in the keyring, or in the encrypted secrets file
+func StorePullSecret(pullSecret string) error {
 	var b bytes.Buffer
 
 	if err := validation.ImagePullSecret(pullSecret); err != nil {
@@ -145,11 +152,11 @@ func StoreInKeyring(pullSecret string) error {
 	if err := compressor.Close(); err != nil {
 		return err
 	}
-	return keyring.Set(keyringService, keyringUser, base64.StdEncoding.EncodeToString(b.Bytes()))
+	return secretStorage.Set(PullSecretKey, base64.StdEncoding.EncodeToString(b.Bytes()))
 }
 
 func ForgetPullSecret() error {
-	_ = keyring.Delete(keyringService, keyringUser)
+	_ = secretStorage.Unset(PullSecretKey)
 	return nil
 }
 
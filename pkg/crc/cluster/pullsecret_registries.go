@@ -0,0 +1,118 @@
+package cluster
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/validation"
+)
+
+// PullSecretRegistry is a registry of the pull secret, without its credentials
+type PullSecretRegistry struct {
+	Registry string `json:"registry"`
+	Username string `json:"username,omitempty"`
+}
+
+type registryAuth struct {
+	Auth string `json:"auth,omitempty"`
+}
+
+// parsePullSecret returns the top-level fields and the auths of the pull
+// secret, the fields which are not used by crc are kept as is
+func parsePullSecret(pullSecret string) (map[string]json.RawMessage, map[string]json.RawMessage, error) {
+	if err := validation.ImagePullSecret(pullSecret); err != nil {
+		return nil, nil, err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(pullSecret), &fields); err != nil {
+		return nil, nil, err
+	}
+	var auths map[string]json.RawMessage
+	if err := json.Unmarshal(fields["auths"], &auths); err != nil {
+		return nil, nil, err
+	}
+	return fields, auths, nil
+}
+
+func marshalPullSecret(fields map[string]json.RawMessage, auths map[string]json.RawMessage) (string, error) {
+	bin, err := json.Marshal(auths)
+	if err != nil {
+		return "", err
+	}
+	fields["auths"] = bin
+	bin, err = json.Marshal(fields)
+	if err != nil {
+		return "", err
+	}
+	return string(bin), validation.ImagePullSecret(string(bin))
+}
+
+// GetPullSecretRegistries returns the sorted registries of the pull secret
+func GetPullSecretRegistries(pullSecret string) ([]PullSecretRegistry, error) {
+	_, auths, err := parsePullSecret(pullSecret)
+	if err != nil {
+		return nil, err
+	}
+	var registries []PullSecretRegistry
+	for name, raw := range auths {
+		registry := PullSecretRegistry{Registry: name}
+		var auth registryAuth
+		if err := json.Unmarshal(raw, &auth); err == nil {
+			if decoded, err := base64.StdEncoding.DecodeString(auth.Auth); err == nil {
+				if username, _, found := strings.Cut(string(decoded), ":"); found {
+					registry.Username = username
+				}
+			}
+		}
+		registries = append(registries, registry)
+	}
+	sort.Slice(registries, func(i, j int) bool {
+		return registries[i].Registry < registries[j].Registry
+	})
+	return registries, nil
+}
+
+// AddPullSecretRegistry returns the pull secret with the credentials of
+// registry, they replace the existing ones
+func AddPullSecretRegistry(pullSecret, registry, username, password string) (string, error) {
+	if registry == "" || strings.ContainsAny(registry, " \t\n\"") {
+		return "", fmt.Errorf("invalid registry '%s'", registry)
+	}
+	if username == "" || strings.Contains(username, ":") {
+		return "", fmt.Errorf("invalid username '%s'", username)
+	}
+	if password == "" {
+		return "", fmt.Errorf("the password of %s cannot be empty", registry)
+	}
+	fields, auths, err := parsePullSecret(pullSecret)
+	if err != nil {
+		return "", err
+	}
+	bin, err := json.Marshal(registryAuth{
+		Auth: base64.StdEncoding.EncodeToString([]byte(fmt.Sprintf("%s:%s", username, password))),
+	})
+	if err != nil {
+		return "", err
+	}
+	auths[registry] = bin
+	return marshalPullSecret(fields, auths)
+}
+
+// RemovePullSecretRegistry returns the pull secret without the credentials of registry
+func RemovePullSecretRegistry(pullSecret, registry string) (string, error) {
+	fields, auths, err := parsePullSecret(pullSecret)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := auths[registry]; !ok {
+		return "", fmt.Errorf("registry %s is not in the pull secret", registry)
+	}
+	if len(auths) == 1 {
+		return "", fmt.Errorf("registry %s is the only registry of the pull secret", registry)
+	}
+	delete(auths, registry)
+	return marshalPullSecret(fields, auths)
+}
@@ -33,7 +33,7 @@ func TestLoadPullSecret(t *testing.T) {
 	_, err := loader.Value()
 	assert.Error(t, err)
 
-	assert.NoError(t, StoreInKeyring(secret3))
+	assert.NoError(t, StorePullSecret(secret3))
 
 	val, err := loader.Value()
 	assert.NoError(t, err)
@@ -53,5 +53,38 @@ func TestLoadPullSecret(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, secret1, val)
 
-	assert.Error(t, StoreInKeyring(secret4))
+	assert.Error(t, StorePullSecret(secret4))
+}
+
+func TestPullSecretRegistries(t *testing.T) {
+	pullSecret := `{"auths":{"quay.io":{"auth":"dXNlcjE6dG9rZW4x","email":"user1@example.com"},"registry.example.com":{"credsStore":"pass"}},"credHelpers":{}}` // #nosec G101
+
+	registries, err := GetPullSecretRegistries(pullSecret)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullSecretRegistry{
+		{Registry: "quay.io", Username: "user1"},
+		{Registry: "registry.example.com"},
+	}, registries)
+
+	updated, err := AddPullSecretRegistry(pullSecret, "registry.internal:5000", "user2", "password2")
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"auths":{"quay.io":{"auth":"dXNlcjE6dG9rZW4x","email":"user1@example.com"},"registry.example.com":{"credsStore":"pass"},"registry.internal:5000":{"auth":"dXNlcjI6cGFzc3dvcmQy"}},"credHelpers":{}}`, updated)
+
+	updated, err = RemovePullSecretRegistry(updated, "registry.example.com")
+	assert.NoError(t, err)
+	registries, err = GetPullSecretRegistries(updated)
+	assert.NoError(t, err)
+	assert.Equal(t, []PullSecretRegistry{
+		{Registry: "quay.io", Username: "user1"},
+		{Registry: "registry.internal:5000", Username: "user2"},
+	}, registries)
+
+	_, err = RemovePullSecretRegistry(updated, "docker.io")
+	assert.EqualError(t, err, "registry docker.io is not in the pull secret")
+	_, err = RemovePullSecretRegistry(secret1, "quay.io")
+	assert.EqualError(t, err, "registry quay.io is the only registry of the pull secret")
+	_, err = AddPullSecretRegistry(secret1, "quay.io", "user:1", "password")
+	assert.EqualError(t, err, "invalid username 'user:1'")
+	_, err = AddPullSecretRegistry(secret4, "quay.io", "user1", "password")
+	assert.Error(t, err)
 }
@@ -0,0 +1,46 @@
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	crcerrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+)
+
+const (
+	imageRegistryNamespace = "openshift-image-registry"
+	// InternalRegistry is the address of the image registry inside the cluster
+	InternalRegistry = "image-registry.openshift-image-registry.svc:5000"
+)
+
+// ExposeImageRegistry enables the default route of the image registry and
+// returns its host
+func ExposeImageRegistry(ctx context.Context, ocConfig oc.Config) (string, error) {
+	_, stderr, err := ocConfig.RunOcCommand("patch", "configs.imageregistry.operator.openshift.io/cluster",
+		"--type", "merge", "-p", `'{"spec":{"defaultRoute":true}}'`)
+	if err != nil {
+		return "", fmt.Errorf("Failed to enable the route of the image registry: %v: %s", err, stderr)
+	}
+
+	var host string
+	getRouteHost := func() error {
+		stdout, stderr, err := ocConfig.RunOcCommand("get", "route", "default-route", "-n", imageRegistryNamespace, "-o", "jsonpath={.spec.host}")
+		if err != nil {
+			logging.Debug(stderr)
+			return &crcerrors.RetriableError{Err: err}
+		}
+		host = strings.TrimSpace(stdout)
+		if host == "" {
+			return &crcerrors.RetriableError{Err: fmt.Errorf("the route of the image registry has no host")}
+		}
+		return nil
+	}
+	if err := crcerrors.Retry(ctx, 2*time.Minute, getRouteHost, 2*time.Second); err != nil {
+		return "", err
+	}
+	return host, nil
+}
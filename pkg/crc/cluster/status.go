@@ -3,34 +3,69 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	"github.com/crc-org/crc/v2/pkg/crc/network/httpproxy"
 )
 
+const stabilityCheckInterval = 30 * time.Second
+
+// StabilityOptions are the criteria for the cluster to be considered stable
+type StabilityOptions struct {
+	// Timeout is the maximum time to wait, 5 minutes are added when a proxy is used
+	Timeout time.Duration
+	// ConsecutiveChecks is the number of consecutive checks where all the operators must be ready
+	ConsecutiveChecks int
+	// IgnoredOperators are not taken into account
+	IgnoredOperators []string
+}
+
+// UnstableClusterError is returned when the cluster operators are not stable before the timeout
+type UnstableClusterError struct {
+	Duration time.Duration
+	// Operators which were not ready at the last check
+	Operators []string
+}
+
+func (e *UnstableClusterError) Error() string {
+	if len(e.Operators) == 0 {
+		return fmt.Sprintf("cluster operators are still not stable after %s", e.Duration.Round(time.Second))
+	}
+	return fmt.Sprintf("cluster operators are still not stable after %s: %s", e.Duration.Round(time.Second), strings.Join(e.Operators, ", "))
+}
+
 // WaitForClusterStable checks that the cluster is running a number of consecutive times
-func WaitForClusterStable(ctx context.Context, ip string, kubeconfigFilePath string, proxy *httpproxy.ProxyConfig) error {
+func WaitForClusterStable(ctx context.Context, ip string, kubeconfigFilePath string, proxy *httpproxy.ProxyConfig, options StabilityOptions) error {
+	if proxy.IsEnabled() {
+		// In case proxy is enabled increase the timeout by 5 mins.
+		options.Timeout += 5 * time.Minute
+	}
+	lister, err := openshiftClient(ip, kubeconfigFilePath)
+	if err != nil {
+		return err
+	}
+	return waitForStable(ctx, lister.ConfigV1().ClusterOperators(), options, stabilityCheckInterval)
+}
+
+func waitForStable(ctx context.Context, lister operatorLister, options StabilityOptions, retryDuration time.Duration) error {
 	if ctx.Err() != nil {
 		return ctx.Err()
 	}
 
 	startTime := time.Now()
+	deadline := startTime.Add(options.Timeout)
 
-	retryDuration := 30 * time.Second
-	retryCount := 20 // 10 minutes
-
-	if proxy.IsEnabled() {
-		// In case proxy is enabled increase the retry count
-		// to 10 and this will add addition 5 mins.
-		retryCount += 10
+	numConsecutive := options.ConsecutiveChecks
+	if numConsecutive < 1 {
+		numConsecutive = 1
 	}
-
-	numConsecutive := 3
 	var count int // holds num of consecutive matches
+	var notReady []string
 
-	for i := 0; i < retryCount; i++ {
-		status, err := GetClusterOperatorsStatus(ctx, ip, kubeconfigFilePath)
+	for {
+		status, err := getStatus(ctx, lister, []string{}, options.IgnoredOperators)
 		if err == nil {
 			// update counter for consecutive matches
 			if status.IsReady() {
@@ -44,14 +79,19 @@ func WaitForClusterStable(ctx context.Context, ip string, kubeconfigFilePath str
 				logging.Info(status.String())
 				count = 0
 			}
+			notReady = status.NotReady()
 			// break if done
 			if count == numConsecutive {
 				logging.Debugf("Cluster took %s to stabilize", time.Since(startTime))
 				return nil
 			}
 		} else {
+			logging.Debugf("Cannot get the cluster operators status: %v", err)
 			count = 0
 		}
+		if time.Now().Add(retryDuration).After(deadline) {
+			break
+		}
 		select {
 		case <-ctx.Done():
 			return ctx.Err()
@@ -59,5 +99,8 @@ func WaitForClusterStable(ctx context.Context, ip string, kubeconfigFilePath str
 		}
 	}
 
-	return fmt.Errorf("cluster operators are still not stable after %s", time.Since(startTime))
+	return &UnstableClusterError{
+		Duration:  time.Since(startTime),
+		Operators: notReady,
+	}
 }
@@ -0,0 +1,35 @@
+package cluster
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForStable(t *testing.T) {
+	assert.NoError(t, waitForStable(context.Background(), lister("co.json"), StabilityOptions{
+		Timeout:           time.Second,
+		ConsecutiveChecks: 3,
+	}, time.Millisecond))
+}
+
+func TestWaitForStableIgnoredOperators(t *testing.T) {
+	assert.NoError(t, waitForStable(context.Background(), lister("co-progressing.json"), StabilityOptions{
+		Timeout:           time.Second,
+		ConsecutiveChecks: 2,
+		IgnoredOperators:  []string{"authentication"},
+	}, time.Millisecond))
+}
+
+func TestWaitForStableTimeout(t *testing.T) {
+	err := waitForStable(context.Background(), lister("co-progressing.json"), StabilityOptions{
+		Timeout:           10 * time.Millisecond,
+		ConsecutiveChecks: 3,
+	}, time.Millisecond)
+	var unstable *UnstableClusterError
+	assert.ErrorAs(t, err, &unstable)
+	assert.Equal(t, []string{"authentication"}, unstable.Operators)
+	assert.Contains(t, err.Error(), ": authentication")
+}
@@ -0,0 +1,284 @@
+package cluster
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	crcerrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+)
+
+// builtinUsers are the users whose password is set by 'crc start'
+var builtinUsers = []string{"kubeadmin", "developer"}
+
+func IsBuiltinUser(username string) bool {
+	for _, builtin := range builtinUsers {
+		if username == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// getHtpasswdSecret returns the resource version and the base64 encoded
+// htpasswd data of the secret of the htpasswd identity provider
+func getHtpasswdSecret(ocConfig oc.Config) (string, string, error) {
+	out, stderr, err := ocConfig.RunOcCommandPrivate("get", "secret", "htpass-secret", "-n", "openshift-config", "-o", `jsonpath="{.metadata.resourceVersion} {.data.htpasswd}"`)
+	if err != nil {
+		return "", "", fmt.Errorf("%s:%v", stderr, err)
+	}
+	resourceVersion, given, _ := strings.Cut(strings.TrimSpace(out), " ")
+	return resourceVersion, given, nil
+}
+
+// updateHtpasswdSecret replaces the htpasswd data of the secret with the
+// data returned by update, which returns an empty string when there is
+// nothing to change. The secret is patched with the resource version it was
+// read with, when it was changed in between the update is retried with the
+// new data.
+func updateHtpasswdSecret(ctx context.Context, ocConfig oc.Config, update func(given string) (string, error)) error {
+	return crcerrors.Retry(ctx, 30*time.Second, func() error {
+		resourceVersion, given, err := getHtpasswdSecret(ocConfig)
+		if err != nil {
+			return err
+		}
+		expected, err := update(given)
+		if err != nil || expected == "" {
+			return err
+		}
+		cmdArgs := []string{"patch", "secret", "htpass-secret", "-p",
+			fmt.Sprintf(`'{"metadata":{"resourceVersion":"%s"},"data":{"htpasswd":"%s"}}'`, resourceVersion, expected),
+			"-n", "openshift-config", "--type", "merge"}
+		if _, stderr, err := ocConfig.RunOcCommandPrivate(cmdArgs...); err != nil {
+			err = fmt.Errorf("Failed to update the htpasswd secret %v: %s", err, stderr)
+			if strings.Contains(stderr, "the object has been modified") {
+				return &crcerrors.RetriableError{Err: err}
+			}
+			return err
+		}
+		return nil
+	}, time.Second)
+}
+
+// htpasswdLines returns the lines of the base64 encoded htpasswd data, indexed by username
+func htpasswdLines(given string) (map[string]string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(given)
+	if err != nil {
+		return nil, err
+	}
+	lines := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(decoded))
+	for scanner.Scan() {
+		username, _, ok := strings.Cut(scanner.Text(), ":")
+		if !ok {
+			continue
+		}
+		lines[username] = scanner.Text()
+	}
+	return lines, nil
+}
+
+func encodeHtpasswd(lines map[string]string) string {
+	var ret []string
+	for _, line := range lines {
+		ret = append(ret, line)
+	}
+	sort.Strings(ret)
+	return base64.StdEncoding.EncodeToString([]byte(strings.Join(ret, "\n")))
+}
+
+// setHtpasswdUser returns the htpasswd data with the password of username
+// set, the user is added when mustExist is false
+func setHtpasswdUser(given, username, password string, mustExist bool) (string, error) {
+	lines, err := htpasswdLines(given)
+	if err != nil {
+		return "", err
+	}
+	_, exists := lines[username]
+	if exists && !mustExist {
+		return "", fmt.Errorf("user %s already exists", username)
+	}
+	if !exists && mustExist {
+		return "", fmt.Errorf("user %s does not exist", username)
+	}
+	hash, err := hashBcrypt(password)
+	if err != nil {
+		return "", err
+	}
+	lines[username] = fmt.Sprintf("%s:%s", username, hash)
+	return encodeHtpasswd(lines), nil
+}
+
+func removeHtpasswdUser(given, username string) (string, error) {
+	lines, err := htpasswdLines(given)
+	if err != nil {
+		return "", err
+	}
+	if _, exists := lines[username]; !exists {
+		return "", fmt.Errorf("user %s does not exist", username)
+	}
+	delete(lines, username)
+	return encodeHtpasswd(lines), nil
+}
+
+func validateUsername(username string) error {
+	if username == "" || strings.ContainsAny(username, ": \t\n/") {
+		return fmt.Errorf("invalid username '%s'", username)
+	}
+	if IsBuiltinUser(username) {
+		return fmt.Errorf("user %s is managed by crc", username)
+	}
+	return nil
+}
+
+// ListUsers returns the users of the htpasswd identity provider
+func ListUsers(ocConfig oc.Config) ([]string, error) {
+	_, given, err := getHtpasswdSecret(ocConfig)
+	if err != nil {
+		return nil, err
+	}
+	lines, err := htpasswdLines(given)
+	if err != nil {
+		return nil, err
+	}
+	var users []string
+	for username := range lines {
+		users = append(users, username)
+	}
+	sort.Strings(users)
+	return users, nil
+}
+
+// AddUser adds a user to the htpasswd identity provider, and binds the
+// cluster roles to it
+func AddUser(ctx context.Context, ocConfig oc.Config, username, password string, clusterRoles []string) error {
+	if err := validateUsername(username); err != nil {
+		return err
+	}
+	logging.Infof("Adding user %s", username)
+	if err := updateHtpasswdSecret(ctx, ocConfig, func(given string) (string, error) {
+		return setHtpasswdUser(given, username, password, false)
+	}); err != nil {
+		return err
+	}
+	for _, role := range clusterRoles {
+		if _, stderr, err := ocConfig.RunOcCommand("adm", "policy", "add-cluster-role-to-user", role, username); err != nil {
+			return fmt.Errorf("Failed to bind cluster role %s to user %s %v: %s", role, username, err, stderr)
+		}
+	}
+	return nil
+}
+
+// SetUserPassword changes the password of a user of the htpasswd identity provider
+func SetUserPassword(ctx context.Context, ocConfig oc.Config, username, password string) error {
+	if err := validateUsername(username); err != nil {
+		return err
+	}
+	logging.Infof("Changing the password of user %s", username)
+	return updateHtpasswdSecret(ctx, ocConfig, func(given string) (string, error) {
+		return setHtpasswdUser(given, username, password, true)
+	})
+}
+
+// roleBindings is the subset of a list of ClusterRoleBinding and RoleBinding
+// objects needed to find the bindings of a user
+type roleBindings struct {
+	Items []struct {
+		Kind     string `json:"kind"`
+		Metadata struct {
+			Namespace string `json:"namespace"`
+		} `json:"metadata"`
+		RoleRef struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"roleRef"`
+		Subjects []struct {
+			Kind string `json:"kind"`
+			Name string `json:"name"`
+		} `json:"subjects"`
+	} `json:"items"`
+}
+
+// removeRoleBindingsCommands returns the oc commands removing username from
+// the cluster role bindings and role bindings of bindingsJSON
+func removeRoleBindingsCommands(bindingsJSON []byte, username string) ([][]string, error) {
+	var bindings roleBindings
+	if err := json.Unmarshal(bindingsJSON, &bindings); err != nil {
+		return nil, err
+	}
+	var commands [][]string
+	seen := map[string]bool{}
+	for _, binding := range bindings.Items {
+		bound := false
+		for _, subject := range binding.Subjects {
+			bound = bound || (subject.Kind == "User" && subject.Name == username)
+		}
+		if !bound {
+			continue
+		}
+		var command []string
+		switch {
+		case binding.Kind == "ClusterRoleBinding":
+			command = []string{"adm", "policy", "remove-cluster-role-from-user", binding.RoleRef.Name, username}
+		case binding.RoleRef.Kind == "Role":
+			command = []string{"adm", "policy", "remove-role-from-user", binding.RoleRef.Name, username, "-n", binding.Metadata.Namespace, "--role-namespace", binding.Metadata.Namespace}
+		default:
+			command = []string{"adm", "policy", "remove-role-from-user", binding.RoleRef.Name, username, "-n", binding.Metadata.Namespace}
+		}
+		if key := strings.Join(command, " "); !seen[key] {
+			seen[key] = true
+			commands = append(commands, command)
+		}
+	}
+	return commands, nil
+}
+
+// RemoveUser removes a user from the htpasswd identity provider, with its
+// user and identity objects, and the role bindings which reference it so that
+// a user added later with the same name does not get its roles
+func RemoveUser(ctx context.Context, ocConfig oc.Config, username string) error {
+	if err := validateUsername(username); err != nil {
+		return err
+	}
+	logging.Infof("Removing user %s", username)
+	if err := updateHtpasswdSecret(ctx, ocConfig, func(given string) (string, error) {
+		return removeHtpasswdUser(given, username)
+	}); err != nil {
+		return err
+	}
+	bindings, stderr, err := ocConfig.RunOcCommand("get", "clusterrolebindings,rolebindings", "--all-namespaces", "-o", "json")
+	if err != nil {
+		return fmt.Errorf("Failed to get the role bindings %v: %s", err, stderr)
+	}
+	commands, err := removeRoleBindingsCommands([]byte(bindings), username)
+	if err != nil {
+		return fmt.Errorf("Cannot parse the role bindings: %w", err)
+	}
+	for _, command := range commands {
+		if _, stderr, err := ocConfig.RunOcCommand(command...); err != nil {
+			return fmt.Errorf("Failed to remove the %s role of user %s %v: %s", command[3], username, err, stderr)
+		}
+	}
+	// the user and identity objects only exist after the first login
+	identities, _, err := ocConfig.RunOcCommand("get", "user", username, "--ignore-not-found", "-o", `jsonpath="{.identities[*]}"`)
+	if err != nil {
+		logging.Debugf("Cannot get the identities of user %s: %v", username, err)
+	}
+	for _, identity := range strings.Fields(identities) {
+		if _, stderr, err := ocConfig.RunOcCommand("delete", "identity", identity, "--ignore-not-found"); err != nil {
+			return fmt.Errorf("Failed to delete identity %s %v: %s", identity, err, stderr)
+		}
+	}
+	if _, stderr, err := ocConfig.RunOcCommand("delete", "user", username, "--ignore-not-found"); err != nil {
+		return fmt.Errorf("Failed to delete user %s %v: %s", username, err, stderr)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetHtpasswdUser(t *testing.T) {
+	htpasswd, err := getHtpasswd(map[string]string{"kubeadmin": "secret", "developer": "developer"}, []string{})
+	require.NoError(t, err)
+
+	htpasswd, err = setHtpasswdUser(htpasswd, "alice", "password1", false)
+	require.NoError(t, err)
+	ok, _, err := compareHtpasswd(htpasswd, map[string]string{"kubeadmin": "secret", "developer": "developer", "alice": "password1"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	_, err = setHtpasswdUser(htpasswd, "alice", "password2", false)
+	assert.EqualError(t, err, "user alice already exists")
+	_, err = setHtpasswdUser(htpasswd, "bob", "password2", true)
+	assert.EqualError(t, err, "user bob does not exist")
+
+	htpasswd, err = setHtpasswdUser(htpasswd, "alice", "password2", true)
+	require.NoError(t, err)
+	ok, _, err = compareHtpasswd(htpasswd, map[string]string{"kubeadmin": "secret", "alice": "password2"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestRemoveHtpasswdUser(t *testing.T) {
+	htpasswd, err := getHtpasswd(map[string]string{"developer": "developer", "alice": "password1"}, []string{})
+	require.NoError(t, err)
+
+	htpasswd, err = removeHtpasswdUser(htpasswd, "alice")
+	require.NoError(t, err)
+	lines, err := htpasswdLines(htpasswd)
+	require.NoError(t, err)
+	assert.Len(t, lines, 1)
+	assert.Contains(t, lines, "developer")
+
+	_, err = removeHtpasswdUser(htpasswd, "alice")
+	assert.EqualError(t, err, "user alice does not exist")
+}
+
+func TestValidateUsername(t *testing.T) {
+	assert.NoError(t, validateUsername("alice"))
+	assert.EqualError(t, validateUsername("kubeadmin"), "user kubeadmin is managed by crc")
+	assert.EqualError(t, validateUsername("al:ice"), "invalid username 'al:ice'")
+	assert.Error(t, validateUsername(""))
+}
+
+// htpasswdSecretRunner runs the oc commands reading and patching the
+// htpasswd secret, the secret is changed by onPatch before it is patched
+type htpasswdSecretRunner struct {
+	resourceVersion int
+	htpasswd        string
+	onPatch         func(r *htpasswdSecretRunner)
+	patches         int
+}
+
+func (r *htpasswdSecretRunner) Run(command string, args ...string) (string, string, error) {
+	return r.RunPrivate(command, args...)
+}
+
+func (r *htpasswdSecretRunner) RunPrivileged(_ string, cmdAndArgs ...string) (string, string, error) {
+	return r.RunPrivate(cmdAndArgs[0], cmdAndArgs[1:]...)
+}
+
+func (r *htpasswdSecretRunner) RunPrivate(_ string, args ...string) (string, string, error) {
+	switch args[2] {
+	case "get":
+		return fmt.Sprintf("%d %s", r.resourceVersion, r.htpasswd), "", nil
+	case "patch":
+		r.patches++
+		if r.onPatch != nil {
+			r.onPatch(r)
+		}
+		var patch struct {
+			Metadata struct {
+				ResourceVersion string `json:"resourceVersion"`
+			} `json:"metadata"`
+			Data struct {
+				Htpasswd string `json:"htpasswd"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(strings.Trim(args[6], "'")), &patch); err != nil {
+			return "", "", err
+		}
+		if patch.Metadata.ResourceVersion != fmt.Sprint(r.resourceVersion) {
+			return "", `Error from server (Conflict): Operation cannot be fulfilled on secrets "htpass-secret": the object has been modified; please apply your changes to the latest version and try again`, errors.New("exit status 1")
+		}
+		r.resourceVersion++
+		r.htpasswd = patch.Data.Htpasswd
+		return "", "", nil
+	}
+	return "", "", fmt.Errorf("unexpected command %v", args)
+}
+
+func TestAddUserConcurrently(t *testing.T) {
+	htpasswd, err := getHtpasswd(map[string]string{"developer": "developer"}, []string{})
+	require.NoError(t, err)
+	runner := &htpasswdSecretRunner{
+		resourceVersion: 1,
+		htpasswd:        htpasswd,
+	}
+	// another user is added between the first read and patch of the secret
+	runner.onPatch = func(r *htpasswdSecretRunner) {
+		r.onPatch = nil
+		r.htpasswd, err = setHtpasswdUser(r.htpasswd, "bob", "password2", false)
+		require.NoError(t, err)
+		r.resourceVersion++
+	}
+	ocConfig := oc.Config{Runner: runner, OcExecutablePath: "oc", Timeout: "30s"}
+
+	require.NoError(t, AddUser(context.Background(), ocConfig, "alice", "password1", nil))
+	assert.Equal(t, 2, runner.patches)
+	ok, _, err := compareHtpasswd(runner.htpasswd, map[string]string{"developer": "developer", "alice": "password1", "bob": "password2"})
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestUpdateHtpasswdSecretUnchanged(t *testing.T) {
+	htpasswd, err := getHtpasswd(map[string]string{"developer": "developer"}, []string{})
+	require.NoError(t, err)
+	runner := &htpasswdSecretRunner{
+		resourceVersion: 1,
+		htpasswd:        htpasswd,
+	}
+	ocConfig := oc.Config{Runner: runner, OcExecutablePath: "oc", Timeout: "30s"}
+
+	require.NoError(t, updateHtpasswdSecret(context.Background(), ocConfig, func(string) (string, error) {
+		return "", nil
+	}))
+	assert.Equal(t, 0, runner.patches)
+	assert.EqualError(t, RemoveUser(context.Background(), ocConfig, "alice"), "user alice does not exist")
+	assert.Equal(t, 0, runner.patches)
+}
+
+func TestRemoveRoleBindingsCommands(t *testing.T) {
+	bindings := `{"items": [
+		{"kind": "ClusterRoleBinding", "metadata": {"name": "cluster-admin-0"}, "roleRef": {"kind": "ClusterRole", "name": "cluster-admin"}, "subjects": [{"kind": "User", "name": "alice"}]},
+		{"kind": "ClusterRoleBinding", "metadata": {"name": "cluster-admin-1"}, "roleRef": {"kind": "ClusterRole", "name": "cluster-admin"}, "subjects": [{"kind": "User", "name": "alice"}, {"kind": "User", "name": "bob"}]},
+		{"kind": "ClusterRoleBinding", "metadata": {"name": "view"}, "roleRef": {"kind": "ClusterRole", "name": "view"}, "subjects": [{"kind": "ServiceAccount", "name": "alice", "namespace": "default"}]},
+		{"kind": "RoleBinding", "metadata": {"name": "edit", "namespace": "project"}, "roleRef": {"kind": "ClusterRole", "name": "edit"}, "subjects": [{"kind": "User", "name": "alice"}]},
+		{"kind": "RoleBinding", "metadata": {"name": "deployer", "namespace": "project"}, "roleRef": {"kind": "Role", "name": "deployer"}, "subjects": [{"kind": "User", "name": "alice"}]},
+		{"kind": "RoleBinding", "metadata": {"name": "admin", "namespace": "project"}, "roleRef": {"kind": "ClusterRole", "name": "admin"}, "subjects": null}
+	]}`
+	commands, err := removeRoleBindingsCommands([]byte(bindings), "alice")
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{
+		{"adm", "policy", "remove-cluster-role-from-user", "cluster-admin", "alice"},
+		{"adm", "policy", "remove-role-from-user", "edit", "alice", "-n", "project"},
+		{"adm", "policy", "remove-role-from-user", "deployer", "alice", "-n", "project", "--role-namespace", "project"},
+	}, commands)
+}
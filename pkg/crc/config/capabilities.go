@@ -0,0 +1,86 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/spf13/cast"
+)
+
+// Capabilities are the optional components of the OpenShift cluster
+const (
+	CapabilityMonitoring    = "monitoring"
+	CapabilityConsole       = "console"
+	CapabilityImageRegistry = "image-registry"
+	CapabilityOLMCatalogs   = "olm-catalogs"
+	CapabilityInsights      = "insights"
+	CapabilitySamples       = "samples"
+)
+
+var capabilities = []string{
+	CapabilityMonitoring,
+	CapabilityConsole,
+	CapabilityImageRegistry,
+	CapabilityOLMCatalogs,
+	CapabilityInsights,
+	CapabilitySamples,
+}
+
+// ParseCapabilities parses a comma-separated list of capabilities, the ones
+// prefixed with '-' are disabled, the other ones are enabled. The capabilities
+// which are not listed keep the state they have in the bundle.
+func ParseCapabilities(value string) (enabled []string, disabled []string, err error) {
+	for _, item := range splitList(value) {
+		name := strings.TrimPrefix(item, "+")
+		list := &enabled
+		if strings.HasPrefix(item, "-") {
+			name = strings.TrimPrefix(item, "-")
+			list = &disabled
+		}
+		if !crcstrings.Contains(capabilities, name) {
+			return nil, nil, fmt.Errorf("unknown capability '%s', valid capabilities are %s", name, strings.Join(capabilities, ", "))
+		}
+		if crcstrings.Contains(enabled, name) || crcstrings.Contains(disabled, name) {
+			return nil, nil, fmt.Errorf("capability '%s' is listed more than once", name)
+		}
+		*list = append(*list, name)
+	}
+	return enabled, disabled, nil
+}
+
+// GetCapabilities returns the capabilities to enable and to disable,
+// enable-cluster-monitoring enables monitoring when it is not listed
+func GetCapabilities(cfg Storage) (enabled []string, disabled []string) {
+	enabled, disabled, err := ParseCapabilities(cfg.Get(ClusterCapabilities).AsString())
+	if err != nil {
+		return nil, nil
+	}
+	if cfg.Get(EnableClusterMonitoring).AsBool() && !crcstrings.Contains(disabled, CapabilityMonitoring) &&
+		!crcstrings.Contains(enabled, CapabilityMonitoring) {
+		enabled = append(enabled, CapabilityMonitoring)
+	}
+	return enabled, disabled
+}
+
+func validateCapabilities(value interface{}) (bool, string) {
+	if _, _, err := ParseCapabilities(cast.ToString(value)); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+// checkMonitoring checks that monitoring is not both enabled and disabled
+func checkMonitoring(cfg *Config) *Problem {
+	if !cfg.Get(EnableClusterMonitoring).AsBool() {
+		return nil
+	}
+	_, disabled, err := ParseCapabilities(cfg.Get(ClusterCapabilities).AsString())
+	if err != nil || !crcstrings.Contains(disabled, CapabilityMonitoring) {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{EnableClusterMonitoring, ClusterCapabilities},
+		Message: fmt.Sprintf("Cluster monitoring is enabled, and disabled by '-%s'", CapabilityMonitoring),
+	}
+}
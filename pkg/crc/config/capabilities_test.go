@@ -0,0 +1,59 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCapabilities(t *testing.T) {
+	enabled, disabled, err := ParseCapabilities("+monitoring, -console,-insights,samples")
+	require.NoError(t, err)
+	assert.Equal(t, []string{CapabilityMonitoring, CapabilitySamples}, enabled)
+	assert.Equal(t, []string{CapabilityConsole, CapabilityInsights}, disabled)
+
+	enabled, disabled, err = ParseCapabilities("")
+	require.NoError(t, err)
+	assert.Empty(t, enabled)
+	assert.Empty(t, disabled)
+
+	_, _, err = ParseCapabilities("-foo")
+	assert.EqualError(t, err, "unknown capability 'foo', valid capabilities are monitoring, console, image-registry, olm-catalogs, insights, samples")
+	_, _, err = ParseCapabilities("console,-console")
+	assert.EqualError(t, err, "capability 'console' is listed more than once")
+}
+
+func TestGetCapabilities(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	_, err = cfg.Set(ClusterCapabilities, "-console")
+	require.NoError(t, err)
+
+	enabled, disabled := GetCapabilities(cfg)
+	assert.Empty(t, enabled)
+	assert.Equal(t, []string{CapabilityConsole}, disabled)
+
+	_, err = cfg.Set(EnableClusterMonitoring, true)
+	require.NoError(t, err)
+	enabled, disabled = GetCapabilities(cfg)
+	assert.Equal(t, []string{CapabilityMonitoring}, enabled)
+	assert.Equal(t, []string{CapabilityConsole}, disabled)
+}
+
+func TestValidateMonitoringCapability(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	_, err = cfg.Set(EnableClusterMonitoring, true)
+	require.NoError(t, err)
+
+	assert.Equal(t, []Problem{{
+		Keys:    []string{EnableClusterMonitoring, ClusterCapabilities},
+		Message: "Cluster monitoring is enabled, and disabled by '-monitoring'",
+	}}, cfg.ValidateValues(map[string]interface{}{
+		ClusterCapabilities: "-console,-monitoring",
+	}))
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		ClusterCapabilities: "-console",
+	}))
+}
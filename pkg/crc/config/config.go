@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"reflect"
+	"sort"
 
 	"github.com/crc-org/crc/v2/pkg/crc/preset"
 	"github.com/spf13/cast"
@@ -21,6 +22,9 @@ type Config struct {
 	storage        RawStorage
 	secretStorage  RawStorage
 	settingsByName map[string]Setting
+	// registerSettings registers again the settings whose validation
+	// functions read this configuration, see withValues
+	registerSettings func(cfg *Config)
 
 	valueChangeNotifiers map[string]ValueChangedFunc
 }
@@ -87,64 +91,74 @@ func (c *Config) Set(key string, value interface{}) (string, error) {
 		return "", err
 	}
 
+	castValue, err := setting.cast(value)
+	if err != nil {
+		return "", err
+	}
+
+	// Make sure if user try to set same value which
+	// is default then just unset the value which
+	// anyway make it default and don't update it
+	// ~/.crc/crc.json (viper config) file.
+	if setting.defaultValue == castValue {
+		if _, err := c.Unset(key); err != nil {
+			return "", err
+		}
+		return c.settingsByName[key].callbackFn(key, castValue), nil
+	}
+
+	if setting.isSecret {
+		if err := c.secretStorage.Set(key, castValue); err != nil {
+			return "", err
+		}
+	} else {
+		if err := c.storage.Set(key, castValue); err != nil {
+			return "", err
+		}
+	}
+
+	c.valueChangeNotify(key, value)
+
+	return c.settingsByName[key].callbackFn(key, castValue), nil
+}
+
+// cast converts value to the type of the default value of the setting
+func (s Setting) cast(value interface{}) (interface{}, error) {
 	var castValue interface{}
 	var err error
-	switch setting.defaultValue.(type) {
+	switch s.defaultValue.(type) {
 	case int:
 		castValue, err = cast.ToIntE(value)
 		if err != nil {
-			return "", fmt.Errorf(invalidProp, value, key, err)
+			return nil, fmt.Errorf(invalidProp, value, s.Name, err)
 		}
 	case uint:
 		castValue, err = cast.ToUintE(value)
 		if err != nil {
-			return "", fmt.Errorf(invalidProp, value, key, err)
+			return nil, fmt.Errorf(invalidProp, value, s.Name, err)
 		}
 	case string, Secret:
 		castValue = cast.ToString(value)
 	case bool:
 		castValue, err = cast.ToBoolE(value)
 		if err != nil {
-			return "", fmt.Errorf(invalidProp, value, key, err)
+			return nil, fmt.Errorf(invalidProp, value, s.Name, err)
 		}
 	case Path:
 		path := cast.ToString(value)
 		path, err := filepath.Abs(path)
 		if err != nil {
-			return "", fmt.Errorf(invalidProp, value, key, err)
+			return nil, fmt.Errorf(invalidProp, value, s.Name, err)
 		}
 		castValue = path
 
 	case preset.Preset:
 		castValue = cast.ToString(value)
 	default:
-		return "", fmt.Errorf(invalidType, value, key)
-	}
-
-	// Make sure if user try to set same value which
-	// is default then just unset the value which
-	// anyway make it default and don't update it
-	// ~/.crc/crc.json (viper config) file.
-	if setting.defaultValue == castValue {
-		if _, err := c.Unset(key); err != nil {
-			return "", err
-		}
-		return c.settingsByName[key].callbackFn(key, castValue), nil
-	}
-
-	if setting.isSecret {
-		if err := c.secretStorage.Set(key, castValue); err != nil {
-			return "", err
-		}
-	} else {
-		if err := c.storage.Set(key, castValue); err != nil {
-			return "", err
-		}
+		return nil, fmt.Errorf(invalidType, value, s.Name)
 	}
 
-	c.valueChangeNotify(key, value)
-
-	return c.settingsByName[key].callbackFn(key, castValue), nil
+	return castValue, nil
 }
 
 // Unset unsets a given config key
@@ -187,6 +201,34 @@ func (c *Config) valueChangeNotify(key string, value interface{}) {
 	changeNotifier(c, key, value)
 }
 
+// notifyChanges calls the notifiers of the settings whose value is different
+// from before, and returns the messages of their callbacks sorted by key.
+func (c *Config) notifyChanges(before map[string]SettingValue) []string {
+	// notifiers can change the default value of other settings, for example when the preset changes
+	for key, value := range c.AllConfigs() {
+		if !reflect.DeepEqual(before[key].Value, value.Value) {
+			c.valueChangeNotify(key, value.Value)
+		}
+	}
+
+	after := c.AllConfigs()
+	var keys []string
+	for key := range after {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var messages []string
+	for _, key := range keys {
+		if reflect.DeepEqual(before[key].Value, after[key].Value) {
+			continue
+		}
+		if message := c.settingsByName[key].callbackFn(key, after[key].Value); message != "" {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
 func (c *Config) Get(key string) SettingValue {
 	setting, ok := c.settingsByName[key]
 	if !ok {
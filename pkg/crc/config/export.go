@@ -0,0 +1,166 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+)
+
+// batchStorage is implemented by the storages which can change several
+// values with a single write
+type batchStorage interface {
+	SetAll(values map[string]interface{}) error
+}
+
+// overlayStorage returns values before looking them up in the underlying storage
+type overlayStorage struct {
+	RawStorage
+	values map[string]interface{}
+}
+
+func (s *overlayStorage) Get(key string) interface{} {
+	if value, ok := s.values[key]; ok {
+		return value
+	}
+	return s.RawStorage.Get(key)
+}
+
+// Export returns the values of the settings which are not set to their
+// default value. Secrets are omitted.
+func (c *Config) Export() map[string]interface{} {
+	values := map[string]interface{}{}
+	for key, value := range c.AllConfigs() {
+		if value.IsDefault || value.IsSecret || value.Invalid {
+			continue
+		}
+		values[key] = value.Value
+	}
+	return values
+}
+
+// Import sets all values at once, after checking that each of them is valid.
+// Settings which depend on other settings are validated against the imported
+// values. Nothing is changed when one of the values is invalid or cannot be
+// written. It returns the messages of the settings whose value changed.
+func (c *Config) Import(values map[string]interface{}) ([]string, error) {
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	castValues := map[string]interface{}{}
+	secretValues := map[string]interface{}{}
+	for _, key := range keys {
+		setting, ok := c.settingsByName[key]
+		if !ok {
+			return nil, fmt.Errorf(configPropDoesntExistMsg, key)
+		}
+		castValue, err := setting.cast(values[key])
+		if err != nil {
+			return nil, err
+		}
+		if setting.isSecret {
+			secretValues[key] = castValue
+		} else {
+			castValues[key] = castValue
+		}
+	}
+
+	if err := c.validateAll(keys, castValues, secretValues); err != nil {
+		return nil, err
+	}
+
+	before := c.AllConfigs()
+	// the secret storage is usually the system keyring, which is more likely
+	// to fail than the configuration file, so it is written first
+	rollback, err := setAll(c.secretStorage, secretValues)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := setAll(c.storage, castValues); err != nil {
+		rollback()
+		return nil, err
+	}
+	return c.notifyChanges(before), nil
+}
+
+// validateAll validates keys with values and secretValues overlaid on the
+// storages, so that validation functions reading other settings see the new
+// values
+func (c *Config) validateAll(keys []string, values, secretValues map[string]interface{}) error {
+	overlay := c.withValues(values, secretValues)
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			value = secretValues[key]
+		}
+		if err := overlay.validate(key, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// withValues returns a copy of the configuration in which values and
+// secretValues are overlaid on the storages. The receiver is not modified,
+// so that it can be validated against while it is used concurrently.
+func (c *Config) withValues(values, secretValues map[string]interface{}) *Config {
+	overlay := New(&overlayStorage{RawStorage: c.storage, values: values},
+		&overlayStorage{RawStorage: c.secretStorage, values: secretValues})
+	for key, setting := range c.settingsByName {
+		overlay.settingsByName[key] = setting
+	}
+	// some validation functions read the configuration they were registered
+	// with, registering them again makes them read the overlaid values
+	if c.registerSettings != nil {
+		c.registerSettings(overlay)
+	}
+	return overlay
+}
+
+// setAll sets values in storage. When one of them cannot be set, the values
+// which were already set are restored. On success, it returns a function
+// restoring the values which were replaced.
+func setAll(storage RawStorage, values map[string]interface{}) (func(), error) {
+	previous := map[string]interface{}{}
+	for key := range values {
+		previous[key] = storage.Get(key)
+	}
+	rollback := func(keys []string) {
+		for _, key := range keys {
+			var err error
+			if previous[key] == nil {
+				err = storage.Unset(key)
+			} else {
+				err = storage.Set(key, previous[key])
+			}
+			if err != nil {
+				logging.Warnf("Failed to restore the previous value of %s: %v", key, err)
+			}
+		}
+	}
+
+	var keys []string
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if batch, ok := storage.(batchStorage); ok {
+		// batch storages change all the values or none of them
+		if len(values) > 0 {
+			if err := batch.SetAll(values); err != nil {
+				return nil, err
+			}
+		}
+		return func() { rollback(keys) }, nil
+	}
+	for i, key := range keys {
+		if err := storage.Set(key, values[key]); err != nil {
+			rollback(keys[:i])
+			return nil, err
+		}
+	}
+	return func() { rollback(keys) }, nil
+}
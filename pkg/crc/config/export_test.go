@@ -0,0 +1,139 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	crcpreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExport(t *testing.T) {
+	config, err := newTestConfig(filepath.Join(t.TempDir(), "crc.json"), "CRC")
+	require.NoError(t, err)
+	config.AddSetting("password", Secret(""), validateString, SuccessfullyApplied, "")
+
+	assert.Empty(t, config.Export())
+
+	_, err = config.Set(nameServer, "1.1.1.1")
+	require.NoError(t, err)
+	_, err = config.Set("password", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		nameServer: "1.1.1.1",
+	}, config.Export())
+}
+
+func TestImport(t *testing.T) {
+	configFile := filepath.Join(t.TempDir(), "crc.json")
+	config, err := newTestConfig(configFile, "CRC")
+	require.NoError(t, err)
+
+	messages, err := config.Import(map[string]interface{}{
+		cpus:       8,
+		nameServer: "1.1.1.1",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"Changes to configuration property 'cpus' are only applied when the CRC instance is started.\n" +
+			"If you already have a running CRC instance, then for this configuration change to take effect, " +
+			"stop the CRC instance with 'crc stop' and restart it with 'crc start'.",
+		"Successfully configured nameservers to 1.1.1.1",
+	}, messages)
+
+	bin, err := os.ReadFile(configFile)
+	require.NoError(t, err)
+	var content map[string]interface{}
+	require.NoError(t, json.Unmarshal(bin, &content))
+	assert.Equal(t, map[string]interface{}{
+		cpus:       float64(8),
+		nameServer: "1.1.1.1",
+	}, content)
+}
+
+func TestImportInvalid(t *testing.T) {
+	config, err := newTestConfig(filepath.Join(t.TempDir(), "crc.json"), "CRC")
+	require.NoError(t, err)
+
+	_, err = config.Import(map[string]interface{}{
+		cpus:       1,
+		nameServer: "1.1.1.1",
+	})
+	assert.EqualError(t, err, "Value '1' for configuration property 'cpus' is invalid, reason: requires CPUs >= 4")
+	_, err = config.Import(map[string]interface{}{
+		"foo":      "bar",
+		nameServer: "1.1.1.1",
+	})
+	assert.EqualError(t, err, "Configuration property 'foo' does not exist")
+	assert.True(t, config.Get(nameServer).IsDefault)
+}
+
+// Check that settings depending on the preset are validated against the imported preset
+func TestImportPreset(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	cpus := constants.GetDefaultCPUs(crcpreset.Microshift)
+	_, err = cfg.Import(map[string]interface{}{
+		Preset: crcpreset.Microshift.String(),
+		CPUs:   cpus,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, crcpreset.Microshift, GetPreset(cfg))
+	assert.Equal(t, cpus, cfg.Get(CPUs).AsUInt())
+	assert.Equal(t, constants.GetDefaultMemory(crcpreset.Microshift), cfg.Get(Memory).AsUInt())
+}
+
+// failingStorage fails to set the key failingKey
+type failingStorage struct {
+	*InMemoryStorage
+	failingKey string
+}
+
+func (s *failingStorage) Set(key string, value interface{}) error {
+	if key == s.failingKey {
+		return errors.New("cannot write " + key)
+	}
+	return s.InMemoryStorage.Set(key, value)
+}
+
+func TestImportRollback(t *testing.T) {
+	storage := &failingStorage{InMemoryStorage: NewInMemoryStorage(map[string]interface{}{
+		nameServer: "8.8.8.8",
+	})}
+	secretStorage := &failingStorage{InMemoryStorage: NewEmptyInMemoryStorage()}
+	config := New(storage, secretStorage)
+	config.AddSetting(cpus, 4, validateUint, RequiresRestartMsg, "")
+	config.AddSetting(nameServer, "", validateIPAddress, SuccessfullyApplied, "")
+	config.AddSetting("password", Secret(""), validateString, SuccessfullyApplied, "")
+	config.AddSetting("token", Secret(""), validateString, SuccessfullyApplied, "")
+
+	// the configuration file is not changed when a secret cannot be written
+	secretStorage.failingKey = "token"
+	_, err := config.Import(map[string]interface{}{
+		cpus:       8,
+		nameServer: "1.1.1.1",
+		"password": "secret",
+		"token":    "secret",
+	})
+	assert.EqualError(t, err, "cannot write token")
+	assert.Equal(t, map[string]interface{}{nameServer: "8.8.8.8"}, storage.storage)
+	assert.Empty(t, secretStorage.storage)
+
+	// the secrets are restored when the configuration file cannot be written
+	secretStorage.failingKey = ""
+	storage.failingKey = nameServer
+	_, err = config.Import(map[string]interface{}{
+		cpus:       8,
+		nameServer: "1.1.1.1",
+		"password": "secret",
+	})
+	assert.EqualError(t, err, "cannot write nameservers")
+	assert.Equal(t, map[string]interface{}{nameServer: "8.8.8.8"}, storage.storage)
+	assert.Empty(t, secretStorage.storage)
+}
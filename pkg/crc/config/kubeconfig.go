@@ -0,0 +1,32 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+const (
+	DefaultAdminContext     = "crc-admin"
+	DefaultDeveloperContext = "crc-developer"
+)
+
+func validateContextName(value interface{}) (bool, string) {
+	name := cast.ToString(value)
+	if name == "" || strings.ContainsAny(name, " \t\n") {
+		return false, fmt.Sprintf("'%s' is not a valid kubeconfig context name", name)
+	}
+	return true, ""
+}
+
+// checkKubeconfigContexts checks that kubeadmin and developer have different contexts
+func checkKubeconfigContexts(cfg *Config) *Problem {
+	if cfg.Get(KubeconfigAdminContext).AsString() != cfg.Get(KubeconfigDeveloperContext).AsString() {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{KubeconfigAdminContext, KubeconfigDeveloperContext},
+		Message: "The kubeadmin and developer kubeconfig contexts must have different names",
+	}
+}
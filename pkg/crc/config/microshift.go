@@ -0,0 +1,121 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/preset"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/spf13/cast"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+var microshiftLogLevels = []string{"Normal", "Debug", "Trace", "TraceAll"}
+
+var microshiftSettings = []string{
+	MicroShiftLogLevel,
+	MicroShiftSubjectAltNames,
+	MicroShiftBaseDomain,
+	MicroShiftClusterNetwork,
+	MicroShiftServiceNetwork,
+	MicroShiftManifestsDir,
+}
+
+func validateMicroShiftLogLevel(value interface{}) (bool, string) {
+	if level := cast.ToString(value); level != "" && !crcstrings.Contains(microshiftLogLevels, level) {
+		return false, fmt.Sprintf("valid log levels are %s", strings.Join(microshiftLogLevels, ", "))
+	}
+	return true, ""
+}
+
+func validateSubjectAltNames(value interface{}) (bool, string) {
+	for _, name := range splitList(cast.ToString(value)) {
+		if net.ParseIP(name) != nil {
+			continue
+		}
+		if errs := validation.IsDNS1123Subdomain(name); len(errs) != 0 {
+			return false, fmt.Sprintf("'%s' is neither an IP address nor a DNS name", name)
+		}
+	}
+	return true, ""
+}
+
+func validateBaseDomain(value interface{}) (bool, string) {
+	if domain := cast.ToString(value); domain != "" {
+		if errs := validation.IsDNS1123Subdomain(domain); len(errs) != 0 {
+			return false, fmt.Sprintf("'%s' is not a valid domain", domain)
+		}
+	}
+	return true, ""
+}
+
+func validateCIDR(value interface{}) (bool, string) {
+	if cidr := cast.ToString(value); cidr != "" {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return false, fmt.Sprintf("'%s' is not a valid CIDR", cidr)
+		}
+	}
+	return true, ""
+}
+
+func validateManifestsDir(value interface{}) (bool, string) {
+	dir := cast.ToString(value)
+	if dir == "" {
+		return true, ""
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return false, err.Error()
+	}
+	if !info.IsDir() {
+		return false, fmt.Sprintf("%s is not a directory", dir)
+	}
+	return true, ""
+}
+
+// GetMicroShiftSubjectAltNames returns the list of names set in microshift-subject-alt-names
+func GetMicroShiftSubjectAltNames(config Storage) []string {
+	return splitList(config.Get(MicroShiftSubjectAltNames).AsString())
+}
+
+// checkMicroShiftSettings checks that the microshift settings are only used
+// with the microshift preset
+func checkMicroShiftSettings(cfg *Config) *Problem {
+	if GetPreset(cfg) == preset.Microshift {
+		return nil
+	}
+	var keys []string
+	for _, key := range microshiftSettings {
+		if !cfg.Get(key).IsDefault {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	return &Problem{
+		Keys:    append(keys, Preset),
+		Message: fmt.Sprintf("The microshift settings are only used by the %s preset", preset.Microshift),
+	}
+}
+
+// checkMicroShiftNetworks checks that the cluster and service networks do not overlap
+func checkMicroShiftNetworks(cfg *Config) *Problem {
+	_, clusterNetwork, err := net.ParseCIDR(cfg.Get(MicroShiftClusterNetwork).AsString())
+	if err != nil {
+		return nil
+	}
+	_, serviceNetwork, err := net.ParseCIDR(cfg.Get(MicroShiftServiceNetwork).AsString())
+	if err != nil {
+		return nil
+	}
+	if !clusterNetwork.Contains(serviceNetwork.IP) && !serviceNetwork.Contains(clusterNetwork.IP) {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{MicroShiftClusterNetwork, MicroShiftServiceNetwork},
+		Message: "The cluster and service networks of MicroShift overlap",
+	}
+}
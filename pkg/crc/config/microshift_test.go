@@ -0,0 +1,50 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateMicroShiftValues(t *testing.T) {
+	ok, _ := validateSubjectAltNames("api.example.com, 192.168.1.10")
+	assert.True(t, ok)
+	ok, msg := validateSubjectAltNames("api.example.com,not a name")
+	assert.False(t, ok)
+	assert.Equal(t, "'not a name' is neither an IP address nor a DNS name", msg)
+	ok, msg = validateMicroShiftLogLevel("debug")
+	assert.False(t, ok)
+	assert.Equal(t, "valid log levels are Normal, Debug, Trace, TraceAll", msg)
+	ok, msg = validateCIDR("10.42.0.0")
+	assert.False(t, ok)
+	assert.Equal(t, "'10.42.0.0' is not a valid CIDR", msg)
+}
+
+func TestValidateMicroShiftSettings(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Problem{{
+		Keys:    []string{MicroShiftLogLevel, Preset},
+		Message: "The microshift settings are only used by the microshift preset",
+	}}, cfg.ValidateValues(map[string]interface{}{
+		Preset:             "openshift",
+		MicroShiftLogLevel: "Debug",
+	}))
+
+	assert.Equal(t, []Problem{{
+		Keys:    []string{MicroShiftClusterNetwork, MicroShiftServiceNetwork},
+		Message: "The cluster and service networks of MicroShift overlap",
+	}}, cfg.ValidateValues(map[string]interface{}{
+		Preset:                   "microshift",
+		MicroShiftClusterNetwork: "10.42.0.0/16",
+		MicroShiftServiceNetwork: "10.42.128.0/17",
+	}))
+
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		Preset:                   "microshift",
+		MicroShiftClusterNetwork: "10.42.0.0/16",
+		MicroShiftServiceNetwork: "10.43.0.0/16",
+	}))
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/spf13/cast"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var evictionSignals = []string{
+	"memory.available",
+	"nodefs.available",
+	"nodefs.inodesFree",
+	"imagefs.available",
+	"imagefs.inodesFree",
+	"pid.available",
+}
+
+var percentageRegexp = regexp.MustCompile(`^\d+(\.\d+)?%$`)
+
+// ParseEvictionThresholds parses a comma-separated list of signal<threshold,
+// such as 'memory.available<200Mi,nodefs.available<5%'
+func ParseEvictionThresholds(value string) (map[string]string, error) {
+	thresholds := map[string]string{}
+	for _, item := range splitList(value) {
+		signal, threshold, found := strings.Cut(item, "<")
+		signal, threshold = strings.TrimSpace(signal), strings.TrimSpace(threshold)
+		if !found {
+			return nil, fmt.Errorf("invalid eviction threshold '%s', the format is signal<threshold", item)
+		}
+		if !crcstrings.Contains(evictionSignals, signal) {
+			return nil, fmt.Errorf("unknown eviction signal '%s', valid signals are %s", signal, strings.Join(evictionSignals, ", "))
+		}
+		if _, err := resource.ParseQuantity(threshold); err != nil && !percentageRegexp.MatchString(threshold) {
+			return nil, fmt.Errorf("invalid threshold '%s' for %s, it must be a quantity or a percentage", threshold, signal)
+		}
+		if _, ok := thresholds[signal]; ok {
+			return nil, fmt.Errorf("eviction signal '%s' is listed more than once", signal)
+		}
+		thresholds[signal] = threshold
+	}
+	return thresholds, nil
+}
+
+func validateEvictionThresholds(value interface{}) (bool, string) {
+	if _, err := ParseEvictionThresholds(cast.ToString(value)); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+func validatePercentage(value interface{}) (bool, string) {
+	percentage, err := cast.ToUintE(value)
+	if err != nil || percentage > 100 {
+		return false, fmt.Sprintf("could not convert '%s' to a percentage between 0 and 100", value)
+	}
+	return true, ""
+}
+
+func validateSize(value interface{}) (bool, string) {
+	if size := cast.ToString(value); size != "" {
+		if _, err := resource.ParseQuantity(size); err != nil {
+			return false, fmt.Sprintf("'%s' is not a valid size", size)
+		}
+	}
+	return true, ""
+}
+
+// GetKubeletEvictionHard returns the thresholds set in kubelet-eviction-hard
+func GetKubeletEvictionHard(config Storage) map[string]string {
+	thresholds, err := ParseEvictionThresholds(config.Get(KubeletEvictionHard).AsString())
+	if err != nil {
+		return nil
+	}
+	return thresholds
+}
+
+// GetCrioLogSizeMax returns the size in bytes set in crio-log-size-max, 0 when it is not set
+func GetCrioLogSizeMax(config Storage) int64 {
+	size, err := resource.ParseQuantity(config.Get(CrioLogSizeMax).AsString())
+	if err != nil {
+		return 0
+	}
+	return size.Value()
+}
+
+// checkImageGCThresholds checks that image garbage collection stops below
+// the threshold where it starts
+func checkImageGCThresholds(cfg *Config) *Problem {
+	high := cfg.Get(KubeletImageGCHighThreshold).AsUInt()
+	low := cfg.Get(KubeletImageGCLowThreshold).AsUInt()
+	if high == 0 || low == 0 || low < high {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{KubeletImageGCHighThreshold, KubeletImageGCLowThreshold},
+		Message: "The low threshold of the image garbage collection must be lower than the high threshold",
+	}
+}
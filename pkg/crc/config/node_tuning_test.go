@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseEvictionThresholds(t *testing.T) {
+	thresholds, err := ParseEvictionThresholds("memory.available<100Mi, nodefs.available < 5%,pid.available<1000")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"memory.available": "100Mi",
+		"nodefs.available": "5%",
+		"pid.available":    "1000",
+	}, thresholds)
+
+	_, err = ParseEvictionThresholds("memory.available=100Mi")
+	assert.EqualError(t, err, "invalid eviction threshold 'memory.available=100Mi', the format is signal<threshold")
+	_, err = ParseEvictionThresholds("memory.free<100Mi")
+	assert.ErrorContains(t, err, "unknown eviction signal 'memory.free'")
+	_, err = ParseEvictionThresholds("nodefs.available<5 percent")
+	assert.EqualError(t, err, "invalid threshold '5 percent' for nodefs.available, it must be a quantity or a percentage")
+	_, err = ParseEvictionThresholds("nodefs.available<5%,nodefs.available<10%")
+	assert.EqualError(t, err, "eviction signal 'nodefs.available' is listed more than once")
+}
+
+func TestGetCrioLogSizeMax(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), GetCrioLogSizeMax(cfg))
+	_, err = cfg.Set(CrioLogSizeMax, "50Mi")
+	require.NoError(t, err)
+	assert.Equal(t, int64(50*1024*1024), GetCrioLogSizeMax(cfg))
+}
+
+func TestValidateImageGCThresholds(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, []Problem{{
+		Keys:    []string{KubeletImageGCHighThreshold, KubeletImageGCLowThreshold},
+		Message: "The low threshold of the image garbage collection must be lower than the high threshold",
+	}}, cfg.ValidateValues(map[string]interface{}{
+		KubeletImageGCHighThreshold: 70,
+		KubeletImageGCLowThreshold:  80,
+	}))
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		KubeletImageGCLowThreshold: 60,
+	}))
+}
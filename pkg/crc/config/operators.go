@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cast"
+)
+
+// DefaultOperatorsNamespace has a global OperatorGroup, the operators
+// installed in this namespace watch all the namespaces
+const DefaultOperatorsNamespace = "openshift-operators"
+
+var operatorNameRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9.]*[a-z0-9])?$`)
+
+// Operator is an OLM package to install, the default channel of the package
+// is used when Channel is empty
+type Operator struct {
+	Package   string `json:"package"`
+	Channel   string `json:"channel,omitempty"`
+	Namespace string `json:"namespace"`
+}
+
+func (o Operator) Validate() error {
+	if !operatorNameRegexp.MatchString(o.Package) {
+		return fmt.Errorf("invalid package name '%s'", o.Package)
+	}
+	if o.Channel != "" && strings.ContainsAny(o.Channel, " :,'\"") {
+		return fmt.Errorf("invalid channel name '%s'", o.Channel)
+	}
+	if !operatorNameRegexp.MatchString(o.Namespace) {
+		return fmt.Errorf("invalid namespace '%s'", o.Namespace)
+	}
+	return nil
+}
+
+// ParseOperators parses a comma-separated list of package[:channel[:namespace]]
+func ParseOperators(value string) ([]Operator, error) {
+	var operators []Operator
+	for _, item := range splitList(value) {
+		parts := strings.Split(item, ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid operator '%s', the format is package[:channel[:namespace]]", item)
+		}
+		operator := Operator{
+			Package:   parts[0],
+			Namespace: DefaultOperatorsNamespace,
+		}
+		if len(parts) > 1 {
+			operator.Channel = parts[1]
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			operator.Namespace = parts[2]
+		}
+		if err := operator.Validate(); err != nil {
+			return nil, err
+		}
+		operators = append(operators, operator)
+	}
+	return operators, nil
+}
+
+func validateOperators(value interface{}) (bool, string) {
+	if _, err := ParseOperators(cast.ToString(value)); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+// checkOperators checks that the catalogs providing the operators are enabled
+func checkOperators(cfg *Config) *Problem {
+	if cfg.Get(Operators).AsString() == "" {
+		return nil
+	}
+	_, disabled, err := ParseCapabilities(cfg.Get(ClusterCapabilities).AsString())
+	if err != nil {
+		return nil
+	}
+	for _, capability := range disabled {
+		if capability == CapabilityOLMCatalogs {
+			return &Problem{
+				Keys:    []string{Operators, ClusterCapabilities},
+				Message: fmt.Sprintf("The operators cannot be installed when '%s' is disabled", CapabilityOLMCatalogs),
+			}
+		}
+	}
+	return nil
+}
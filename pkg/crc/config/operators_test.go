@@ -0,0 +1,40 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseOperators(t *testing.T) {
+	operators, err := ParseOperators("serverless-operator, amq-streams:stable, web-terminal::my-operators,devworkspace-operator:fast:dev")
+	require.NoError(t, err)
+	assert.Equal(t, []Operator{
+		{Package: "serverless-operator", Namespace: DefaultOperatorsNamespace},
+		{Package: "amq-streams", Channel: "stable", Namespace: DefaultOperatorsNamespace},
+		{Package: "web-terminal", Namespace: "my-operators"},
+		{Package: "devworkspace-operator", Channel: "fast", Namespace: "dev"},
+	}, operators)
+
+	_, err = ParseOperators("a:b:c:d")
+	assert.EqualError(t, err, "invalid operator 'a:b:c:d', the format is package[:channel[:namespace]]")
+	_, err = ParseOperators("Serverless")
+	assert.EqualError(t, err, "invalid package name 'Serverless'")
+	_, err = ParseOperators("serverless-operator:stable:My_NS")
+	assert.EqualError(t, err, "invalid namespace 'My_NS'")
+}
+
+func TestValidateOperatorsWithoutCatalogs(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	_, err = cfg.Set(ClusterCapabilities, "-olm-catalogs")
+	require.NoError(t, err)
+
+	assert.Equal(t, []Problem{{
+		Keys:    []string{Operators, ClusterCapabilities},
+		Message: "The operators cannot be installed when 'olm-catalogs' is disabled",
+	}}, cfg.ValidateValues(map[string]interface{}{
+		Operators: "serverless-operator",
+	}))
+}
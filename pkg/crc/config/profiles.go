@@ -0,0 +1,162 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	crcos "github.com/crc-org/crc/v2/pkg/os"
+)
+
+// DefaultProfile is the profile stored in the main configuration file
+const DefaultProfile = "default"
+
+const (
+	profilesDirName   = "profiles"
+	activeProfileFile = "active"
+)
+
+var validProfileName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9_.-]*$`)
+
+// Profiles manages named sets of configuration values. Each profile is
+// stored in its own configuration file in the profiles directory next to the
+// main configuration file, which holds the default profile.
+type Profiles struct {
+	defaultConfigFile string
+}
+
+func NewProfiles(defaultConfigFile string) *Profiles {
+	return &Profiles{
+		defaultConfigFile: defaultConfigFile,
+	}
+}
+
+func validateProfileName(name string) error {
+	if name != DefaultProfile && !validProfileName.MatchString(name) {
+		return fmt.Errorf("invalid profile name '%s', it must only contain letters, digits, '.', '_' and '-'", name)
+	}
+	return nil
+}
+
+func (p *Profiles) dir() string {
+	return filepath.Join(filepath.Dir(p.defaultConfigFile), profilesDirName)
+}
+
+// ConfigFile returns the path of the configuration file of the profile name
+func (p *Profiles) ConfigFile(name string) string {
+	if name == DefaultProfile {
+		return p.defaultConfigFile
+	}
+	return filepath.Join(p.dir(), name+filepath.Ext(p.defaultConfigFile))
+}
+
+// Exists returns true when the profile name was created, names which are not
+// valid never exist so that they cannot point outside of the profiles directory
+func (p *Profiles) Exists(name string) bool {
+	if validateProfileName(name) != nil {
+		return false
+	}
+	return name == DefaultProfile || crcos.FileExists(p.ConfigFile(name))
+}
+
+// Active returns the name of the profile in use
+func (p *Profiles) Active() string {
+	content, err := os.ReadFile(filepath.Join(p.dir(), activeProfileFile))
+	if err != nil {
+		return DefaultProfile
+	}
+	name := strings.TrimSpace(string(content))
+	if name == "" || !p.Exists(name) {
+		logging.Debugf("Active profile '%s' does not exist, using the default profile", name)
+		return DefaultProfile
+	}
+	return name
+}
+
+// List returns the names of all the profiles, starting with the default profile
+func (p *Profiles) List() ([]string, error) {
+	profiles := []string{DefaultProfile}
+	files, err := filepath.Glob(filepath.Join(p.dir(), "*"+filepath.Ext(p.defaultConfigFile)))
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)))
+	}
+	sort.Strings(names)
+	return append(profiles, names...), nil
+}
+
+// Create creates the profile name. Its values are copied from the profile
+// from, or from the default profile when from is empty.
+func (p *Profiles) Create(name, from string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if name == DefaultProfile || p.Exists(name) {
+		return fmt.Errorf("profile '%s' already exists", name)
+	}
+	if from == "" {
+		from = DefaultProfile
+	}
+	if !p.Exists(from) {
+		return fmt.Errorf("profile '%s' does not exist", from)
+	}
+	if err := ensureConfigFileExists(p.ConfigFile(from)); err != nil {
+		return err
+	}
+	content, err := os.ReadFile(p.ConfigFile(from))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(p.dir(), 0700); err != nil {
+		return err
+	}
+	return atomicWrite(content, p.ConfigFile(name))
+}
+
+// Use makes name the active profile
+func (p *Profiles) Use(name string) error {
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if !p.Exists(name) {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if err := os.MkdirAll(p.dir(), 0700); err != nil {
+		return err
+	}
+	return atomicWrite([]byte(name+"\n"), filepath.Join(p.dir(), activeProfileFile))
+}
+
+// Delete removes the profile name, which must not be the active profile
+func (p *Profiles) Delete(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be deleted", DefaultProfile)
+	}
+	if err := validateProfileName(name); err != nil {
+		return err
+	}
+	if !p.Exists(name) {
+		return fmt.Errorf("profile '%s' does not exist", name)
+	}
+	if p.Active() == name {
+		return fmt.Errorf("profile '%s' is in use, switch to another profile with 'crc config profile use' first", name)
+	}
+	return os.Remove(p.ConfigFile(name))
+}
+
+// UseProfile switches the active profile to name, and returns the messages
+// of the settings whose value changed, as if they had been set one by one.
+func (c *Config) UseProfile(profiles *Profiles, name string) ([]string, error) {
+	before := c.AllConfigs()
+	if err := profiles.Use(name); err != nil {
+		return nil, err
+	}
+	return c.notifyChanges(before), nil
+}
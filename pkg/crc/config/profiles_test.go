@@ -0,0 +1,99 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestProfileConfig(t *testing.T) (*Config, *Profiles) {
+	profiles := NewProfiles(filepath.Join(t.TempDir(), "crc.json"))
+	storage, err := NewViperStorageWithProfiles(profiles, "CRC")
+	require.NoError(t, err)
+
+	config := New(storage, NewEmptyInMemorySecretStorage())
+	config.AddSetting(cpus, 4, validateUint, RequiresRestartMsg, "")
+	config.AddSetting(nameServer, "", validateIPAddress, SuccessfullyApplied, "")
+	return config, profiles
+}
+
+func TestProfiles(t *testing.T) {
+	_, profiles := newTestProfileConfig(t)
+
+	assert.Equal(t, DefaultProfile, profiles.Active())
+	require.NoError(t, profiles.Create("work", ""))
+	require.NoError(t, profiles.Create("home", "work"))
+	assert.EqualError(t, profiles.Create("work", ""), "profile 'work' already exists")
+	assert.EqualError(t, profiles.Create("default", ""), "profile 'default' already exists")
+	assert.ErrorContains(t, profiles.Create("../work", ""), "invalid profile name")
+	assert.EqualError(t, profiles.Create("other", "missing"), "profile 'missing' does not exist")
+
+	names, err := profiles.List()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "home", "work"}, names)
+
+	require.NoError(t, profiles.Use("work"))
+	assert.Equal(t, "work", profiles.Active())
+	assert.EqualError(t, profiles.Use("missing"), "profile 'missing' does not exist")
+	assert.ErrorContains(t, profiles.Delete("work"), "profile 'work' is in use")
+	assert.EqualError(t, profiles.Delete(DefaultProfile), "the default profile cannot be deleted")
+	require.NoError(t, profiles.Delete("home"))
+
+	names, err = profiles.List()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "work"}, names)
+}
+
+func TestProfileNameTraversal(t *testing.T) {
+	config, profiles := newTestProfileConfig(t)
+	_, err := config.Set(cpus, 8)
+	require.NoError(t, err)
+	defaultConfigFile := profiles.ConfigFile(DefaultProfile)
+	require.FileExists(t, defaultConfigFile)
+	name := "../" + strings.TrimSuffix(filepath.Base(defaultConfigFile), filepath.Ext(defaultConfigFile))
+
+	assert.False(t, profiles.Exists(name))
+	assert.ErrorContains(t, profiles.Delete(name), "invalid profile name")
+	assert.FileExists(t, defaultConfigFile)
+	assert.ErrorContains(t, profiles.Use(name), "invalid profile name")
+	assert.NoFileExists(t, filepath.Join(profiles.dir(), activeProfileFile))
+}
+
+func TestProfileValues(t *testing.T) {
+	config, profiles := newTestProfileConfig(t)
+
+	_, err := config.Set(nameServer, "1.1.1.1")
+	require.NoError(t, err)
+	require.NoError(t, profiles.Create("copy", DefaultProfile))
+	require.NoError(t, profiles.Create("work", ""))
+
+	messages, err := config.UseProfile(profiles, "work")
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+	assert.Equal(t, "1.1.1.1", config.Get(nameServer).AsString())
+
+	_, err = config.Set(cpus, 8)
+	require.NoError(t, err)
+	_, err = config.Unset(nameServer)
+	require.NoError(t, err)
+	bin, err := os.ReadFile(profiles.ConfigFile("work"))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"cpus": 8}`, string(bin))
+
+	messages, err = config.UseProfile(profiles, "copy")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		RequiresRestartMsg(cpus, 4),
+		"Successfully configured nameservers to 1.1.1.1",
+	}, messages)
+	assert.Equal(t, 4, config.Get(cpus).AsInt())
+	assert.Equal(t, "1.1.1.1", config.Get(nameServer).AsString())
+
+	messages, err = config.UseProfile(profiles, DefaultProfile)
+	require.NoError(t, err)
+	assert.Empty(t, messages)
+}
@@ -0,0 +1,90 @@
+package config
+
+import (
+	"sort"
+
+	"github.com/crc-org/crc/v2/pkg/crc/network"
+	"github.com/crc-org/crc/v2/pkg/crc/preset"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+)
+
+const jsonSchemaVersion = "https://json-schema.org/draft/2020-12/schema"
+
+// JSONSchema describes the files read by 'crc config import'
+type JSONSchema struct {
+	Schema               string                        `json:"$schema"`
+	Title                string                        `json:"title"`
+	Type                 string                        `json:"type"`
+	Properties           map[string]JSONSchemaProperty `json:"properties"`
+	AdditionalProperties bool                          `json:"additionalProperties"`
+}
+
+type JSONSchemaProperty struct {
+	Type        string      `json:"type"`
+	Description string      `json:"description,omitempty"`
+	Default     interface{} `json:"default,omitempty"`
+	Enum        []string    `json:"enum,omitempty"`
+	Minimum     *int        `json:"minimum,omitempty"`
+	WriteOnly   bool        `json:"writeOnly,omitempty"`
+}
+
+// GenerateJSONSchema returns a JSON schema of the registered settings
+func (c *Config) GenerateJSONSchema() JSONSchema {
+	schema := JSONSchema{
+		Schema:     jsonSchemaVersion,
+		Title:      "CRC configuration",
+		Type:       "object",
+		Properties: map[string]JSONSchemaProperty{},
+	}
+	for name, setting := range c.settingsByName {
+		schema.Properties[name] = setting.jsonSchemaProperty()
+	}
+	return schema
+}
+
+func (s Setting) jsonSchemaProperty() JSONSchemaProperty {
+	property := JSONSchemaProperty{
+		Description: s.Help,
+		Default:     s.defaultValue,
+		Enum:        s.allowedValues(),
+		WriteOnly:   s.isSecret,
+	}
+	switch s.defaultValue.(type) {
+	case int:
+		property.Type = "integer"
+	case uint:
+		property.Type = "integer"
+		minimum := 0
+		property.Minimum = &minimum
+	case bool:
+		property.Type = "boolean"
+	default:
+		property.Type = "string"
+	}
+	// settings such as consent-telemetry have an empty default which cannot be set
+	if defaultValue, ok := s.defaultValue.(string); property.Enum != nil && (!ok || !crcstrings.Contains(property.Enum, defaultValue)) {
+		property.Default = nil
+	}
+	return property
+}
+
+// allowedValues returns the values accepted by the settings which only
+// accept a few values, or nil
+func (s Setting) allowedValues() []string {
+	switch s.Name {
+	case Preset:
+		var presets []string
+		for _, p := range preset.AllPresets() {
+			presets = append(presets, p.String())
+		}
+		sort.Strings(presets)
+		return presets
+	case NetworkMode:
+		return []string{network.SystemNetworkingMode.String(), network.UserNetworkingMode.String()}
+	case ConsentTelemetry:
+		return []string{"no", "yes"}
+	case SecretBackend:
+		return []string{string(AutoSecretStorage), string(FileSecretStorage), string(KeyringSecretStorage)}
+	}
+	return nil
+}
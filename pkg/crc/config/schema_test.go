@@ -0,0 +1,38 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/version"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateJSONSchema(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	schema := cfg.GenerateJSONSchema()
+	assert.Len(t, schema.Properties, len(cfg.AllSettings()))
+
+	minimum := 0
+	assert.Equal(t, JSONSchemaProperty{
+		Type:        "integer",
+		Description: cfg.settingsByName[CPUs].Help,
+		Default:     constants.GetDefaultCPUs(version.GetDefaultPreset()),
+		Minimum:     &minimum,
+	}, schema.Properties[CPUs])
+	assert.Equal(t, JSONSchemaProperty{
+		Type:        "boolean",
+		Description: cfg.settingsByName[DisableUpdateCheck].Help,
+		Default:     false,
+	}, schema.Properties[DisableUpdateCheck])
+	assert.Equal(t, JSONSchemaProperty{
+		Type:        "string",
+		Description: cfg.settingsByName[ConsentTelemetry].Help,
+		Enum:        []string{"no", "yes"},
+	}, schema.Properties[ConsentTelemetry])
+	assert.Equal(t, []string{"microshift", "okd", "openshift"}, schema.Properties[Preset].Enum)
+}
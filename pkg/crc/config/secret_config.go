@@ -19,41 +19,184 @@ func (s Secret) String() string {
 	return string(s)
 }
 
+// SecretStorageBackend is where the secrets are stored
+type SecretStorageBackend string
+
+const (
+	// AutoSecretStorage uses the keyring when it is accessible, and the encrypted file otherwise
+	AutoSecretStorage    SecretStorageBackend = "auto"
+	KeyringSecretStorage SecretStorageBackend = "keyring"
+	FileSecretStorage    SecretStorageBackend = "file"
+)
+
 type SecretStorage struct {
 	secretService   string
 	storeAccessible bool
+	// secrets can be overridden by environment variables, like the other settings
+	envPrefix string
+	// file stores the secrets when the keyring is not used, it is nil when there is no fallback
+	file *EncryptedFileStorage
+	// backend returns the value of the secret-backend setting
+	backend func() SecretStorageBackend
 }
 
-func NewSecretStorage() *SecretStorage {
+// NewSecretStorage returns a storage using the system keyring, or the
+// encrypted secretsFile when the keyring is not accessible or when the
+// secret-backend setting is 'file'
+func NewSecretStorage(envPrefix, secretsFile string) *SecretStorage {
+	passphrase, _ := lookupEnv(envPrefix, SecretsPassphrase)
 	return &SecretStorage{
 		secretService:   secretServiceName,
 		storeAccessible: keyringAccessible(),
+		envPrefix:       envPrefix,
+		file:            NewEncryptedFileStorage(secretsFile, passphrase),
+	}
+}
+
+// NewKeyringStorage returns a storage which only uses the system keyring
+func NewKeyringStorage() *SecretStorage {
+	return &SecretStorage{
+		secretService:   secretServiceName,
+		storeAccessible: true,
+	}
+}
+
+// Backend returns the backend used to store the secrets
+func (c *SecretStorage) Backend() SecretStorageBackend {
+	if c.file == nil {
+		return KeyringSecretStorage
+	}
+	backend := AutoSecretStorage
+	if c.backend != nil {
+		backend = c.backend()
+	}
+	switch backend {
+	case FileSecretStorage, KeyringSecretStorage:
+		return backend
+	default:
+		if c.storeAccessible {
+			return KeyringSecretStorage
+		}
+		return FileSecretStorage
 	}
 }
 
 func (c *SecretStorage) Get(key string) interface{} {
+	if value, ok := lookupEnv(c.envPrefix, key); ok {
+		return value
+	}
+	return c.getFrom(c.Backend(), key)
+}
+
+func (c *SecretStorage) Set(key string, value interface{}) error {
+	secret, err := cast.ToStringE(value)
+	if err != nil {
+		return fmt.Errorf("Failed to cast secret value to string: %w", err)
+	}
+	return c.setIn(c.Backend(), key, secret)
+}
+
+func (c *SecretStorage) Unset(key string) error {
+	return c.unsetIn(c.Backend(), key)
+}
+
+// Source returns where the secret key comes from
+func (c *SecretStorage) Source(key string) Source {
+	if _, ok := lookupEnv(c.envPrefix, key); ok {
+		return SourceEnv
+	}
+	backend := c.Backend()
+	if c.getFrom(backend, key) == nil {
+		return SourceDefault
+	}
+	if backend == FileSecretStorage {
+		return SourceSecretsFile
+	}
+	return SourceKeyring
+}
+
+// StoredIn returns the backends which have a value for key
+func (c *SecretStorage) StoredIn(key string) []SecretStorageBackend {
+	var backends []SecretStorageBackend
+	for _, backend := range []SecretStorageBackend{KeyringSecretStorage, FileSecretStorage} {
+		if c.getFrom(backend, key) != nil {
+			backends = append(backends, backend)
+		}
+	}
+	return backends
+}
+
+// Migrate moves keys from the other backend to the backend to, and returns
+// the keys which were moved
+func (c *SecretStorage) Migrate(keys []string, to SecretStorageBackend) ([]string, error) {
+	if c.file == nil || !c.storeAccessible {
+		return nil, ErrSecretsNotAccessible
+	}
+	from := KeyringSecretStorage
+	switch to {
+	case FileSecretStorage:
+	case KeyringSecretStorage:
+		from = FileSecretStorage
+	default:
+		return nil, fmt.Errorf("secrets can only be migrated to %s or %s", KeyringSecretStorage, FileSecretStorage)
+	}
+	var migrated []string
+	for _, key := range keys {
+		value := c.getFrom(from, key)
+		if value == nil {
+			continue
+		}
+		if err := c.setIn(to, key, value.(string)); err != nil {
+			return migrated, err
+		}
+		if err := c.unsetIn(from, key); err != nil {
+			return migrated, err
+		}
+		migrated = append(migrated, key)
+	}
+	return migrated, nil
+}
+
+func (c *SecretStorage) getFrom(backend SecretStorageBackend, key string) interface{} {
+	if backend == FileSecretStorage {
+		if c.file == nil {
+			return nil
+		}
+		return c.file.Get(key)
+	}
 	if !c.storeAccessible {
 		return nil
 	}
 	secret, err := keyring.Get(c.secretService, key)
-	if errors.Is(err, keyring.ErrNotFound) {
+	if err != nil {
+		if !errors.Is(err, keyring.ErrNotFound) {
+			logging.Debugf("Cannot get %s from the keyring: %v", key, err)
+		}
 		return nil
 	}
 	return secret
 }
 
-func (c *SecretStorage) Set(key string, value interface{}) error {
+func (c *SecretStorage) setIn(backend SecretStorageBackend, key, secret string) error {
+	if backend == FileSecretStorage {
+		if c.file == nil {
+			return ErrSecretsNotAccessible
+		}
+		return c.file.Set(key, secret)
+	}
 	if !c.storeAccessible {
 		return ErrSecretsNotAccessible
 	}
-	secret, err := cast.ToStringE(value)
-	if err != nil {
-		return fmt.Errorf("Failed to cast secret value to string: %w", err)
-	}
 	return keyring.Set(c.secretService, key, secret)
 }
 
-func (c *SecretStorage) Unset(key string) error {
+func (c *SecretStorage) unsetIn(backend SecretStorageBackend, key string) error {
+	if backend == FileSecretStorage {
+		if c.file == nil {
+			return ErrSecretsNotAccessible
+		}
+		return c.file.Unset(key)
+	}
 	if !c.storeAccessible {
 		return ErrSecretsNotAccessible
 	}
@@ -81,3 +224,9 @@ func NewEmptyInMemorySecretStorage() *SecretStorage {
 		storeAccessible: true,
 	}
 }
+
+// SecretStorage returns the storage of the secret settings when it is a SecretStorage
+func (c *Config) SecretStorage() (*SecretStorage, bool) {
+	storage, ok := c.secretStorage.(*SecretStorage)
+	return storage, ok
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -67,3 +68,37 @@ func TestSecretConfigUnsetAndGet(t *testing.T) {
 		IsSecret:  true,
 	}, cfg.Get(secret))
 }
+
+func TestSecretStorageBackend(t *testing.T) {
+	storage := NewEmptyInMemorySecretStorage()
+	storage.file = NewEncryptedFileStorage(filepath.Join(t.TempDir(), "secrets.json.enc"), "passphrase")
+	cfg := New(NewEmptyInMemoryStorage(), storage)
+	RegisterSettings(cfg)
+	cfg.AddSetting(password, Secret(""), validateString, SuccessfullyApplied, "")
+
+	assert.Equal(t, KeyringSecretStorage, storage.Backend())
+	_, err := cfg.Set(password, "pass123")
+	require.NoError(t, err)
+	assert.Equal(t, SourceKeyring, cfg.Source(password))
+
+	_, err = cfg.Set(SecretBackend, string(FileSecretStorage))
+	require.NoError(t, err)
+	assert.Equal(t, FileSecretStorage, storage.Backend())
+	assert.True(t, cfg.Get(password).IsDefault)
+	assert.Equal(t, []SecretStorageBackend{KeyringSecretStorage}, storage.StoredIn(password))
+
+	migrated, err := storage.Migrate([]string{password, secret}, FileSecretStorage)
+	require.NoError(t, err)
+	assert.Equal(t, []string{password}, migrated)
+	assert.Equal(t, []SecretStorageBackend{FileSecretStorage}, storage.StoredIn(password))
+	assert.Equal(t, Secret("pass123"), cfg.Get(password).Value)
+	assert.Equal(t, SourceSecretsFile, cfg.Source(password))
+
+	// without keyring, the file is used
+	storage.storeAccessible = false
+	_, err = cfg.Set(SecretBackend, string(AutoSecretStorage))
+	require.NoError(t, err)
+	assert.Equal(t, FileSecretStorage, storage.Backend())
+	_, err = storage.Migrate([]string{password}, KeyringSecretStorage)
+	assert.ErrorIs(t, err, ErrSecretsNotAccessible)
+}
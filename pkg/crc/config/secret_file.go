@@ -0,0 +1,233 @@
+package config
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/spf13/cast"
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	secretsFileVersion = 1
+
+	passphraseKeySource = "passphrase"
+	machineKeySource    = "machine"
+
+	// SecretsPassphrase is the name of the environment variable, without
+	// prefix, holding the passphrase of the secrets file
+	SecretsPassphrase = "secrets-passphrase" // #nosec G101
+)
+
+// EncryptedFileStorage stores secrets in a file encrypted with AES-GCM. The
+// key is derived from a passphrase when one is provided, otherwise from the
+// machine ID, which only prevents the file from being read on another machine.
+type EncryptedFileStorage struct {
+	lock       *sync.Mutex
+	file       string
+	passphrase string
+	// derived keys, by key source and salt, as key derivation is slow
+	keys map[string][]byte
+}
+
+// secretsFile is the content of the secrets file
+type secretsFile struct {
+	Version   int    `json:"version"`
+	KeySource string `json:"keySource"`
+	Salt      []byte `json:"salt"`
+	Nonce     []byte `json:"nonce"`
+	Data      []byte `json:"data"`
+}
+
+func NewEncryptedFileStorage(file, passphrase string) *EncryptedFileStorage {
+	return &EncryptedFileStorage{
+		lock:       &sync.Mutex{},
+		file:       file,
+		passphrase: passphrase,
+		keys:       map[string][]byte{},
+	}
+}
+
+func (s *EncryptedFileStorage) Get(key string) interface{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	secrets, err := s.read()
+	if err != nil {
+		logging.Debugf("Cannot read secrets from %s: %v", s.file, err)
+		return nil
+	}
+	value, ok := secrets[key]
+	if !ok {
+		return nil
+	}
+	return value
+}
+
+func (s *EncryptedFileStorage) Set(key string, value interface{}) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	secret, err := cast.ToStringE(value)
+	if err != nil {
+		return fmt.Errorf("Failed to cast secret value to string: %w", err)
+	}
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	secrets[key] = secret
+	return s.write(secrets)
+}
+
+func (s *EncryptedFileStorage) Unset(key string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	secrets, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[key]; !ok {
+		return nil
+	}
+	delete(secrets, key)
+	return s.write(secrets)
+}
+
+// read decrypts the secrets file, a missing file has no secrets
+func (s *EncryptedFileStorage) read() (map[string]string, error) {
+	secrets := map[string]string{}
+	bin, err := os.ReadFile(s.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var content secretsFile
+	if err := json.Unmarshal(bin, &content); err != nil {
+		return nil, err
+	}
+	if content.Version != secretsFileVersion {
+		return nil, fmt.Errorf("unsupported secrets file version %d", content.Version)
+	}
+	gcm, err := s.cipher(content.KeySource, content.Salt)
+	if err != nil {
+		return nil, err
+	}
+	data, err := gcm.Open(nil, content.Nonce, content.Data, nil)
+	if err != nil {
+		if content.KeySource == passphraseKeySource {
+			return nil, errors.New("cannot decrypt the secrets file, the passphrase is wrong")
+		}
+		return nil, errors.New("cannot decrypt the secrets file, it was created on another machine")
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+func (s *EncryptedFileStorage) write(secrets map[string]string) error {
+	data, err := json.Marshal(secrets)
+	if err != nil {
+		return err
+	}
+	content := secretsFile{
+		Version:   secretsFileVersion,
+		KeySource: machineKeySource,
+		Salt:      make([]byte, 16),
+	}
+	if s.passphrase != "" {
+		content.KeySource = passphraseKeySource
+	}
+	if _, err := rand.Read(content.Salt); err != nil {
+		return err
+	}
+	gcm, err := s.cipher(content.KeySource, content.Salt)
+	if err != nil {
+		return err
+	}
+	content.Nonce = make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(content.Nonce); err != nil {
+		return err
+	}
+	content.Data = gcm.Seal(nil, content.Nonce, data, nil)
+
+	bin, err := json.MarshalIndent(content, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.file), 0700); err != nil {
+		return err
+	}
+	return atomicWrite(bin, s.file)
+}
+
+func (s *EncryptedFileStorage) cipher(keySource string, salt []byte) (cipher.AEAD, error) {
+	cacheKey := keySource + string(salt)
+	key, ok := s.keys[cacheKey]
+	if !ok {
+		var secret []byte
+		switch keySource {
+		case passphraseKeySource:
+			if s.passphrase == "" {
+				return nil, fmt.Errorf("the secrets file is encrypted with a passphrase, set it with %s", EnvVarName(constants.CrcEnvPrefix, SecretsPassphrase))
+			}
+			secret = []byte(s.passphrase)
+		case machineKeySource:
+			id, err := machineID()
+			if err != nil {
+				return nil, err
+			}
+			secret = []byte(id)
+		default:
+			return nil, fmt.Errorf("unknown secrets file key source '%s'", keySource)
+		}
+		var err error
+		key, err = scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+		if err != nil {
+			return nil, err
+		}
+		s.keys[cacheKey] = key
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// machineID returns an identifier of the machine and of the user
+func machineID() (string, error) {
+	var id string
+	if runtime.GOOS == "linux" {
+		for _, file := range []string{"/etc/machine-id", "/var/lib/dbus/machine-id"} {
+			if bin, err := os.ReadFile(file); err == nil {
+				id = strings.TrimSpace(string(bin))
+				break
+			}
+		}
+	}
+	if id == "" {
+		hostname, err := os.Hostname()
+		if err != nil {
+			return "", err
+		}
+		id = hostname
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return id + "\x00" + home, nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptedFileStorage(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.json.enc")
+	storage := NewEncryptedFileStorage(file, "passphrase")
+
+	assert.Nil(t, storage.Get(password))
+	require.NoError(t, storage.Set(password, "pass123"))
+	require.NoError(t, storage.Set(secret, "apples"))
+	assert.Equal(t, "pass123", storage.Get(password))
+
+	content, err := os.ReadFile(file)
+	require.NoError(t, err)
+	assert.NotContains(t, string(content), "pass123")
+
+	// a new storage reads the values stored by the first one
+	assert.Equal(t, "apples", NewEncryptedFileStorage(file, "passphrase").Get(secret))
+
+	require.NoError(t, storage.Unset(password))
+	assert.Nil(t, storage.Get(password))
+	assert.Equal(t, "apples", storage.Get(secret))
+}
+
+func TestEncryptedFileStorageWrongPassphrase(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.json.enc")
+	require.NoError(t, NewEncryptedFileStorage(file, "passphrase").Set(password, "pass123"))
+
+	storage := NewEncryptedFileStorage(file, "wrong")
+	assert.Nil(t, storage.Get(password))
+	assert.EqualError(t, storage.Set(secret, "apples"), "cannot decrypt the secrets file, the passphrase is wrong")
+	assert.EqualError(t, NewEncryptedFileStorage(file, "").Set(secret, "apples"),
+		"the secrets file is encrypted with a passphrase, set it with CRC_SECRETS_PASSPHRASE")
+}
+
+func TestEncryptedFileStorageMachineKey(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "secrets.json.enc")
+	require.NoError(t, NewEncryptedFileStorage(file, "").Set(password, "pass123"))
+	assert.Equal(t, "pass123", NewEncryptedFileStorage(file, "").Get(password))
+	// a passphrase is used for the next writes
+	storage := NewEncryptedFileStorage(file, "passphrase")
+	require.NoError(t, storage.Set(secret, "apples"))
+	assert.Nil(t, NewEncryptedFileStorage(file, "").Get(password))
+	assert.Equal(t, "pass123", storage.Get(password))
+}
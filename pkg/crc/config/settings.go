@@ -3,6 +3,8 @@ package config
 import (
 	"fmt"
 	"runtime"
+	"strings"
+	"time"
 
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
@@ -15,6 +17,8 @@ const (
 	Bundle                   = "bundle"
 	CPUs                     = "cpus"
 	Memory                   = "memory"
+	MaxCPUs                  = "max-cpus"
+	MaxMemory                = "max-memory"
 	DiskSize                 = "disk-size"
 	NameServer               = "nameserver"
 	PullSecretFile           = "pull-secret-file"
@@ -28,6 +32,8 @@ const (
 	ProxyCAFile              = "proxy-ca-file"
 	ConsentTelemetry         = "consent-telemetry"
 	EnableClusterMonitoring  = "enable-cluster-monitoring"
+	ClusterCapabilities      = "cluster-capabilities"
+	Operators                = "operators"
 	KubeAdminPassword        = "kubeadmin-password"
 	Preset                   = "preset"
 	EnableSharedDirs         = "enable-shared-dirs"
@@ -37,9 +43,41 @@ const (
 	EmergencyLogin           = "enable-emergency-login"
 	PersistentVolumeSize     = "persistent-volume-size"
 	EnableBundleQuayFallback = "enable-bundle-quay-fallback"
+	BundleTrustedKeys        = "bundle-trusted-keys"
+	RequireSignedBundles     = "require-signed-bundles"
+	CacheMaxSize             = "cache-max-size"
+	CacheKeepVersions        = "cache-keep-versions"
+	CacheKeepInUse           = "cache-keep-in-use"
+	SecretBackend            = "secret-backend"
+	CertExpiryWarningDays    = "cert-expiry-warning-days"
+	VMCustomization          = "vm-customization"
+
+	ClusterStableTimeout          = "cluster-stable-timeout"
+	ClusterStableChecks           = "cluster-stable-checks"
+	ClusterStableIgnoredOperators = "cluster-stable-ignored-operators"
+	ClusterStableStrict           = "cluster-stable-strict"
+
+	KubeconfigAutoMerge        = "kubeconfig-auto-merge"
+	KubeconfigAdminContext     = "kubeconfig-admin-context"
+	KubeconfigDeveloperContext = "kubeconfig-developer-context"
+
+	MicroShiftLogLevel        = "microshift-log-level"
+	MicroShiftSubjectAltNames = "microshift-subject-alt-names"
+	MicroShiftBaseDomain      = "microshift-base-domain"
+	MicroShiftClusterNetwork  = "microshift-cluster-network"
+	MicroShiftServiceNetwork  = "microshift-service-network"
+	MicroShiftManifestsDir    = "microshift-manifests-dir"
+
+	KubeletMaxPods              = "kubelet-max-pods"
+	KubeletEvictionHard         = "kubelet-eviction-hard"
+	KubeletImageGCHighThreshold = "kubelet-image-gc-high-threshold"
+	KubeletImageGCLowThreshold  = "kubelet-image-gc-low-threshold"
+	CrioLogSizeMax              = "crio-log-size-max"
 )
 
 func RegisterSettings(cfg *Config) {
+	cfg.registerSettings = RegisterSettings
+
 	validateHostNetworkAccess := func(value interface{}) (bool, string) {
 		mode := GetNetworkMode(cfg)
 		if mode != network.UserNetworkingMode {
@@ -81,6 +119,15 @@ func RegisterSettings(cfg *Config) {
 		fmt.Sprintf("Number of CPU cores (must be greater than or equal to '%d')", defaultCPUs(cfg)))
 	cfg.AddSetting(Memory, defaultMemory(cfg), validMemory, RequiresRestartMsg,
 		fmt.Sprintf("Memory size in MiB (must be greater than or equal to '%d')", defaultMemory(cfg)))
+	cfg.setPresetDefault(Bundle, CPUs, Memory)
+	// Only the libvirt driver can change the CPUs and memory of a running VM,
+	// on other platforms these settings do not exist and are never validated
+	if runtime.GOOS == "linux" {
+		cfg.AddSetting(MaxCPUs, uint(0), validateUint, RequiresRestartMsg,
+			fmt.Sprintf("Number of CPU cores the running CRC VM can be given without a restart, Linux only (0 to use '%s')", CPUs))
+		cfg.AddSetting(MaxMemory, uint(0), validateUint, RequiresRestartMsg,
+			fmt.Sprintf("Memory size in MiB the running CRC VM can be given without a restart, Linux only (0 to use '%s')", Memory))
+	}
 	cfg.AddSetting(DiskSize, constants.DefaultDiskSize, validateDiskSize, RequiresRestartMsg,
 		fmt.Sprintf("Total size in GiB of the disk (must be greater than or equal to '%d')", constants.DefaultDiskSize))
 	cfg.AddSetting(NameServer, "", validateIPAddress, SuccessfullyApplied,
@@ -127,6 +174,57 @@ func RegisterSettings(cfg *Config) {
 
 	cfg.AddSetting(EnableClusterMonitoring, false, ValidateBool, SuccessfullyApplied,
 		"Enable cluster monitoring Operator (true/false, default: false)")
+	cfg.AddSetting(ClusterCapabilities, "", validateCapabilities, RequiresRestartMsg,
+		fmt.Sprintf("Optional components of the cluster to enable, or to disable when prefixed with '-' (string, comma-separated list of %s)",
+			strings.Join(capabilities, ", ")))
+	cfg.AddSetting(Operators, "", validateOperators, RequiresRestartMsg,
+		fmt.Sprintf("OLM operators installed by 'crc start' (string, comma-separated list of package[:channel[:namespace]], default namespace: %s)", DefaultOperatorsNamespace))
+
+	cfg.AddSetting(ClusterStableTimeout, "10m", validateDuration, SuccessfullyApplied,
+		"Maximum time 'crc start' waits for the cluster operators to be stable, 5 minutes are added when a proxy is used (duration, such as '90s' or '15m', default: 10m)")
+	cfg.AddSetting(ClusterStableChecks, 3, validateStableChecks, SuccessfullyApplied,
+		"Number of consecutive checks, 30 seconds apart, where all the cluster operators must be ready (1 or more, default: 3)")
+	cfg.AddSetting(ClusterStableIgnoredOperators, "", validateClusterOperatorNames, SuccessfullyApplied,
+		"Cluster operators which are not taken into account when waiting for the cluster to be stable (string, comma-separated list of names)")
+	cfg.AddSetting(ClusterStableStrict, false, ValidateBool, SuccessfullyApplied,
+		"Make 'crc start' fail when the cluster operators are not stable in time instead of only warning (true/false, default: false)")
+
+	cfg.AddSetting(KubeconfigAutoMerge, true, ValidateBool, SuccessfullyApplied,
+		"Add the contexts of the cluster to the global kubeconfig file on 'crc start', 'crc kubeconfig merge' can be used when it is disabled (true/false, default: true)")
+	cfg.AddSetting(KubeconfigAdminContext, DefaultAdminContext, validateContextName, SuccessfullyApplied,
+		fmt.Sprintf("Name of the kubeconfig context of kubeadmin (string, default: %s)", DefaultAdminContext))
+	cfg.AddSetting(KubeconfigDeveloperContext, DefaultDeveloperContext, validateContextName, SuccessfullyApplied,
+		fmt.Sprintf("Name of the kubeconfig context of developer (string, default: %s)", DefaultDeveloperContext))
+
+	// MicroShift configuration, the settings which are not set keep the value of the bundle
+	cfg.AddSetting(MicroShiftLogLevel, "", validateMicroShiftLogLevel, RequiresRestartMsg,
+		fmt.Sprintf("Log level of MicroShift (%s)", strings.Join(microshiftLogLevels, ", ")))
+	cfg.AddSetting(MicroShiftSubjectAltNames, "", validateSubjectAltNames, RequiresRestartMsg,
+		"Additional names of the MicroShift API server certificate (string, comma-separated list of DNS names and IP addresses)")
+	cfg.AddSetting(MicroShiftBaseDomain, "", validateBaseDomain, RequiresRestartMsg,
+		"Base domain of the MicroShift cluster, the names in this domain must be resolved on the host by the user (string, such as 'example.com')")
+	cfg.AddSetting(MicroShiftClusterNetwork, "", validateCIDR, RequiresDeleteMsg,
+		"Network of the MicroShift pods (CIDR, such as '10.42.0.0/16')")
+	cfg.AddSetting(MicroShiftServiceNetwork, "", validateCIDR, RequiresDeleteMsg,
+		"Network of the MicroShift services (CIDR, such as '10.43.0.0/16')")
+	cfg.AddSetting(MicroShiftManifestsDir, "", validateManifestsDir, RequiresRestartMsg,
+		"Directory of manifests applied by MicroShift when it starts, a kustomization.yaml listing the manifests is generated when it has none (string, path to a directory)")
+
+	cfg.AddSetting(VMCustomization, Path(""), validateVMCustomization, RequiresRestartMsg,
+		"Path of a Butane configuration with files, systemd units and kernel arguments applied to the CRC VM on start when it has changed, removing it does not revert the changes")
+
+	// Node tuning, the settings which are not set keep the value of the bundle
+	cfg.AddSetting(KubeletMaxPods, 0, validateUint, RequiresRestartMsg,
+		"Maximum number of pods on the node (0 keeps the value of the bundle, default: 0)")
+	cfg.AddSetting(KubeletEvictionHard, "", validateEvictionThresholds, RequiresRestartMsg,
+		fmt.Sprintf("Hard eviction thresholds of the kubelet (string, comma-separated list of signal<threshold such as 'memory.available<100Mi,nodefs.available<5%%', signals are %s)",
+			strings.Join(evictionSignals, ", ")))
+	cfg.AddSetting(KubeletImageGCHighThreshold, 0, validatePercentage, RequiresRestartMsg,
+		"Disk usage percentage which starts the image garbage collection (0-100, 0 keeps the value of the bundle, default: 0)")
+	cfg.AddSetting(KubeletImageGCLowThreshold, 0, validatePercentage, RequiresRestartMsg,
+		"Disk usage percentage the image garbage collection frees down to (0-100, 0 keeps the value of the bundle, default: 0)")
+	cfg.AddSetting(CrioLogSizeMax, "", validateSize, RequiresRestartMsg,
+		"Maximum size of the log of a container kept by cri-o (string, such as '50Mi')")
 
 	// Telemeter Configuration
 	cfg.AddSetting(ConsentTelemetry, "", validateYesNo, SuccessfullyApplied,
@@ -141,6 +239,29 @@ func RegisterSettings(cfg *Config) {
 
 	cfg.AddSetting(EnableBundleQuayFallback, false, ValidateBool, SuccessfullyApplied,
 		"If bundle download from the default location fails, fallback to quay.io (true/false, default: false)")
+	cfg.AddSetting(BundleTrustedKeys, "", validateTrustedKeys, SuccessfullyApplied,
+		"Armored public keys trusted to sign bundles, in addition to the Red Hat keys (string, comma-separated list of paths)")
+	cfg.AddSetting(RequireSignedBundles, false, ValidateBool, SuccessfullyApplied,
+		"Refuse to use bundles which are not signed by a trusted key (true/false, default: false)")
+
+	cfg.AddSetting(CacheMaxSize, 0, validateUint, SuccessfullyApplied,
+		"Maximum size in GiB of the cache, the oldest bundles are removed after 'crc setup' and 'crc start' when it is exceeded (0 disables the limit, default: 0)")
+	cfg.AddSetting(CacheKeepVersions, 0, validateUint, SuccessfullyApplied,
+		"Number of bundle versions to keep in the cache for each preset after 'crc setup' and 'crc start' (0 keeps all of them, default: 0)")
+	cfg.AddSetting(CacheKeepInUse, true, ValidateBool, SuccessfullyApplied,
+		"Never remove the bundle used by the instance from the cache (true/false, default: true)")
+
+	cfg.AddSetting(CertExpiryWarningDays, 7, validateUint, SuccessfullyApplied,
+		"Show a warning in 'crc status' when a certificate of the cluster expires within this number of days (0 disables the warning, default: 7)")
+
+	cfg.AddSetting(SecretBackend, string(AutoSecretStorage), validateSecretBackend, SuccessfullyApplied,
+		fmt.Sprintf("Where secrets are stored, '%s' uses the system keyring when it is accessible and an encrypted file otherwise (%s, %s or %s, default: %s)",
+			AutoSecretStorage, AutoSecretStorage, KeyringSecretStorage, FileSecretStorage, AutoSecretStorage))
+	if secretStorage, ok := cfg.secretStorage.(*SecretStorage); ok {
+		secretStorage.backend = func() SecretStorageBackend {
+			return SecretStorageBackend(cfg.Get(SecretBackend).AsString())
+		}
+	}
 
 	if err := cfg.RegisterNotifier(Preset, presetChanged); err != nil {
 		logging.Debugf("Failed to register notifier for Preset: %v", err)
@@ -167,6 +288,35 @@ func GetPreset(config Storage) preset.Preset {
 	return preset.ParsePreset(config.Get(Preset).AsString())
 }
 
+// GetBundleTrustedKeys returns the list of public key files set in bundle-trusted-keys
+func GetBundleTrustedKeys(config Storage) []string {
+	return splitList(config.Get(BundleTrustedKeys).AsString())
+}
+
+// GetClusterStableTimeout returns the duration set in cluster-stable-timeout
+func GetClusterStableTimeout(config Storage) time.Duration {
+	timeout, err := time.ParseDuration(config.Get(ClusterStableTimeout).AsString())
+	if err != nil {
+		return 10 * time.Minute
+	}
+	return timeout
+}
+
+// GetClusterStableIgnoredOperators returns the list of cluster operators set in cluster-stable-ignored-operators
+func GetClusterStableIgnoredOperators(config Storage) []string {
+	return splitList(config.Get(ClusterStableIgnoredOperators).AsString())
+}
+
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func defaultNetworkMode() network.Mode {
 	if runtime.GOOS != "linux" {
 		return network.UserNetworkingMode
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	crcpreset "github.com/crc-org/crc/v2/pkg/crc/preset"
@@ -182,3 +183,40 @@ func TestPath(t *testing.T) {
 		IsSecret:  false,
 	}, cfg.Get(ProxyCAFile))
 }
+
+func TestClusterStableSettings(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	assert.Equal(t, 10*time.Minute, GetClusterStableTimeout(cfg))
+	_, err = cfg.Set(ClusterStableTimeout, "15")
+	assert.Error(t, err)
+	_, err = cfg.Set(ClusterStableTimeout, "90s")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, GetClusterStableTimeout(cfg))
+
+	_, err = cfg.Set(ClusterStableChecks, 0)
+	assert.Error(t, err)
+	_, err = cfg.Set(ClusterStableChecks, 1)
+	assert.NoError(t, err)
+
+	_, err = cfg.Set(ClusterStableIgnoredOperators, "insights, Monitoring")
+	assert.Error(t, err)
+	_, err = cfg.Set(ClusterStableIgnoredOperators, "insights, monitoring")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"insights", "monitoring"}, GetClusterStableIgnoredOperators(cfg))
+}
+
+func TestKubeconfigContexts(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	_, err = cfg.Set(KubeconfigAdminContext, "crc admin")
+	assert.Error(t, err)
+	assert.Equal(t, []Problem{{
+		Keys:    []string{KubeconfigAdminContext, KubeconfigDeveloperContext},
+		Message: "The kubeadmin and developer kubeconfig contexts must have different names",
+	}}, cfg.ValidateValues(map[string]interface{}{
+		KubeconfigAdminContext: DefaultDeveloperContext,
+	}))
+}
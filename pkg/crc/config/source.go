@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"strings"
+)
+
+// Source tells where the value of a setting comes from
+type Source string
+
+const (
+	// SourceDefault is used for settings without a value
+	SourceDefault Source = "default"
+	// SourcePresetDefault is used for settings without a value whose default value depends on the preset
+	SourcePresetDefault Source = "preset-default"
+	SourceFile          Source = "file"
+	SourceKeyring       Source = "keyring"
+	// SourceSecretsFile is the encrypted file used when the keyring is not available
+	SourceSecretsFile Source = "secrets-file"
+	SourceFlag        Source = "flag"
+	SourceEnv         Source = "env"
+)
+
+// sourceStorage is implemented by the storages which can tell where their values come from
+type sourceStorage interface {
+	Source(key string) Source
+}
+
+// EnvVarName returns the name of the environment variable overriding the
+// setting key, for example CRC_NETWORK_MODE for network-mode
+func EnvVarName(envPrefix, key string) string {
+	return strings.ToUpper(envPrefix + "_" + strings.ReplaceAll(key, "-", "_"))
+}
+
+// lookupEnv returns the value of the environment variable overriding key.
+// Like viper, empty environment variables are ignored.
+func lookupEnv(envPrefix, key string) (string, bool) {
+	if envPrefix == "" {
+		return "", false
+	}
+	value := os.Getenv(EnvVarName(envPrefix, key))
+	return value, value != ""
+}
+
+// Source returns where the value of key comes from
+func (c *Config) Source(key string) Source {
+	setting, ok := c.settingsByName[key]
+	if !ok {
+		return ""
+	}
+	source := c.storageSource(setting)
+	if source == SourceDefault && setting.presetDefault {
+		return SourcePresetDefault
+	}
+	return source
+}
+
+func (c *Config) storageSource(setting Setting) Source {
+	storage := c.storage
+	if setting.isSecret {
+		storage = c.secretStorage
+	}
+	if s, ok := storage.(sourceStorage); ok {
+		return s.Source(setting.Name)
+	}
+	if storage.Get(setting.Name) == nil {
+		return SourceDefault
+	}
+	if setting.isSecret {
+		return SourceKeyring
+	}
+	return SourceFile
+}
+
+// setPresetDefault records that the default value of keys depends on the preset
+func (c *Config) setPresetDefault(keys ...string) {
+	for _, key := range keys {
+		if setting, ok := c.settingsByName[key]; ok {
+			setting.presetDefault = true
+			c.settingsByName[key] = setting
+		}
+	}
+}
+
+// AllSources returns where the values of all the known configs come from
+func (c *Config) AllSources() map[string]Source {
+	sources := make(map[string]Source)
+	for key := range c.settingsByName {
+		sources[key] = c.Source(key)
+	}
+	return sources
+}
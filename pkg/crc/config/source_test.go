@@ -0,0 +1,79 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	crcpreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnvVarName(t *testing.T) {
+	assert.Equal(t, "CRC_NETWORK_MODE", EnvVarName("CRC", NetworkMode))
+	assert.Equal(t, "CRC_CPUS", EnvVarName("CRC", CPUs))
+}
+
+func TestSource(t *testing.T) {
+	dir := t.TempDir()
+	storage, err := NewViperStorage(filepath.Join(dir, "crc.json"), "CRC")
+	require.NoError(t, err)
+	config := New(storage, NewEmptyInMemorySecretStorage())
+	config.AddSetting(cpus, 4, validateUint, RequiresRestartMsg, "")
+	config.AddSetting(nameServer, "", validateIPAddress, SuccessfullyApplied, "")
+
+	assert.Equal(t, SourceDefault, config.Source(nameServer))
+	assert.Equal(t, Source(""), config.Source("foo"))
+
+	_, err = config.Set(nameServer, "1.1.1.1")
+	require.NoError(t, err)
+	assert.Equal(t, SourceFile, config.Source(nameServer))
+
+	t.Setenv("CRC_NAMESERVERS", "8.8.8.8")
+	assert.Equal(t, SourceEnv, config.Source(nameServer))
+	assert.Equal(t, "8.8.8.8", config.Get(nameServer).AsString())
+
+	flagSet := pflag.NewFlagSet("start", pflag.ExitOnError)
+	flagSet.UintP(cpus, "c", 4, "")
+	require.NoError(t, storage.BindFlagSet(flagSet))
+	assert.Equal(t, SourceDefault, config.Source(cpus))
+	require.NoError(t, flagSet.Set(cpus, "6"))
+	assert.Equal(t, SourceFlag, config.Source(cpus))
+	assert.Equal(t, uint(6), config.Get(cpus).AsUInt())
+}
+
+func TestSecretSource(t *testing.T) {
+	secretStorage := NewEmptyInMemorySecretStorage()
+	secretStorage.envPrefix = "CRC"
+	config := New(NewEmptyInMemoryStorage(), secretStorage)
+	config.AddSetting("password", Secret(""), validateString, SuccessfullyApplied, "")
+
+	assert.Equal(t, SourceDefault, config.Source("password"))
+	_, err := config.Set("password", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, SourceKeyring, config.Source("password"))
+
+	t.Setenv("CRC_PASSWORD", "override")
+	assert.Equal(t, SourceEnv, config.Source("password"))
+	assert.Equal(t, Secret("override"), config.Get("password").Value)
+}
+
+func TestPresetDefaultSource(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	_, err = cfg.Set(Preset, crcpreset.Microshift.String())
+	require.NoError(t, err)
+	assert.Equal(t, SourceFile, cfg.Source(Preset))
+	assert.Equal(t, SourcePresetDefault, cfg.Source(CPUs))
+	assert.Equal(t, SourcePresetDefault, cfg.Source(Memory))
+	assert.Equal(t, SourcePresetDefault, cfg.Source(Bundle))
+	assert.Equal(t, SourceDefault, cfg.Source(DiskSize))
+	assert.Equal(t, constants.GetDefaultMemory(crcpreset.Microshift), cfg.Get(Memory).AsUInt())
+
+	_, err = cfg.Set(Memory, constants.GetDefaultMemory(crcpreset.Microshift)+1024)
+	require.NoError(t, err)
+	assert.Equal(t, SourceFile, cfg.Source(Memory))
+}
@@ -19,7 +19,9 @@ type Setting struct {
 	validationFn ValidationFnType
 	callbackFn   SetFn
 	isSecret     bool
-	Help         string
+	// presetDefault is true when the default value depends on the preset
+	presetDefault bool
+	Help          string
 }
 
 type SettingValue struct {
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Problem is an invalid value or an invalid combination of values
+type Problem struct {
+	// Keys are the settings involved in the problem
+	Keys    []string `json:"keys"`
+	Message string   `json:"message"`
+}
+
+func (p Problem) Error() string {
+	return fmt.Sprintf("%s: %s", strings.Join(p.Keys, ", "), p.Message)
+}
+
+func (p Problem) involves(keys []string) bool {
+	for _, key := range p.Keys {
+		if crcstrings.Contains(keys, key) {
+			return true
+		}
+	}
+	return false
+}
+
+// dependencies are the settings read by the validation function of a setting
+var dependencies = map[string][]string{
+	Bundle:            {Preset},
+	CPUs:              {Preset},
+	Memory:            {Preset},
+	HostNetworkAccess: {NetworkMode},
+	EnableSharedDirs:  {HostNetworkAccess, SharedDirPassword},
+}
+
+// alwaysValidated are validated even when they use their default value,
+// which can be invalid for the preset or the host
+var alwaysValidated = []string{CPUs, Memory}
+
+// crossChecks find the invalid combinations of values which are not caught
+// by the validation function of a single setting
+var crossChecks = []func(cfg *Config) *Problem{
+	checkIngressPorts,
+	checkProxyCAFile,
+	checkMaxCPUs,
+	checkMaxMemory,
+	checkMonitoring,
+	checkOperators,
+	checkKubeconfigContexts,
+	checkMicroShiftSettings,
+	checkMicroShiftNetworks,
+	checkImageGCThresholds,
+}
+
+// Validate checks the value of every setting, and their combinations. It
+// returns all the problems which would prevent the instance from starting.
+func (c *Config) Validate() []Problem {
+	var keys []string
+	for key := range c.settingsByName {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []Problem
+	for _, key := range keys {
+		setting := c.settingsByName[key]
+		if setting.validationFn == nil {
+			continue
+		}
+		value := c.Get(key)
+		if value.Invalid {
+			problems = append(problems, Problem{
+				Keys:    []string{key},
+				Message: fmt.Sprintf("Value for configuration property '%s' cannot be read", key),
+			})
+			continue
+		}
+		if value.IsDefault && !crcstrings.Contains(alwaysValidated, key) {
+			continue
+		}
+		if err := c.validate(key, value.Value); err != nil {
+			problems = append(problems, Problem{
+				Keys:    append([]string{key}, dependencies[key]...),
+				Message: err.Error(),
+			})
+		}
+	}
+	for _, check := range crossChecks {
+		if problem := check(c); problem != nil {
+			problems = append(problems, *problem)
+		}
+	}
+	return problems
+}
+
+// ValidateValues returns the problems involving the keys of values which
+// the configuration would have if values were set. Unknown keys and values
+// of the wrong type are ignored, they are rejected by Set.
+func (c *Config) ValidateValues(values map[string]interface{}) []Problem {
+	var keys []string
+	castValues := map[string]interface{}{}
+	secretValues := map[string]interface{}{}
+	for key, value := range values {
+		setting, ok := c.settingsByName[key]
+		if !ok {
+			continue
+		}
+		castValue, err := setting.cast(value)
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+		if setting.isSecret {
+			secretValues[key] = castValue
+		} else {
+			castValues[key] = castValue
+		}
+	}
+
+	var problems []Problem
+	for _, problem := range c.withValues(castValues, secretValues).Validate() {
+		if problem.involves(keys) {
+			problems = append(problems, problem)
+		}
+	}
+	return problems
+}
+
+func checkIngressPorts(cfg *Config) *Problem {
+	if cfg.Get(IngressHTTPPort).AsUInt() != cfg.Get(IngressHTTPSPort).AsUInt() {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{IngressHTTPPort, IngressHTTPSPort},
+		Message: fmt.Sprintf("HTTP and HTTPS routes cannot both use port %d", cfg.Get(IngressHTTPPort).AsUInt()),
+	}
+}
+
+func checkProxyCAFile(cfg *Config) *Problem {
+	if cfg.Get(ProxyCAFile).AsString() == "" || cfg.Get(HTTPSProxy).AsString() != "" {
+		return nil
+	}
+	// crc also uses the proxy of the environment
+	if httpproxy.FromEnvironment().HTTPSProxy != "" {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{ProxyCAFile, HTTPSProxy},
+		Message: "The proxy CA file is only used with an HTTPS proxy",
+	}
+}
+
+func checkMaxCPUs(cfg *Config) *Problem {
+	return checkMaximum(cfg, CPUs, MaxCPUs)
+}
+
+func checkMaxMemory(cfg *Config) *Problem {
+	return checkMaximum(cfg, Memory, MaxMemory)
+}
+
+// checkMaximum checks that the maximum of a resource changed without a
+// restart is not lower than the resource, 0 disables the maximum. The
+// maximums are only registered on Linux, there is nothing to check on the
+// other platforms.
+func checkMaximum(cfg *Config, key, maxKey string) *Problem {
+	if _, ok := cfg.settingsByName[maxKey]; !ok {
+		return nil
+	}
+	maximum := cfg.Get(maxKey)
+	if maximum.Invalid || maximum.AsUInt() == 0 || maximum.AsUInt() >= cfg.Get(key).AsUInt() {
+		return nil
+	}
+	return &Problem{
+		Keys:    []string{maxKey, key},
+		Message: fmt.Sprintf("%s must be 0 or greater than or equal to %s (%d)", maxKey, key, cfg.Get(key).AsUInt()),
+	}
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/network"
+	crcpreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Validate())
+
+	// values which are not set with Set, for example when the configuration file is edited
+	require.NoError(t, cfg.storage.Set(Memory, 2048))
+	require.NoError(t, cfg.storage.Set(NetworkMode, string(network.SystemNetworkingMode)))
+	require.NoError(t, cfg.storage.Set(HostNetworkAccess, true))
+	require.NoError(t, cfg.storage.Set(IngressHTTPPort, 8443))
+	require.NoError(t, cfg.storage.Set(IngressHTTPSPort, 8443))
+
+	problems := cfg.Validate()
+	require.Len(t, problems, 3)
+	assert.Equal(t, []string{HostNetworkAccess, NetworkMode}, problems[0].Keys)
+	assert.Equal(t, []string{Memory, Preset}, problems[1].Keys)
+	assert.Equal(t, Problem{
+		Keys:    []string{IngressHTTPPort, IngressHTTPSPort},
+		Message: "HTTP and HTTPS routes cannot both use port 8443",
+	}, problems[2])
+}
+
+func TestValidateProxyCAFile(t *testing.T) {
+	t.Setenv("HTTPS_PROXY", "")
+	t.Setenv("https_proxy", "")
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(caFile, []byte("ca"), 0600))
+	_, err = cfg.Set(ProxyCAFile, caFile)
+	require.NoError(t, err)
+	assert.Equal(t, []Problem{{
+		Keys:    []string{ProxyCAFile, HTTPSProxy},
+		Message: "The proxy CA file is only used with an HTTPS proxy",
+	}}, cfg.Validate())
+
+	_, err = cfg.Set(HTTPSProxy, "https://proxy.example.com:3128")
+	require.NoError(t, err)
+	assert.Empty(t, cfg.Validate())
+}
+
+func TestValidateMaximums(t *testing.T) {
+	if runtime.GOOS != "linux" {
+		t.Skip("max-cpus and max-memory are only available with libvirt")
+	}
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	_, err = cfg.Set(CPUs, 6)
+	require.NoError(t, err)
+
+	problems := cfg.ValidateValues(map[string]interface{}{
+		MaxCPUs: 4,
+	})
+	assert.Equal(t, []Problem{{
+		Keys:    []string{MaxCPUs, CPUs},
+		Message: "max-cpus must be 0 or greater than or equal to cpus (6)",
+	}}, problems)
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		MaxCPUs: 8,
+	}))
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		MaxCPUs: 0,
+	}))
+}
+
+func TestValidateValues(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+	_, err = cfg.Set(IngressHTTPPort, 8080)
+	require.NoError(t, err)
+
+	problems := cfg.ValidateValues(map[string]interface{}{
+		IngressHTTPSPort: 8080,
+	})
+	require.Len(t, problems, 1)
+	assert.Equal(t, []string{IngressHTTPPort, IngressHTTPSPort}, problems[0].Keys)
+	assert.Equal(t, uint(443), cfg.Get(IngressHTTPSPort).AsUInt())
+
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		CPUs:  6,
+		"foo": "bar",
+	}))
+	// problems which do not involve the new values are not reported
+	require.NoError(t, cfg.storage.Set(IngressHTTPSPort, 8080))
+	assert.Empty(t, cfg.ValidateValues(map[string]interface{}{
+		CPUs: 6,
+	}))
+}
+
+// Check that validating values does not change the configuration used by
+// concurrent requests of the daemon
+func TestValidateValuesConcurrently(t *testing.T) {
+	cfg, err := newInMemoryConfig()
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Len(t, cfg.ValidateValues(map[string]interface{}{
+				Preset: crcpreset.OpenShift.String(),
+				CPUs:   2,
+			}), 1)
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, crcpreset.OpenShift, GetPreset(cfg))
+		assert.Equal(t, constants.GetDefaultCPUs(crcpreset.OpenShift), cfg.Get(CPUs).AsUInt())
+	}
+	wg.Wait()
+}
@@ -5,6 +5,8 @@ import (
 	"strings"
 
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/customization/butane"
+	"github.com/crc-org/crc/v2/pkg/crc/gpg"
 	"github.com/crc-org/crc/v2/pkg/crc/network/httpproxy"
 	crcpreset "github.com/crc-org/crc/v2/pkg/crc/preset"
 	"github.com/crc-org/crc/v2/pkg/crc/validation"
@@ -41,6 +43,41 @@ func validateDiskSize(value interface{}) (bool, string) {
 	return true, ""
 }
 
+// validateUint checks that the value is a non-negative integer
+func validateUint(value interface{}) (bool, string) {
+	if _, err := cast.ToUintE(value); err != nil {
+		return false, fmt.Sprintf("could not convert '%s' to a non-negative integer", value)
+	}
+	return true, ""
+}
+
+// validateDuration checks that the value is a positive duration such as '15m'
+func validateDuration(value interface{}) (bool, string) {
+	if err := validation.ValidateDuration(cast.ToString(value)); err != nil {
+		return false, err.Error()
+	}
+	return true, ""
+}
+
+// validateStableChecks checks that the number of consecutive checks is at least 1
+func validateStableChecks(value interface{}) (bool, string) {
+	checks, err := cast.ToUintE(value)
+	if err != nil || checks == 0 {
+		return false, fmt.Sprintf("could not convert '%s' to a positive integer", value)
+	}
+	return true, ""
+}
+
+// validateClusterOperatorNames checks a comma-separated list of cluster operator names
+func validateClusterOperatorNames(value interface{}) (bool, string) {
+	for _, name := range splitList(cast.ToString(value)) {
+		if !operatorNameRegexp.MatchString(name) {
+			return false, fmt.Sprintf("invalid cluster operator name '%s'", name)
+		}
+	}
+	return true, ""
+}
+
 // validatePersistentVolumeSize checks if provided disk size is valid in the config
 func validatePersistentVolumeSize(value interface{}) (bool, string) {
 	diskSize, err := cast.ToIntE(value)
@@ -103,6 +140,29 @@ func validatePath(value interface{}) (bool, string) {
 	return true, ""
 }
 
+// validateVMCustomization checks that the file is a supported Butane configuration
+func validateVMCustomization(value interface{}) (bool, string) {
+	if file := cast.ToString(value); file != "" {
+		if _, err := butane.Parse(file); err != nil {
+			return false, err.Error()
+		}
+	}
+	return true, ""
+}
+
+// validateTrustedKeys checks that each file in the comma-separated list is an armored public key
+func validateTrustedKeys(value interface{}) (bool, string) {
+	for _, keyFile := range splitList(cast.ToString(value)) {
+		if err := validation.ValidatePath(keyFile); err != nil {
+			return false, err.Error()
+		}
+		if _, err := gpg.ReadPublicKeyFile(keyFile); err != nil {
+			return false, err.Error()
+		}
+	}
+	return true, ""
+}
+
 // validateHTTPProxy checks if given URI is valid for a HTTP proxy
 func validateHTTPProxy(value interface{}) (bool, string) {
 	if err := httpproxy.ValidateProxyURL(cast.ToString(value), false); err != nil {
@@ -134,6 +194,15 @@ func validateYesNo(value interface{}) (bool, string) {
 	return false, "must be yes or no"
 }
 
+func validateSecretBackend(value interface{}) (bool, string) {
+	switch SecretStorageBackend(cast.ToString(value)) {
+	case AutoSecretStorage, KeyringSecretStorage, FileSecretStorage:
+		return true, ""
+	default:
+		return false, fmt.Sprintf("must be %s, %s or %s", AutoSecretStorage, KeyringSecretStorage, FileSecretStorage)
+	}
+}
+
 func validatePreset(value interface{}) (bool, string) {
 	_, err := crcpreset.ParsePresetE(cast.ToString(value))
 	if err != nil {
@@ -20,6 +20,8 @@ type ViperStorage struct {
 
 	configFile string
 	envPrefix  string
+	// when set, the configuration file of the active profile is used instead of configFile
+	profiles *Profiles
 }
 
 func NewViperStorage(configFile, envPrefix string) (*ViperStorage, error) {
@@ -30,12 +32,30 @@ func NewViperStorage(configFile, envPrefix string) (*ViperStorage, error) {
 	}, nil
 }
 
+// NewViperStorageWithProfiles returns a storage using the configuration file of the active profile
+func NewViperStorageWithProfiles(profiles *Profiles, envPrefix string) (*ViperStorage, error) {
+	storage, err := NewViperStorage(profiles.ConfigFile(DefaultProfile), envPrefix)
+	if err != nil {
+		return nil, err
+	}
+	storage.profiles = profiles
+	return storage, nil
+}
+
+func (c *ViperStorage) currentConfigFile() string {
+	if c.profiles == nil {
+		return c.configFile
+	}
+	return c.profiles.ConfigFile(c.profiles.Active())
+}
+
 func (c *ViperStorage) viperInstance() (*viper.Viper, error) {
-	if err := ensureConfigFileExists(c.configFile); err != nil {
+	configFile := c.currentConfigFile()
+	if err := ensureConfigFileExists(configFile); err != nil {
 		return nil, err
 	}
 	v := viper.New()
-	v.SetConfigFile(c.configFile)
+	v.SetConfigFile(configFile)
 	v.SetConfigType("json")
 	v.SetEnvPrefix(c.envPrefix)
 	// Replaces '-' in flags with '_' in env variables
@@ -43,7 +63,7 @@ func (c *ViperStorage) viperInstance() (*viper.Viper, error) {
 	v.AutomaticEnv()
 	v.SetTypeByDefaultValue(true)
 	if err := v.ReadInConfig(); err != nil {
-		return nil, fmt.Errorf("error reading configuration file '%s': %v", c.configFile, err)
+		return nil, fmt.Errorf("error reading configuration file '%s': %v", configFile, err)
 	}
 	if c.flagSet == nil {
 		return v, nil
@@ -62,12 +82,18 @@ func (c *ViperStorage) Get(key string) interface{} {
 }
 
 func (c *ViperStorage) Set(key string, value interface{}) error {
+	return c.SetAll(map[string]interface{}{key: value})
+}
+
+// SetAll sets all values with a single write of the configuration file
+func (c *ViperStorage) SetAll(values map[string]interface{}) error {
 	c.storeLock.Lock()
 	defer c.storeLock.Unlock()
-	if err := ensureConfigFileExists(c.configFile); err != nil {
+	configFile := c.currentConfigFile()
+	if err := ensureConfigFileExists(configFile); err != nil {
 		return err
 	}
-	in, err := os.ReadFile(c.configFile)
+	in, err := os.ReadFile(configFile)
 	if err != nil {
 		return err
 	}
@@ -75,21 +101,24 @@ func (c *ViperStorage) Set(key string, value interface{}) error {
 	if err := json.Unmarshal(in, &cfg); err != nil {
 		return err
 	}
-	cfg[key] = value
+	for key, value := range values {
+		cfg[key] = value
+	}
 	bin, err := json.MarshalIndent(cfg, "", "  ")
 	if err != nil {
 		return err
 	}
-	return atomicWrite(bin, c.configFile)
+	return atomicWrite(bin, configFile)
 }
 
 func (c *ViperStorage) Unset(key string) error {
 	c.storeLock.Lock()
 	defer c.storeLock.Unlock()
-	if err := ensureConfigFileExists(c.configFile); err != nil {
+	configFile := c.currentConfigFile()
+	if err := ensureConfigFileExists(configFile); err != nil {
 		return err
 	}
-	in, err := os.ReadFile(c.configFile)
+	in, err := os.ReadFile(configFile)
 	if err != nil {
 		return err
 	}
@@ -102,7 +131,26 @@ func (c *ViperStorage) Unset(key string) error {
 	if err != nil {
 		return err
 	}
-	return atomicWrite(bin, c.configFile)
+	return atomicWrite(bin, configFile)
+}
+
+// Source returns where the value of key comes from, using the same order as viper
+func (c *ViperStorage) Source(key string) Source {
+	c.storeLock.Lock()
+	defer c.storeLock.Unlock()
+	if c.flagSet != nil {
+		if flag := c.flagSet.Lookup(key); flag != nil && flag.Changed {
+			return SourceFlag
+		}
+	}
+	if _, ok := lookupEnv(c.envPrefix, key); ok {
+		return SourceEnv
+	}
+	viperInstance, err := c.viperInstance()
+	if err == nil && viperInstance.InConfig(key) {
+		return SourceFile
+	}
+	return SourceDefault
 }
 
 // BindFlagset binds a flagset to their respective config properties
@@ -107,6 +107,7 @@ var (
 	CrcPodmanBinDir    = filepath.Join(CrcBinDir, "podman")
 	CrcSymlinkPath     = filepath.Join(CrcBinDir, "crc")
 	ConfigPath         = filepath.Join(CrcBaseDir, ConfigFile)
+	SecretsPath        = filepath.Join(CrcBaseDir, "secrets.json.enc")
 	LogFilePath        = filepath.Join(CrcBaseDir, LogFile)
 	DaemonLogFilePath  = filepath.Join(CrcBaseDir, DaemonLogFile)
 	MachineBaseDir     = CrcBaseDir
@@ -0,0 +1,144 @@
+// Package butane parses the subset of the Butane configuration which can be
+// applied to the instance
+package butane
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	pathRegexp     = regexp.MustCompile(`^(/[A-Za-z0-9@._+-]+)+$`)
+	unitNameRegexp = regexp.MustCompile(`^[A-Za-z0-9@._\\:-]+\.(service|socket|timer|path|mount|target)$`)
+	dropinRegexp   = regexp.MustCompile(`^[A-Za-z0-9@._-]+\.conf$`)
+	kargRegexp     = regexp.MustCompile(`^[A-Za-z0-9._,:/=+-]+$`)
+)
+
+// Config is the subset of the Butane configuration which is applied to the
+// instance: files, systemd units and kernel arguments
+type Config struct {
+	Variant         string          `yaml:"variant"`
+	Version         string          `yaml:"version"`
+	Storage         Storage         `yaml:"storage"`
+	Systemd         Systemd         `yaml:"systemd"`
+	KernelArguments KernelArguments `yaml:"kernel_arguments"`
+
+	checksum string
+}
+
+type Storage struct {
+	Files []File `yaml:"files"`
+}
+
+type File struct {
+	Path     string   `yaml:"path"`
+	Mode     *int     `yaml:"mode"`
+	Contents Contents `yaml:"contents"`
+
+	data []byte
+}
+
+// Data is the content of the file, read from Contents when parsing
+func (f File) Data() []byte {
+	return f.data
+}
+
+// Contents of a file, Local is relative to the directory of the configuration
+type Contents struct {
+	Inline string `yaml:"inline"`
+	Local  string `yaml:"local"`
+}
+
+type Systemd struct {
+	Units []Unit `yaml:"units"`
+}
+
+// Unit is a systemd unit, it is only enabled or disabled when Enabled is set,
+// and an existing unit is customized when Contents is empty
+type Unit struct {
+	Name     string   `yaml:"name"`
+	Enabled  *bool    `yaml:"enabled"`
+	Contents string   `yaml:"contents"`
+	Dropins  []Dropin `yaml:"dropins"`
+}
+
+type Dropin struct {
+	Name     string `yaml:"name"`
+	Contents string `yaml:"contents"`
+}
+
+type KernelArguments struct {
+	ShouldExist    []string `yaml:"should_exist"`
+	ShouldNotExist []string `yaml:"should_not_exist"`
+}
+
+// Parse reads and validates the configuration of file, the fields which are
+// not supported are reported as errors
+func Parse(file string) (*Config, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var config Config
+	if err := decoder.Decode(&config); err != nil {
+		return nil, fmt.Errorf("Cannot parse %s: %w", file, err)
+	}
+
+	hash := sha256.New()
+	hash.Write(data)
+	for i := range config.Storage.Files {
+		f := &config.Storage.Files[i]
+		if !pathRegexp.MatchString(f.Path) || path.Clean(f.Path) != f.Path {
+			return nil, fmt.Errorf("invalid file path '%s'", f.Path)
+		}
+		if f.Mode != nil && (*f.Mode < 0 || *f.Mode > 07777) {
+			return nil, fmt.Errorf("invalid mode %o for %s", *f.Mode, f.Path)
+		}
+		switch {
+		case f.Contents.Local != "" && f.Contents.Inline != "":
+			return nil, fmt.Errorf("%s has both inline and local contents", f.Path)
+		case f.Contents.Local != "":
+			local := f.Contents.Local
+			if !filepath.IsAbs(local) {
+				local = filepath.Join(filepath.Dir(file), local)
+			}
+			if f.data, err = os.ReadFile(local); err != nil {
+				return nil, err
+			}
+			hash.Write(f.data)
+		default:
+			f.data = []byte(f.Contents.Inline)
+		}
+	}
+	for _, unit := range config.Systemd.Units {
+		if !unitNameRegexp.MatchString(unit.Name) {
+			return nil, fmt.Errorf("invalid systemd unit name '%s'", unit.Name)
+		}
+		for _, dropin := range unit.Dropins {
+			if !dropinRegexp.MatchString(dropin.Name) {
+				return nil, fmt.Errorf("invalid drop-in name '%s' for %s", dropin.Name, unit.Name)
+			}
+		}
+	}
+	for _, karg := range append(config.KernelArguments.ShouldExist, config.KernelArguments.ShouldNotExist...) {
+		if !kargRegexp.MatchString(karg) {
+			return nil, fmt.Errorf("invalid kernel argument '%s'", karg)
+		}
+	}
+	config.checksum = fmt.Sprintf("%x", hash.Sum(nil))
+	return &config, nil
+}
+
+// Checksum identifies the configuration and the content of its local files
+func (c *Config) Checksum() string {
+	return c.checksum
+}
@@ -0,0 +1,54 @@
+package butane
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	config, err := Parse(filepath.Join("testdata", "customization.yaml"))
+	require.NoError(t, err)
+
+	require.Len(t, config.Storage.Files, 2)
+	assert.Equal(t, 0644, *config.Storage.Files[0].Mode)
+	assert.Equal(t, "vm.max_map_count = 262144\n", string(config.Storage.Files[0].Data()))
+	assert.Equal(t, "server time.example.com iburst\n", string(config.Storage.Files[1].Data()))
+	require.Len(t, config.Systemd.Units, 3)
+	assert.Nil(t, config.Systemd.Units[2].Enabled)
+	assert.Equal(t, []string{"mitigations=auto,nosmt"}, config.KernelArguments.ShouldNotExist)
+	assert.Len(t, config.Checksum(), 64)
+}
+
+func TestParseChecksumIncludesLocalFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "customization.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("storage:\n  files:\n    - path: /etc/motd\n      contents:\n        local: motd\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "motd"), []byte("hello\n"), 0600))
+	config, err := Parse(file)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "motd"), []byte("bye\n"), 0600))
+	updated, err := Parse(file)
+	require.NoError(t, err)
+	assert.NotEqual(t, config.Checksum(), updated.Checksum())
+}
+
+func TestParseErrors(t *testing.T) {
+	for content, expected := range map[string]string{
+		"passwd:\n  users: []\n":                                                         "field passwd not found",
+		"storage:\n  files:\n    - path: etc/motd\n":                                     "invalid file path 'etc/motd'",
+		"storage:\n  files:\n    - path: /etc/../root/x\n":                               "invalid file path '/etc/../root/x'",
+		"systemd:\n  units:\n    - name: hello\n":                                        "invalid systemd unit name 'hello'",
+		"kernel_arguments:\n  should_exist:\n    - \"a b\"\n":                            "invalid kernel argument 'a b'",
+		"systemd:\n  units:\n    - name: a.service\n      dropins:\n        - name: x\n": "invalid drop-in name 'x' for a.service",
+	} {
+		file := filepath.Join(t.TempDir(), "customization.yaml")
+		require.NoError(t, os.WriteFile(file, []byte(content), 0600))
+		_, err := Parse(file)
+		assert.ErrorContains(t, err, expected)
+	}
+}
@@ -0,0 +1,156 @@
+package customization
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/customization/butane"
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	"github.com/crc-org/crc/v2/pkg/crc/systemd"
+)
+
+const (
+	// ChecksumFile stores in the instance the checksum of the customization
+	// which was applied last
+	ChecksumFile = "/var/lib/crc/vm-customization.sha256"
+
+	defaultFileMode = 0644
+	systemdUnitDir  = "/etc/systemd/system"
+	sysctlDir       = "/etc/sysctl.d"
+)
+
+// Apply writes the files and the systemd units of config, and updates the
+// kernel arguments. It returns true when a reboot is required for the kernel
+// arguments to take effect.
+func Apply(sshRunner *ssh.Runner, config *butane.Config) (bool, error) {
+	sysctlChanged := false
+	for _, f := range config.Storage.Files {
+		mode := defaultFileMode
+		if f.Mode != nil {
+			mode = *f.Mode
+		}
+		if err := writeFile(sshRunner, f.Path, f.Data(), os.FileMode(mode)); err != nil {
+			return false, err
+		}
+		sysctlChanged = sysctlChanged || path.Dir(f.Path) == sysctlDir
+	}
+	if sysctlChanged {
+		if _, _, err := sshRunner.RunPrivileged("Applying the sysctl settings", "sysctl", "--system"); err != nil {
+			return false, err
+		}
+	}
+
+	if err := applyUnits(sshRunner, config.Systemd.Units); err != nil {
+		return false, err
+	}
+
+	kargs := config.KernelArguments
+	if len(kargs.ShouldExist) == 0 && len(kargs.ShouldNotExist) == 0 {
+		return false, nil
+	}
+	cmdline, _, err := sshRunner.Run("cat", "/proc/cmdline")
+	if err != nil {
+		return false, err
+	}
+	if kernelArgumentsApplied(cmdline, kargs) {
+		return false, nil
+	}
+	if _, _, err := sshRunner.RunPrivileged("Updating the kernel arguments", kernelArgumentsCommand(sshRunner, kargs)...); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+func applyUnits(sshRunner *ssh.Runner, units []butane.Unit) error {
+	if len(units) == 0 {
+		return nil
+	}
+	for _, unit := range units {
+		if unit.Contents != "" {
+			if err := writeFile(sshRunner, path.Join(systemdUnitDir, unit.Name), []byte(unit.Contents), defaultFileMode); err != nil {
+				return err
+			}
+		}
+		for _, dropin := range unit.Dropins {
+			if err := writeFile(sshRunner, path.Join(systemdUnitDir, unit.Name+".d", dropin.Name), []byte(dropin.Contents), defaultFileMode); err != nil {
+				return err
+			}
+		}
+	}
+	sd := systemd.NewInstanceSystemdCommander(sshRunner)
+	if err := sd.DaemonReload(); err != nil {
+		return err
+	}
+	for _, unit := range units {
+		var err error
+		switch {
+		case unit.Enabled == nil:
+			// only restart the units which are running to apply their new configuration
+			_, _, err = sshRunner.RunPrivileged(fmt.Sprintf("Restarting %s", unit.Name), "systemctl", "try-restart", unit.Name)
+		case *unit.Enabled:
+			if err = sd.Enable(unit.Name); err == nil {
+				err = sd.Restart(unit.Name)
+			}
+		default:
+			if err = sd.Disable(unit.Name); err == nil {
+				err = sd.Stop(unit.Name)
+			}
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to apply systemd unit %s: %w", unit.Name, err)
+		}
+	}
+	return nil
+}
+
+// kernelArgumentsApplied returns true when the kernel command line already
+// has the arguments which should exist, and none of the ones which should not
+func kernelArgumentsApplied(cmdline string, kargs butane.KernelArguments) bool {
+	current := map[string]bool{}
+	for _, karg := range strings.Fields(cmdline) {
+		current[karg] = true
+	}
+	for _, karg := range kargs.ShouldExist {
+		if !current[karg] {
+			return false
+		}
+	}
+	for _, karg := range kargs.ShouldNotExist {
+		if current[karg] {
+			return false
+		}
+	}
+	return true
+}
+
+// kernelArgumentsCommand uses rpm-ostree on ostree based instances, and
+// grubby on the other ones
+func kernelArgumentsCommand(sshRunner *ssh.Runner, kargs butane.KernelArguments) []string {
+	if _, _, err := sshRunner.Run("test", "-e", "/run/ostree-booted"); err == nil {
+		command := []string{"rpm-ostree", "kargs"}
+		for _, karg := range kargs.ShouldExist {
+			command = append(command, "--append-if-missing="+karg)
+		}
+		for _, karg := range kargs.ShouldNotExist {
+			command = append(command, "--delete-if-present="+karg)
+		}
+		return command
+	}
+	command := []string{"grubby", "--update-kernel=ALL"}
+	if len(kargs.ShouldExist) != 0 {
+		command = append(command, fmt.Sprintf("--args='%s'", strings.Join(kargs.ShouldExist, " ")))
+	}
+	if len(kargs.ShouldNotExist) != 0 {
+		command = append(command, fmt.Sprintf("--remove-args='%s'", strings.Join(kargs.ShouldNotExist, " ")))
+	}
+	return command
+}
+
+func writeFile(sshRunner *ssh.Runner, file string, data []byte, mode os.FileMode) error {
+	if _, _, err := sshRunner.RunPrivileged(fmt.Sprintf("Creating %s", path.Dir(file)), "mkdir", "-p", path.Dir(file)); err != nil {
+		return err
+	}
+	return sshRunner.CopyDataPrivileged(data, file, mode)
+}
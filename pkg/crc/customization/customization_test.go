@@ -0,0 +1,21 @@
+package customization
+
+import (
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/customization/butane"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKernelArgumentsApplied(t *testing.T) {
+	cmdline := "BOOT_IMAGE=(hd0,gpt3)/vmlinuz root=UUID=1234 rw mitigations=auto,nosmt quiet\n"
+	for kargs, expected := range map[*butane.KernelArguments]bool{
+		{ShouldExist: []string{"quiet"}}:                                     true,
+		{ShouldExist: []string{"quiet", "nosmt"}}:                            false,
+		{ShouldNotExist: []string{"mitigations=auto"}}:                       true,
+		{ShouldNotExist: []string{"mitigations=auto,nosmt"}}:                 false,
+		{ShouldExist: []string{"rw"}, ShouldNotExist: []string{"selinux=0"}}: true,
+	} {
+		assert.Equal(t, expected, kernelArgumentsApplied(cmdline, *kargs), "%+v", *kargs)
+	}
+}
@@ -0,0 +1,125 @@
+package diagnose
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	crcErrors "github.com/crc-org/crc/v2/pkg/crc/errors"
+)
+
+const (
+	archiveDir = "crc-diagnostics"
+	errorsFile = "errors.txt"
+)
+
+type archiveFile struct {
+	name string
+	data []byte
+}
+
+// Archive holds the collected diagnostics files, and the errors which
+// prevented some of them from being collected. Secrets are masked in
+// everything which is added.
+type Archive struct {
+	files   []archiveFile
+	errors  []string
+	secrets []string
+}
+
+func NewArchive(secrets ...string) *Archive {
+	var nonEmpty []string
+	for _, secret := range secrets {
+		if secret != "" {
+			nonEmpty = append(nonEmpty, secret)
+		}
+	}
+	return &Archive{
+		secrets: nonEmpty,
+	}
+}
+
+func (a *Archive) mask(text string) string {
+	for _, secret := range a.secrets {
+		text = (&crcErrors.MaskedSecretError{Err: errors.New(text), Secret: secret}).Error()
+	}
+	return text
+}
+
+// Add adds a file to the archive
+func (a *Archive) Add(name string, data []byte) {
+	a.files = append(a.files, archiveFile{
+		name: name,
+		data: []byte(a.mask(string(data))),
+	})
+}
+
+// AddJSON adds a file with the indented JSON representation of value
+func (a *Archive) AddJSON(name string, value interface{}) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		a.AddError(name, err)
+		return
+	}
+	a.Add(name, append(data, '\n'))
+}
+
+// AddHostFile adds a copy of a file of the host
+func (a *Archive) AddHostFile(name, hostPath string) {
+	data, err := os.ReadFile(hostPath)
+	if err != nil {
+		a.AddError(name, err)
+		return
+	}
+	a.Add(name, data)
+}
+
+// AddError records that name could not be collected
+func (a *Archive) AddError(name string, err error) {
+	a.errors = append(a.errors, a.mask(fmt.Sprintf("%s: %v", name, err)))
+}
+
+// Errors returns the files which could not be collected, and why
+func (a *Archive) Errors() []string {
+	return a.errors
+}
+
+// Write writes the archive as a gzipped tarball
+func (a *Archive) Write(writer io.Writer) error {
+	gzipWriter := gzip.NewWriter(writer)
+	tarWriter := tar.NewWriter(gzipWriter)
+	modTime := time.Now()
+
+	files := a.files
+	if len(a.errors) != 0 {
+		files = append(files, archiveFile{
+			name: errorsFile,
+			data: []byte(strings.Join(a.errors, "\n") + "\n"),
+		})
+	}
+	for _, file := range files {
+		header := &tar.Header{
+			Name:    path.Join(archiveDir, file.name),
+			Mode:    0600,
+			Size:    int64(len(file.data)),
+			ModTime: modTime,
+		}
+		if err := tarWriter.WriteHeader(header); err != nil {
+			return err
+		}
+		if _, err := tarWriter.Write(file.data); err != nil {
+			return err
+		}
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	return gzipWriter.Close()
+}
@@ -0,0 +1,85 @@
+package diagnose
+
+import (
+	"fmt"
+	"net/url"
+	"runtime"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/bundle"
+	"github.com/crc-org/crc/v2/pkg/crc/preflight"
+	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/crc-org/crc/v2/pkg/crc/version"
+	"github.com/spf13/cast"
+	"golang.org/x/net/http/httpproxy"
+)
+
+const mask = "*****"
+
+// Secrets returns the values which must not appear in the archive: the
+// secret settings, the kubeadmin password and the proxy passwords
+func Secrets(cfg *crcConfig.Config) []string {
+	var secrets []string
+	for _, value := range cfg.AllConfigs() {
+		if value.IsSecret {
+			secrets = append(secrets, cast.ToString(value.Value))
+		}
+	}
+	secrets = append(secrets, cfg.Get(crcConfig.KubeAdminPassword).AsString())
+	if password, err := cluster.GetKubeadminPassword(); err == nil {
+		secrets = append(secrets, password)
+	}
+	envProxy := httpproxy.FromEnvironment()
+	for _, proxy := range []string{cfg.Get(crcConfig.HTTPProxy).AsString(), cfg.Get(crcConfig.HTTPSProxy).AsString(), envProxy.HTTPProxy, envProxy.HTTPSProxy} {
+		if proxyURL, err := url.Parse(proxy); err == nil && proxyURL.User != nil {
+			if password, ok := proxyURL.User.Password(); ok {
+				secrets = append(secrets, password)
+			}
+		}
+	}
+	return secrets
+}
+
+// CollectHost adds the crc logs, the configuration, the metadata of the
+// bundle and the results of the preflight checks
+func CollectHost(archive *Archive, cfg *crcConfig.Config, bundleName string) {
+	archive.Add("host/version.txt", []byte(fmt.Sprintf("CRC version: %s+%s\nOpenShift version: %s\nMicroShift version: %s\nOS: %s/%s\n",
+		version.GetCRCVersion(), version.GetCommitSha(),
+		version.GetBundleVersion(crcPreset.OpenShift), version.GetBundleVersion(crcPreset.Microshift),
+		runtime.GOOS, runtime.GOARCH)))
+
+	archive.AddHostFile("host/crc.log", constants.LogFilePath)
+	archive.AddHostFile("host/crcd.log", constants.DaemonLogFilePath)
+
+	archive.AddJSON("host/config.json", configValues(cfg))
+
+	if bundleName == "" {
+		bundleName = bundle.GetBundleNameWithoutExtension(bundle.GetBundleNameFromURI(cfg.Get(crcConfig.Bundle).AsString()))
+	}
+	if metadata, err := bundle.Get(bundleName); err != nil {
+		archive.AddError("host/bundle.json", err)
+	} else {
+		archive.AddJSON("host/bundle.json", metadata)
+	}
+
+	archive.AddJSON("host/preflight.json", preflight.RunChecks(cfg))
+}
+
+// configValues returns the settings which are not set to their default
+// value, secrets and the kubeadmin password are masked
+func configValues(cfg *crcConfig.Config) map[string]interface{} {
+	values := map[string]interface{}{}
+	for key, value := range cfg.AllConfigs() {
+		if value.IsDefault {
+			continue
+		}
+		if value.IsSecret || key == crcConfig.KubeAdminPassword {
+			values[key] = mask
+			continue
+		}
+		values[key] = value.Value
+	}
+	return values
+}
@@ -0,0 +1,76 @@
+package diagnose
+
+import (
+	"fmt"
+
+	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	crcos "github.com/crc-org/crc/v2/pkg/os"
+)
+
+const (
+	journalLines = "5000"
+	ocTimeout    = "30s"
+
+	openShiftKubeconfig  = "/opt/kubeconfig"
+	microShiftKubeconfig = "/var/lib/microshift/resources/kubeadmin/kubeconfig"
+)
+
+// vmCommand is a command run in the VM whose output is added to the archive
+type vmCommand struct {
+	file string
+	args []string
+}
+
+func journal(file string, units ...string) vmCommand {
+	args := []string{"sudo", "journalctl", "--no-pager", "--boot", "--lines", journalLines}
+	for _, unit := range units {
+		args = append(args, "--unit", unit)
+	}
+	return vmCommand{file: file, args: args}
+}
+
+func oc(preset crcPreset.Preset, file string, args ...string) vmCommand {
+	command := []string{"timeout", ocTimeout, "oc", "--kubeconfig", openShiftKubeconfig}
+	if preset == crcPreset.Microshift {
+		command = []string{"sudo", "timeout", ocTimeout, "oc", "--kubeconfig", microShiftKubeconfig}
+	}
+	return vmCommand{file: file, args: append(command, args...)}
+}
+
+func vmCommands(preset crcPreset.Preset) []vmCommand {
+	kubeletUnit := "kubelet"
+	if preset == crcPreset.Microshift {
+		kubeletUnit = "microshift"
+	}
+	commands := []vmCommand{
+		journal("vm/journal.log"),
+		journal(fmt.Sprintf("vm/%s.log", kubeletUnit), kubeletUnit),
+		journal("vm/crio.log", "crio"),
+		{file: "vm/crictl-ps.txt", args: []string{"sudo", "crictl", "ps", "--all"}},
+		{file: "vm/disk-usage.txt", args: []string{"df", "-h"}},
+	}
+	if preset != crcPreset.Microshift {
+		commands = append(commands, oc(preset, "vm/clusteroperators.txt", "get", "clusteroperators"))
+	}
+	return append(commands,
+		oc(preset, "vm/nodes.txt", "get", "nodes", "--output", "wide"),
+		oc(preset, "vm/events.txt", "get", "events", "--all-namespaces", "--sort-by", ".lastTimestamp"),
+	)
+}
+
+// CollectVM adds the journal, the kubelet and cri-o logs, the containers,
+// the disk usage, and the state of the cluster. The commands are run
+// independently, so that the logs are collected when the API server is down.
+func CollectVM(archive *Archive, runner crcos.CommandRunner, preset crcPreset.Preset) {
+	for _, command := range vmCommands(preset) {
+		// RunPrivate does not copy the output to the logs
+		stdout, stderr, err := runner.RunPrivate(command.args[0], command.args[1:]...)
+		if err != nil {
+			archive.AddError(command.file, fmt.Errorf("%v %s", err, stderr))
+			if stdout == "" {
+				continue
+			}
+		}
+		archive.Add(command.file, []byte(stdout))
+	}
+}
@@ -2,19 +2,27 @@ package gpg
 
 import (
 	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/sha512"
 	"fmt"
+	"hash"
 	"io"
 	"os"
 	"strings"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	goOpenpgp "golang.org/x/crypto/openpgp"             //nolint
 	goClearsign "golang.org/x/crypto/openpgp/clearsign" //nolint
 )
 
-func Verify(filePath, signatureFilePath string) error {
+// Verify checks the armored detached signature of filePath. The signature
+// must have been made with the crc-org key, or with one of extraKeys
+func Verify(filePath, signatureFilePath string, extraKeys ...string) error {
 	data, err := os.Open(filePath)
 	if err != nil {
 		return err
@@ -27,9 +35,9 @@ func Verify(filePath, signatureFilePath string) error {
 	}
 	defer signature.Close()
 
-	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewBufferString(constants.CrcOrgPublicKey))
+	keyring, err := keyRing(extraKeys)
 	if err != nil {
-		return fmt.Errorf("failed to parse public key: %s", err)
+		return err
 	}
 
 	if _, err = openpgp.CheckArmoredDetachedSignature(keyring, data, signature, nil); err != nil {
@@ -38,6 +46,122 @@ func Verify(filePath, signatureFilePath string) error {
 	return nil
 }
 
+// keyRing returns a keyring with the crc-org key and the armored keys from extraKeys
+func keyRing(extraKeys []string) (openpgp.EntityList, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewBufferString(constants.CrcOrgPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %s", err)
+	}
+	for _, key := range extraKeys {
+		entities, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse public key: %s", err)
+		}
+		keyring = append(keyring, entities...)
+	}
+	return keyring, nil
+}
+
+// ReadPublicKeyFile returns the content of an armored public key file after
+// checking it contains at least one key
+func ReadPublicKeyFile(path string) (string, error) {
+	key, err := os.ReadFile(path) // #nosec G304
+	if err != nil {
+		return "", err
+	}
+	entities, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(key))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse public key %s: %s", path, err)
+	}
+	if len(entities) == 0 {
+		return "", fmt.Errorf("no public key found in %s", path)
+	}
+	return string(key), nil
+}
+
+// Dearmor returns the binary form of the armored keys, concatenated
+func Dearmor(keys []string) ([]byte, error) {
+	var buf bytes.Buffer
+	for _, key := range keys {
+		block, err := armor.Decode(strings.NewReader(key))
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode public key: %s", err)
+		}
+		if _, err := io.Copy(&buf, block.Body); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// StreamVerifier checks a detached signature of the data written to it.
+// Unlike Verify, the signature is only needed after all the data has been
+// written, which is useful when the signed data is processed on the fly
+// and the signature is stored after it.
+type StreamVerifier struct {
+	hashes    map[crypto.Hash]hash.Hash
+	writer    io.Writer
+	extraKeys []string
+}
+
+// NewStreamVerifier creates a verifier accepting signatures made with the
+// crc-org key or with one of extraKeys
+func NewStreamVerifier(extraKeys ...string) *StreamVerifier {
+	verifier := &StreamVerifier{
+		extraKeys: extraKeys,
+		hashes: map[crypto.Hash]hash.Hash{
+			crypto.SHA256: sha256.New(),
+			crypto.SHA512: sha512.New(),
+		},
+	}
+	var writers []io.Writer
+	for _, h := range verifier.hashes {
+		writers = append(writers, h)
+	}
+	verifier.writer = io.MultiWriter(writers...)
+	return verifier
+}
+
+func (verifier *StreamVerifier) Write(p []byte) (int, error) {
+	return verifier.writer.Write(p)
+}
+
+// Verify checks the armored detached signature against the data written so far
+func (verifier *StreamVerifier) Verify(armoredSignature io.Reader) error {
+	block, err := armor.Decode(armoredSignature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %s", err)
+	}
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %s", err)
+	}
+	sig, ok := p.(*packet.Signature)
+	if !ok {
+		return fmt.Errorf("failed to check signature: unexpected packet type %T", p)
+	}
+	h, ok := verifier.hashes[sig.Hash]
+	if !ok {
+		return fmt.Errorf("failed to check signature: unsupported hash algorithm %s", sig.Hash)
+	}
+	if sig.IssuerKeyId == nil {
+		return fmt.Errorf("failed to check signature: signature has no issuer key ID")
+	}
+
+	keyring, err := keyRing(verifier.extraKeys)
+	if err != nil {
+		return err
+	}
+	keys := keyring.KeysByIdUsage(*sig.IssuerKeyId, packet.KeyFlagSign)
+	if len(keys) == 0 {
+		return fmt.Errorf("failed to check signature: signed by unknown key %X", *sig.IssuerKeyId)
+	}
+	if err := keys[0].PublicKey.VerifySignature(h, sig); err != nil {
+		return fmt.Errorf("failed to check signature: %s", err)
+	}
+	return nil
+}
+
 func GetVerifiedClearsignedMsgV3(pubkey, clearSignedMsg string) (string, error) {
 	k, err := goOpenpgp.ReadArmoredKeyRing(bytes.NewBufferString(pubkey))
 	if err != nil {
@@ -1,10 +1,18 @@
 package gpg
 
 import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 const (
@@ -43,3 +51,78 @@ func TestVerify(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedMsg, msg)
 }
+
+func TestStreamVerifierUnknownKey(t *testing.T) {
+	const data = "crc bundle content"
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	var signature bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&signature, entity, strings.NewReader(data), nil))
+
+	verifier := NewStreamVerifier()
+	_, err = io.Copy(verifier, strings.NewReader(data))
+	require.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(&signature), "signed by unknown key")
+}
+
+func newTestKey(t *testing.T) (*openpgp.Entity, string) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+	var publicKey bytes.Buffer
+	w, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	return entity, publicKey.String()
+}
+
+func TestStreamVerifierTrustedKey(t *testing.T) {
+	const data = "crc bundle content"
+	entity, publicKey := newTestKey(t)
+	var signature bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&signature, entity, strings.NewReader(data), nil))
+
+	verifier := NewStreamVerifier(publicKey)
+	_, err := io.Copy(verifier, strings.NewReader(data))
+	require.NoError(t, err)
+	assert.NoError(t, verifier.Verify(bytes.NewReader(signature.Bytes())))
+
+	verifier = NewStreamVerifier(publicKey)
+	_, err = io.Copy(verifier, strings.NewReader("tampered content"))
+	require.NoError(t, err)
+	assert.ErrorContains(t, verifier.Verify(bytes.NewReader(signature.Bytes())), "failed to check signature")
+}
+
+func TestVerifyTrustedKey(t *testing.T) {
+	dir := t.TempDir()
+	dataFile := filepath.Join(dir, "test.crcbundle")
+	require.NoError(t, os.WriteFile(dataFile, []byte("crc bundle content"), 0600))
+
+	entity, publicKey := newTestKey(t)
+	var signature bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&signature, entity, strings.NewReader("crc bundle content"), nil))
+	signatureFile := dataFile + ".sig"
+	require.NoError(t, os.WriteFile(signatureFile, signature.Bytes(), 0600))
+
+	assert.Error(t, Verify(dataFile, signatureFile))
+	assert.NoError(t, Verify(dataFile, signatureFile, publicKey))
+}
+
+func TestReadPublicKeyFile(t *testing.T) {
+	dir := t.TempDir()
+	_, publicKey := newTestKey(t)
+	keyFile := filepath.Join(dir, "key.asc")
+	require.NoError(t, os.WriteFile(keyFile, []byte(publicKey), 0600))
+	key, err := ReadPublicKeyFile(keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, publicKey, key)
+
+	invalidKeyFile := filepath.Join(dir, "invalid.asc")
+	require.NoError(t, os.WriteFile(invalidKeyFile, []byte("not a key"), 0600))
+	_, err = ReadPublicKeyFile(invalidKeyFile)
+	assert.ErrorContains(t, err, "failed to parse public key")
+
+	binaryKey, err := Dearmor([]string{publicKey})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, binaryKey)
+}
@@ -1,30 +1,35 @@
 package image
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/url"
-	"os"
 	"path"
-	"path/filepath"
 	"strings"
 
-	"github.com/containers/image/v5/copy"
-	"github.com/containers/image/v5/directory"
 	"github.com/containers/image/v5/docker"
+	"github.com/containers/image/v5/manifest"
+	"github.com/containers/image/v5/pkg/blobinfocache/none"
 	"github.com/containers/image/v5/signature"
+	"github.com/containers/image/v5/types"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	"github.com/crc-org/crc/v2/pkg/crc/gpg"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	crcpreset "github.com/crc-org/crc/v2/pkg/crc/preset"
-	"github.com/crc-org/crc/v2/pkg/extract"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 type imageHandler struct {
 	imageURI string
+	// trustedKeys are armored public keys trusted to sign bundles, in addition to the crc-org key
+	trustedKeys []string
+	// requireSignature makes the registry signature of custom bundle images mandatory
+	requireSignature bool
 }
 
 func ValidateURI(uri *url.URL) error {
@@ -44,6 +49,24 @@ func ValidateURI(uri *url.URL) error {
 
 func (img *imageHandler) policyContext() (*signature.PolicyContext, error) {
 	policy := &signature.Policy{Default: []signature.PolicyRequirement{signature.NewPRInsecureAcceptAnything()}}
+	if img.requireSignature && len(img.trustedKeys) > 0 {
+		keyData, err := gpg.Dearmor(img.trustedKeys)
+		if err != nil {
+			return nil, err
+		}
+		signedBy, err := signature.NewPRSignedByKeyData(signature.SBKeyTypeGPGKeys, keyData, signature.NewPRMMatchRepoDigestOrExact())
+		if err != nil {
+			return nil, fmt.Errorf("error creating signature policy: %w", err)
+		}
+		policy.Default = []signature.PolicyRequirement{signedBy}
+		// Official bundle images are not signed in the registry, the
+		// bundle they contain is checked against the crc-org key instead
+		policy.Transports = map[string]signature.PolicyTransportScopes{
+			docker.Transport.Name(): {
+				constants.RegistryURI: {signature.NewPRInsecureAcceptAnything()},
+			},
+		}
+	}
 	policyContext, err := signature.NewPolicyContext(policy)
 	if err != nil {
 		return nil, fmt.Errorf("error creating security context: %w", err)
@@ -52,39 +75,6 @@ func (img *imageHandler) policyContext() (*signature.PolicyContext, error) {
 	return policyContext, nil
 }
 
-// copyImage pulls the image from the registry and puts it to destination path
-func (img *imageHandler) copyImage(destPath string, reportWriter io.Writer) (*v1.Manifest, error) {
-	// Source Image from docker transport
-	srcImg := img.imageURI
-	srcRef, err := docker.ParseReference(srcImg)
-	if err != nil {
-		return nil, fmt.Errorf("invalid source image name %s: %w", srcImg, err)
-	}
-
-	destRef, err := directory.Transport.ParseReference(destPath)
-	if err != nil {
-		return nil, fmt.Errorf("invalid destination name %s: %w", destPath, err)
-	}
-
-	policyContext, err := img.policyContext()
-	if err != nil {
-		return nil, err
-	}
-
-	manifestData, err := copy.Image(context.Background(), policyContext,
-		destRef, srcRef, &copy.Options{
-			ReportWriter: reportWriter,
-		})
-	if err != nil {
-		return nil, err
-	}
-	manifest := &v1.Manifest{}
-	if err := json.Unmarshal(manifestData, manifest); err != nil {
-		return nil, err
-	}
-	return manifest, nil
-}
-
 func getLayerPath(m *v1.Manifest, index int, mediaType string) (string, error) {
 	if len(m.Layers) < (index + 1) {
 		return "", fmt.Errorf("image layers in manifest is less than %d", index+1)
@@ -113,43 +103,164 @@ func GetPresetName(imageName string) crcpreset.Preset {
 	return preset
 }
 
-func PullBundle(imageURI string) (string, error) {
-	imgHandler := imageHandler{
-		imageURI: strings.TrimPrefix(imageURI, "docker:"),
+// unparsedImage is the minimal types.UnparsedImage implementation needed to
+// evaluate the signature policy of an image which is not copied with copy.Image
+type unparsedImage struct {
+	src types.ImageSource
+}
+
+func (img *unparsedImage) Reference() types.ImageReference {
+	return img.src.Reference()
+}
+
+func (img *unparsedImage) Manifest(ctx context.Context) ([]byte, string, error) {
+	return img.src.GetManifest(ctx, nil)
+}
+
+func (img *unparsedImage) Signatures(ctx context.Context) ([][]byte, error) {
+	return img.src.GetSignatures(ctx, nil)
+}
+
+// bundleLayer returns the descriptor of the layer containing the bundle and its signature
+func (img *imageHandler) bundleLayer(ctx context.Context, src types.ImageSource) (*v1.Descriptor, error) {
+	manifestData, mimeType, err := src.GetManifest(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.MIMETypeIsMultiImage(mimeType) {
+		list, err := manifest.ListFromBlob(manifestData, mimeType)
+		if err != nil {
+			return nil, err
+		}
+		instance, err := list.ChooseInstance(nil)
+		if err != nil {
+			return nil, err
+		}
+		if manifestData, _, err = src.GetManifest(ctx, &instance); err != nil {
+			return nil, err
+		}
+	}
+	imgManifest := &v1.Manifest{}
+	if err := json.Unmarshal(manifestData, imgManifest); err != nil {
+		return nil, err
 	}
-	destDir, err := os.MkdirTemp(constants.MachineCacheDir, "tmpBundleImage")
+	if _, err := getLayerPath(imgManifest, 0, "application/vnd.oci.image.layer.v1.tar+gzip"); err != nil {
+		return nil, err
+	}
+	return &imgManifest.Layers[0], nil
+}
+
+// streamImage reads the bundle layer from the registry without storing it
+// on disk. The bundle file found in the layer is passed to extractBundle
+// while it's being downloaded. The layer digest and the bundle signature are
+// checked once the whole layer has been read.
+func (img *imageHandler) streamImage(extractBundle func(bundleName string, reader io.Reader) error) error {
+	ctx := context.Background()
+	srcRef, err := docker.ParseReference(img.imageURI)
 	if err != nil {
-		return "", err
+		return fmt.Errorf("invalid source image name %s: %w", img.imageURI, err)
 	}
-	defer os.RemoveAll(destDir)
-	imgManifest, err := imgHandler.copyImage(destDir, os.Stdout)
+	src, err := srcRef.NewImageSource(ctx, nil)
 	if err != nil {
-		return "", err
+		return err
 	}
+	defer src.Close()
 
-	logging.Info("Extracting the image bundle layer...")
-	imgLayer, err := getLayerPath(imgManifest, 0, "application/vnd.oci.image.layer.v1.tar+gzip")
+	policyContext, err := img.policyContext()
 	if err != nil {
-		return "", err
+		return err
+	}
+	defer func() {
+		_ = policyContext.Destroy()
+	}()
+	if _, err := policyContext.IsRunningImageAllowed(ctx, &unparsedImage{src: src}); err != nil {
+		return err
 	}
-	fileList, err := extract.Uncompress(filepath.Join(destDir, imgLayer), constants.MachineCacheDir)
+
+	layer, err := img.bundleLayer(ctx, src)
+	if err != nil {
+		return err
+	}
+	blob, _, err := src.GetBlob(ctx, types.BlobInfo{Digest: layer.Digest, Size: layer.Size}, none.NoCache)
 	if err != nil {
-		return "", err
+		return err
 	}
-	logging.Debugf("Bundle and sign path: %v", fileList)
+	defer blob.Close()
 
-	logging.Info("Verifying the bundle signature...")
-	if len(fileList) != 2 {
-		return "", fmt.Errorf("image layer contains more files than expected: %v", fileList)
+	digestVerifier := layer.Digest.Verifier()
+	layerReader := io.TeeReader(blob, digestVerifier)
+	gzipReader, err := gzip.NewReader(layerReader)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	var (
+		bundleName string
+		signature  []byte
+	)
+	signatureVerifier := gpg.NewStreamVerifier(img.trustedKeys...)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Base(header.Name)
+		switch {
+		case strings.HasSuffix(name, ".crcbundle.sig"):
+			if signature, err = io.ReadAll(tarReader); err != nil {
+				return err
+			}
+		case strings.HasSuffix(name, ".crcbundle"):
+			if bundleName != "" {
+				return fmt.Errorf("image layer contains more than one bundle: %s, %s", bundleName, name)
+			}
+			bundleName = name
+			logging.Infof("Extracting %s...", bundleName)
+			if err := extractBundle(bundleName, io.TeeReader(tarReader, signatureVerifier)); err != nil {
+				return err
+			}
+			// the archive may be padded after its end marker, it's part of the signed data
+			if _, err := io.Copy(signatureVerifier, tarReader); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected file in image layer: %s", header.Name)
+		}
+	}
+	if _, err := io.Copy(io.Discard, layerReader); err != nil {
+		return err
 	}
-	bundleFilePath, sigFilePath := fileList[0], fileList[1]
-	if !strings.HasSuffix(sigFilePath, ".crcbundle.sig") {
-		sigFilePath, bundleFilePath = fileList[0], fileList[1]
+	if !digestVerifier.Verified() {
+		return fmt.Errorf("image layer digest mismatch, expected %s", layer.Digest)
 	}
 
-	if err := gpg.Verify(bundleFilePath, sigFilePath); err != nil {
-		return "", err
+	if bundleName == "" || signature == nil {
+		return fmt.Errorf("image layer must contain a bundle and its signature")
 	}
+	logging.Info("Verifying the bundle signature...")
+	return signatureVerifier.Verify(bytes.NewReader(signature))
+}
 
-	return bundleFilePath, nil
+// StreamBundle downloads the bundle image and passes the bundle it contains to
+// extractBundle, neither the image nor the bundle are stored on disk. An
+// error is returned if the bundle signature is invalid, even if extractBundle
+// was successful. The bundle signature must have been made with the crc-org
+// key or one of trustedKeys. When requireSignature is true and trustedKeys is
+// not empty, custom images must also be signed in the registry with one of
+// trustedKeys.
+func StreamBundle(imageURI string, trustedKeys []string, requireSignature bool, extractBundle func(bundleName string, reader io.Reader) error) error {
+	imgHandler := imageHandler{
+		imageURI:         strings.TrimPrefix(imageURI, "docker:"),
+		trustedKeys:      trustedKeys,
+		requireSignature: requireSignature,
+	}
+	return imgHandler.streamImage(extractBundle)
 }
@@ -0,0 +1,36 @@
+package bundle
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/crc-org/crc/v2/pkg/compress"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+)
+
+const (
+	compressionLevelEnv   = "CRC_BUNDLE_COMPRESSION_LEVEL"
+	compressionThreadsEnv = "CRC_BUNDLE_COMPRESSION_THREADS"
+)
+
+// compressOptions returns the zstd level and concurrency set in the
+// environment, the invalid values are ignored
+func compressOptions() compress.Options {
+	return compress.Options{
+		Level:       intFromEnv(compressionLevelEnv, 1, 22),
+		Concurrency: intFromEnv(compressionThreadsEnv, 1, 1024),
+	}
+}
+
+func intFromEnv(name string, minValue, maxValue int) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	i, err := strconv.Atoi(value)
+	if err != nil || i < minValue || i > maxValue {
+		logging.Warnf("Ignoring %s=%s, it must be between %d and %d", name, value, minValue, maxValue)
+		return 0
+	}
+	return i
+}
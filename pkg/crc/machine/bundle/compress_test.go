@@ -0,0 +1,20 @@
+package bundle
+
+import (
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/compress"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressOptions(t *testing.T) {
+	assert.Equal(t, compress.Options{}, compressOptions())
+
+	t.Setenv(compressionLevelEnv, "19")
+	t.Setenv(compressionThreadsEnv, "4")
+	assert.Equal(t, compress.Options{Level: 19, Concurrency: 4}, compressOptions())
+
+	t.Setenv(compressionLevelEnv, "23")
+	t.Setenv(compressionThreadsEnv, "many")
+	assert.Equal(t, compress.Options{}, compressOptions())
+}
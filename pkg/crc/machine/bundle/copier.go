@@ -121,7 +121,7 @@ func (copier *Copier) GenerateBundle(bundleName string) error {
 	}
 
 	logging.Infof("Compressing %s...", GetBundleNameWithoutExtension(copier.copiedBundle.Name))
-	return compress.Compress(copier.copiedBundle.cachedPath, fmt.Sprintf("%s%s", bundleName, bundleExtension))
+	return compress.CompressWithOptions(copier.copiedBundle.cachedPath, fmt.Sprintf("%s%s", bundleName, bundleExtension), compressOptions())
 }
 
 func sha256sum(path string) (string, error) {
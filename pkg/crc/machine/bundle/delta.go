@@ -0,0 +1,216 @@
+package bundle
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crc-org/crc/v2/pkg/compress"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/delta"
+	crcos "github.com/crc-org/crc/v2/pkg/os"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/docker/go-units"
+)
+
+const deltaExtension = ".crcdelta"
+
+// GenerateDelta creates in outputDir a bundle with the same content as the
+// cached targetBundle, except that its disk image is replaced with a binary
+// diff against the disk image of the cached baseBundle. It returns the path
+// of the generated bundle.
+func (repo *Repository) GenerateDelta(baseBundle, targetBundle, outputDir string) (string, error) {
+	base, err := repo.Get(baseBundle)
+	if err != nil {
+		return "", err
+	}
+	target, err := repo.Get(targetBundle)
+	if err != nil {
+		return "", err
+	}
+	if base.GetBundleType() != target.GetBundleType() || base.DriverInfo.Name != target.DriverInfo.Name {
+		return "", fmt.Errorf("cannot generate a delta between bundles %s and %s which have a different type or driver", base.GetBundleName(), target.GetBundleName())
+	}
+
+	tmpBaseDir, err := os.MkdirTemp(repo.CacheDir, "crc_delta_bundle")
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(tmpBaseDir)
+
+	bundleBaseDir := filepath.Base(target.cachedPath)
+	deltaBundleDir := filepath.Join(tmpBaseDir, bundleBaseDir)
+	if err := os.Mkdir(deltaBundleDir, 0775); err != nil {
+		return "", err
+	}
+
+	diskImageName := target.Storage.DiskImages[0].Name
+	if err := copyBundleFiles(target.cachedPath, deltaBundleDir, diskImageName, metadataFilename); err != nil {
+		return "", err
+	}
+
+	logging.Infof("Computing the delta between the disk images of %s and %s...", base.GetBundleName(), target.GetBundleName())
+	deltaPath := filepath.Join(deltaBundleDir, diskImageName+deltaExtension)
+	if err := diffDiskImages(base.GetDiskImagePath(), target.GetDiskImagePath(), deltaPath); err != nil {
+		return "", err
+	}
+	deltaInfo, err := getFileInfo(deltaPath)
+	if err != nil {
+		return "", err
+	}
+
+	deltaBundle := *target
+	deltaBundle.Delta = &Delta{
+		BaseBundle:    filepath.Base(base.cachedPath),
+		BaseDiskImage: base.Storage.DiskImages[0].File,
+		DiskImage:     *deltaInfo,
+	}
+	if err := writeMetadata(deltaBundleDir, &deltaBundle); err != nil {
+		return "", err
+	}
+
+	bundlePath := filepath.Join(outputDir, GetBundleNameWithExtension(bundleBaseDir))
+	logging.Infof("Compressing %s...", bundleBaseDir)
+	if err := compress.CompressWithOptions(deltaBundleDir, bundlePath, compressOptions()); err != nil {
+		return "", err
+	}
+	return bundlePath, nil
+}
+
+func copyBundleFiles(srcDir, destDir string, excludedFiles ...string) error {
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || crcstrings.Contains(excludedFiles, entry.Name()) {
+			continue
+		}
+		fi, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		if err := crcos.CopyFileContents(filepath.Join(srcDir, entry.Name()), filepath.Join(destDir, entry.Name()), fi.Mode().Perm()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func diffDiskImages(basePath, targetPath, deltaPath string) error {
+	base, err := os.Open(basePath)
+	if err != nil {
+		return err
+	}
+	defer base.Close()
+	target, err := os.Open(targetPath)
+	if err != nil {
+		return err
+	}
+	defer target.Close()
+	out, err := os.Create(deltaPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	stats, err := delta.Diff(base, target, out)
+	if err != nil {
+		return err
+	}
+	logging.Infof("Disk image delta: %s unchanged, %s changed, %s of zeroes",
+		units.HumanSize(float64(stats.Copied)),
+		units.HumanSize(float64(stats.Data)),
+		units.HumanSize(float64(stats.Zero)))
+	return out.Close()
+}
+
+func writeMetadata(bundleDir string, bundleInfo *CrcBundleInfo) error {
+	bundleContent, err := json.MarshalIndent(bundleInfo, "", " ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(bundleDir, metadataFilename), bundleContent, 0600); err != nil {
+		return fmt.Errorf("error writing bundle metadata %w", err)
+	}
+	return nil
+}
+
+// applyDelta reconstructs the disk image of the delta bundle extracted in
+// bundleDir from the disk image of its base bundle. This is a no-op for
+// bundles which are not delta bundles.
+func (repo *Repository) applyDelta(bundleDir string) error {
+	content, err := os.ReadFile(filepath.Join(bundleDir, metadataFilename))
+	if err != nil {
+		return err
+	}
+	var bundleInfo CrcBundleInfo
+	if err := json.Unmarshal(content, &bundleInfo); err != nil {
+		return fmt.Errorf("error Unmarshal the data: %w", err)
+	}
+	if bundleInfo.Delta == nil {
+		return nil
+	}
+	bundleInfo.cachedPath = bundleDir
+
+	deltaInfo := bundleInfo.Delta
+	base, err := repo.Get(deltaInfo.BaseBundle)
+	if err != nil {
+		return fmt.Errorf("%s is a delta bundle, its base bundle %s must be in the cache: %w", bundleInfo.GetBundleName(), deltaInfo.BaseBundle, err)
+	}
+	if base.Storage.DiskImages[0].Checksum != deltaInfo.BaseDiskImage.Checksum {
+		return fmt.Errorf("the disk image of %s does not match the base disk image of delta bundle %s", deltaInfo.BaseBundle, bundleInfo.GetBundleName())
+	}
+
+	logging.Infof("Reconstructing the disk image of %s from %s...", bundleInfo.GetBundleName(), deltaInfo.BaseBundle)
+	deltaPath := bundleInfo.resolvePath(deltaInfo.DiskImage.Name)
+	diskImage := bundleInfo.Storage.DiskImages[0]
+	sha256sum, err := patchDiskImage(base.GetDiskImagePath(), deltaPath, bundleInfo.GetDiskImagePath())
+	if err != nil {
+		return err
+	}
+	if sha256sum != diskImage.Checksum {
+		return fmt.Errorf("sha256sum mismatch for reconstructed disk image %s: expected %s, got %s", diskImage.Name, diskImage.Checksum, sha256sum)
+	}
+	if err := bundleInfo.checkDiskImageSize(); err != nil {
+		return err
+	}
+	if err := os.Remove(deltaPath); err != nil {
+		return err
+	}
+
+	// the bundle no longer depends on its base bundle
+	bundleInfo.Delta = nil
+	return writeMetadata(bundleDir, &bundleInfo)
+}
+
+func patchDiskImage(basePath, deltaPath, targetPath string) (string, error) {
+	base, err := os.Open(basePath)
+	if err != nil {
+		return "", err
+	}
+	defer base.Close()
+	deltaFile, err := os.Open(deltaPath)
+	if err != nil {
+		return "", err
+	}
+	defer deltaFile.Close()
+	target, err := os.OpenFile(targetPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return "", err
+	}
+	defer target.Close()
+
+	sha256sum, err := delta.Apply(base, deltaFile, target)
+	if err != nil {
+		return "", err
+	}
+	return sha256sum, target.Close()
+}
+
+// GenerateDelta creates a delta bundle of targetBundle against baseBundle in
+// outputDir, see Repository.GenerateDelta
+func GenerateDelta(baseBundle, targetBundle, outputDir string) (string, error) {
+	return defaultRepo.GenerateDelta(baseBundle, targetBundle, outputDir)
+}
@@ -0,0 +1,87 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setDiskImage replaces the disk image of the cached bundle name with content
+func setDiskImage(t *testing.T, repo *Repository, name string, content []byte) {
+	bundleInfo, err := repo.Get(name)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(bundleInfo.GetDiskImagePath(), content, 0600))
+	sum := sha256.Sum256(content)
+	bundleInfo.Storage.DiskImages[0].Size = fmt.Sprintf("%d", len(content))
+	bundleInfo.Storage.DiskImages[0].Checksum = hex.EncodeToString(sum[:])
+	require.NoError(t, writeMetadata(bundleInfo.cachedPath, bundleInfo))
+}
+
+func TestGenerateAndExtractDelta(t *testing.T) {
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+	createDummyBundleContent(t, repo.CacheDir, "crc_libvirt_4.6.15", "1.0")
+	createDummyBundleContent(t, repo.CacheDir, "crc_libvirt_4.6.16", "1.0")
+
+	baseImage := bytes.Repeat([]byte("0123456789abcdef"), 1024*1024)
+	targetImage := append(bytes.Clone(baseImage), []byte("4.6.16")...)
+	setDiskImage(t, repo, "crc_libvirt_4.6.15", baseImage)
+	setDiskImage(t, repo, "crc_libvirt_4.6.16", targetImage)
+
+	outputDir := t.TempDir()
+	deltaPath, err := repo.GenerateDelta("crc_libvirt_4.6.15", "crc_libvirt_4.6.16.crcbundle", outputDir)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(outputDir, "crc_libvirt_4.6.16.crcbundle"), deltaPath)
+	fi, err := os.Stat(deltaPath)
+	require.NoError(t, err)
+	assert.Less(t, fi.Size(), int64(len(targetImage)/10))
+
+	require.NoError(t, os.RemoveAll(filepath.Join(repo.CacheDir, "crc_libvirt_4.6.16")))
+	require.NoError(t, repo.Extract(deltaPath))
+
+	bundle, err := repo.Get("crc_libvirt_4.6.16.crcbundle")
+	require.NoError(t, err)
+	assert.Nil(t, bundle.Delta)
+	diskImage, err := os.ReadFile(bundle.GetDiskImagePath())
+	require.NoError(t, err)
+	assert.Equal(t, targetImage, diskImage)
+	_, err = os.Stat(bundle.GetDiskImagePath() + deltaExtension)
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	content, err := os.ReadFile(filepath.Join(bundle.cachedPath, metadataFilename))
+	require.NoError(t, err)
+	var metadata map[string]interface{}
+	require.NoError(t, json.Unmarshal(content, &metadata))
+	assert.NotContains(t, metadata, "delta")
+}
+
+func TestExtractDeltaWithoutBase(t *testing.T) {
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+	createDummyBundleContent(t, repo.CacheDir, "crc_libvirt_4.6.15", "1.0")
+	createDummyBundleContent(t, repo.CacheDir, "crc_libvirt_4.6.16", "1.0")
+	setDiskImage(t, repo, "crc_libvirt_4.6.16", []byte("crc.qcow2 4.6.16"))
+
+	deltaPath, err := repo.GenerateDelta("crc_libvirt_4.6.15", "crc_libvirt_4.6.16", t.TempDir())
+	require.NoError(t, err)
+
+	otherRepo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+	assert.ErrorContains(t, otherRepo.Extract(deltaPath), "crc_libvirt_4.6.16 is a delta bundle, its base bundle crc_libvirt_4.6.15 must be in the cache")
+	_, err = os.Stat(filepath.Join(otherRepo.CacheDir, "crc_libvirt_4.6.16"))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+
+	setDiskImage(t, repo, "crc_libvirt_4.6.15", []byte("another base image"))
+	assert.ErrorContains(t, repo.Extract(deltaPath), "does not match the base disk image")
+}
@@ -32,6 +32,7 @@ type CrcBundleInfo struct {
 	Nodes       []Node      `json:"nodes"`
 	Storage     Storage     `json:"storage"`
 	DriverInfo  DriverInfo  `json:"driverInfo"`
+	Delta       *Delta      `json:"delta,omitempty"`
 
 	cachedPath string
 }
@@ -100,6 +101,16 @@ type DriverInfo struct {
 	Name string `json:"name"`
 }
 
+// Delta is set for bundles which contain a binary diff of their disk image
+// against the disk image of a base bundle instead of the disk image itself.
+// Storage.DiskImages describes the full disk image, which is reconstructed
+// when the bundle is extracted.
+type Delta struct {
+	BaseBundle    string `json:"baseBundle"`
+	BaseDiskImage File   `json:"baseDiskImage"`
+	DiskImage     File   `json:"diskImage"`
+}
+
 func (bundle *CrcBundleInfo) resolvePath(filename string) string {
 	return filepath.Join(bundle.cachedPath, filename)
 }
@@ -281,8 +292,8 @@ func GetBundleInfoFromName(bundleName string) (*FilenameInfo, error) {
 	return &filenameInfo, nil
 }
 
-func getBundleDownloadInfo(preset crcPreset.Preset) (*download.RemoteFile, error) {
-	sha256sum, err := getDefaultBundleVerifiedHash(preset)
+func getBundleDownloadInfo(preset crcPreset.Preset, trustedKeys ...string) (*download.RemoteFile, error) {
+	sha256sum, err := getDefaultBundleVerifiedHash(preset, trustedKeys...)
 	if err != nil {
 		return nil, fmt.Errorf("unable to get verified hash for default bundle: %w", err)
 	}
@@ -293,11 +304,13 @@ func getBundleDownloadInfo(preset crcPreset.Preset) (*download.RemoteFile, error
 // getDefaultBundleVerifiedHash downloads the sha256sum.txt.sig file from mirror.openshift.com
 // then verifies it is signed by redhat release key, if signature is valid it returns the hash
 // for the default bundle of preset from the file
-func getDefaultBundleVerifiedHash(preset crcPreset.Preset) (string, error) {
-	return getVerifiedHash(constants.GetDefaultBundleSignedHashURL(preset), constants.GetDefaultBundle(preset))
+func getDefaultBundleVerifiedHash(preset crcPreset.Preset, trustedKeys ...string) (string, error) {
+	return getVerifiedHash(constants.GetDefaultBundleSignedHashURL(preset), constants.GetDefaultBundle(preset), trustedKeys...)
 }
 
-func getVerifiedHash(url string, file string) (string, error) {
+// getVerifiedHash returns the sha256sum of file from the signed sha256sum.txt.sig file at url.
+// The file must be signed with the Red Hat release key, or with one of trustedKeys.
+func getVerifiedHash(url string, file string, trustedKeys ...string) (string, error) {
 	res, err := download.InMemory(url)
 	if err != nil {
 		return "", err
@@ -308,7 +321,13 @@ func getVerifiedHash(url string, file string) (string, error) {
 		return "", err
 	}
 
-	verifiedHashes, err := gpg.GetVerifiedClearsignedMsgV3(constants.RedHatReleaseKey, string(signedHashes))
+	var verifiedHashes string
+	for _, key := range append([]string{constants.RedHatReleaseKey}, trustedKeys...) {
+		verifiedHashes, err = gpg.GetVerifiedClearsignedMsgV3(key, string(signedHashes))
+		if err == nil {
+			break
+		}
+	}
 	if err != nil {
 		return "", fmt.Errorf("Invalid signature: %w", err)
 	}
@@ -325,46 +344,6 @@ func getVerifiedHash(url string, file string) (string, error) {
 	return "", fmt.Errorf("%s hash is missing or shasums are malformed", file)
 }
 
-func downloadDefault(preset crcPreset.Preset) (string, error) {
-	downloadInfo, err := getBundleDownloadInfo(preset)
-	if err != nil {
-		return "", err
-	}
-
-	return downloadInfo.Download(constants.GetDefaultBundlePath(preset), 0664)
-}
-
-func Download(preset crcPreset.Preset, bundleURI string, enableBundleQuayFallback bool) (string, error) {
-	// If we are asked to download
-	// ~/.crc/cache/crc_podman_libvirt_4.1.1.crcbundle, this means we want
-	// are downloading the default bundle for this release. This uses a
-	// different codepath from user-specified URIs as for the default
-	// bundles, their sha256sums are known and can be checked.
-	if bundleURI == constants.GetDefaultBundlePath(preset) {
-		switch preset {
-		case crcPreset.OpenShift, crcPreset.Microshift:
-			downloadedBundlePath, err := downloadDefault(preset)
-			if err != nil && enableBundleQuayFallback {
-				logging.Info("Unable to download bundle from mirror, falling back to quay")
-				return image.PullBundle(constants.GetDefaultBundleImageRegistry(preset))
-			}
-			return downloadedBundlePath, err
-		case crcPreset.OKD:
-			fallthrough
-		default:
-			return image.PullBundle(constants.GetDefaultBundleImageRegistry(preset))
-		}
-	}
-	switch {
-	case strings.HasPrefix(bundleURI, "http://"), strings.HasPrefix(bundleURI, "https://"):
-		return download.Download(bundleURI, constants.MachineCacheDir, 0644, nil)
-	case strings.HasPrefix(bundleURI, "docker://"):
-		return image.PullBundle(bundleURI)
-	}
-	// the `bundleURI` parameter turned out to be a local path
-	return bundleURI, nil
-}
-
 type Version struct {
 	CrcVersion       *semver.Version `json:"crcVersion"`
 	GitSha           string          `json:"gitSha"`
@@ -24,6 +24,9 @@ const (
 	supportedVersion = "^1.0"
 	bundleExtension  = ".crcbundle"
 	metadataFilename = "crc-bundle-info.json"
+	// verifiedFilename is created in the bundle directory when the signature
+	// or the sha256sum of the bundle was checked during its extraction
+	verifiedFilename = "crc-bundle-verified"
 )
 
 type Repository struct {
@@ -137,11 +140,20 @@ func (repo *Repository) Extract(path string) error {
 		return err
 	}
 
+	return repo.commit(tmpDir, bundleName)
+}
+
+// commit moves the bundle extracted in stagingDir to its final location in the cache.
+// The disk image of delta bundles is reconstructed before the bundle is moved.
+func (repo *Repository) commit(stagingDir, bundleName string) error {
 	bundleBaseDir := GetBundleNameWithoutExtension(bundleName)
+	if err := repo.applyDelta(filepath.Join(stagingDir, bundleBaseDir)); err != nil {
+		return err
+	}
 	bundleDir := filepath.Join(repo.CacheDir, bundleBaseDir)
 	_ = os.RemoveAll(bundleDir)
 	err := crcerrors.Retry(context.Background(), time.Minute, func() error {
-		if err := os.Rename(filepath.Join(tmpDir, bundleBaseDir), bundleDir); err != nil {
+		if err := os.Rename(filepath.Join(stagingDir, bundleBaseDir), bundleDir); err != nil {
 			return &crcerrors.RetriableError{Err: err}
 		}
 		return nil
@@ -153,6 +165,19 @@ func (repo *Repository) Extract(path string) error {
 	return os.Chmod(bundleDir, 0755)
 }
 
+// markVerified records that the bundle in the cache was verified when it was
+// extracted, the record is removed with the bundle directory
+func (repo *Repository) markVerified(bundleName string) error {
+	return os.WriteFile(filepath.Join(repo.CacheDir, GetBundleNameWithoutExtension(bundleName), verifiedFilename), nil, 0600)
+}
+
+// IsVerified returns true when the signature or the sha256sum of the bundle
+// was checked when it was added to the cache
+func (repo *Repository) IsVerified(bundleName string) bool {
+	_, err := os.Stat(filepath.Join(repo.CacheDir, GetBundleNameWithoutExtension(bundleName), verifiedFilename))
+	return err == nil
+}
+
 func (repo *Repository) List() ([]CrcBundleInfo, error) {
 	files, err := os.ReadDir(repo.CacheDir)
 	if err != nil {
@@ -198,6 +223,10 @@ func Use(bundleName string) (*CrcBundleInfo, error) {
 	return defaultRepo.Use(bundleName)
 }
 
+func IsVerified(bundleName string) bool {
+	return defaultRepo.IsVerified(bundleName)
+}
+
 func Extract(path string) (*CrcBundleInfo, error) {
 	if err := defaultRepo.Extract(path); err != nil {
 		return nil, err
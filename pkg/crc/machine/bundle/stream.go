@@ -0,0 +1,273 @@
+package bundle
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/gpg"
+	"github.com/crc-org/crc/v2/pkg/crc/image"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/crc-org/crc/v2/pkg/download"
+	"github.com/crc-org/crc/v2/pkg/extract"
+)
+
+// The functions in this file download and extract bundles at the same time,
+// without storing the compressed bundle in the cache. The bundle is first
+// extracted to a staging directory, and is only moved to its final location
+// once its sha256sum or signature have been checked.
+
+type DownloadOptions struct {
+	// EnableQuayFallback makes the default bundle to be pulled from quay.io
+	// when it cannot be downloaded from the mirror
+	EnableQuayFallback bool
+	// TrustedKeyFiles are armored public keys which are trusted to sign
+	// bundles, in addition to the Red Hat and crc-org keys
+	TrustedKeyFiles []string
+	// RequireSignature refuses bundles which are not signed
+	RequireSignature bool
+}
+
+func (opts DownloadOptions) trustedKeys() ([]string, error) {
+	var keys []string
+	for _, keyFile := range opts.TrustedKeyFiles {
+		key, err := gpg.ReadPublicKeyFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (repo *Repository) stagingDir(bundleName string) string {
+	return filepath.Join(repo.CacheDir, fmt.Sprintf("tmp-extract-%s", GetBundleNameWithoutExtension(bundleName)))
+}
+
+func (repo *Repository) stage(bundleName string, reader io.Reader) error {
+	stagingDir := repo.stagingDir(bundleName)
+	_ = os.RemoveAll(stagingDir) // clean up before using it
+	_, err := extract.UncompressStream(reader, stagingDir)
+	return err
+}
+
+// ExtractStream extracts the bundle read from reader. verify is called once
+// the extraction is complete, the extracted bundle is discarded if it returns
+// an error.
+func (repo *Repository) ExtractStream(bundleName string, reader io.Reader, verify func() error) error {
+	defer func() {
+		_ = os.RemoveAll(repo.stagingDir(bundleName)) // clean up after using it
+	}()
+	if err := repo.stage(bundleName, reader); err != nil {
+		return err
+	}
+	if err := verify(); err != nil {
+		return err
+	}
+	return repo.commit(repo.stagingDir(bundleName), bundleName)
+}
+
+// lookupSignature looks for a detached signature (bundle.crcbundle.sig) or for a
+// signed sha256sum.txt.sig file next to the bundle at uri. It returns either
+// the armored detached signature, or the verified sha256sum of the bundle.
+func lookupSignature(uri, bundleName string, trustedKeys []string) ([]byte, string, error) {
+	if rsp, err := download.InMemory(uri + ".sig"); err == nil {
+		defer rsp.Close()
+		signature, err := io.ReadAll(rsp)
+		if err == nil {
+			logging.Debugf("Found detached signature %s.sig", uri)
+			return signature, "", nil
+		}
+		logging.Debugf("Failed to read %s.sig: %v", uri, err)
+	}
+	signedHashesURI := strings.TrimSuffix(uri, bundleName) + "sha256sum.txt.sig"
+	sha256sum, err := getVerifiedHash(signedHashesURI, bundleName, trustedKeys...)
+	if err != nil {
+		return nil, "", fmt.Errorf("no valid signature found for %s: %w", bundleName, err)
+	}
+	return nil, sha256sum, nil
+}
+
+// DownloadAndExtract extracts the bundle while it's downloaded from uri.
+// When sha256sum is not empty, the bundle is only added to the cache if the
+// downloaded data matches it. Otherwise its signature is looked up next to
+// uri, see lookupSignature. It returns the name of the bundle.
+func (repo *Repository) DownloadAndExtract(uri string, sha256sum string, opts DownloadOptions) (string, error) {
+	u, err := url.Parse(uri)
+	if err != nil {
+		return "", err
+	}
+	bundleName := path.Base(u.Path)
+
+	trustedKeys, err := opts.trustedKeys()
+	if err != nil {
+		return "", err
+	}
+	var signature []byte
+	if sha256sum == "" {
+		signature, sha256sum, err = lookupSignature(uri, bundleName, trustedKeys)
+		if err != nil {
+			if opts.RequireSignature {
+				return "", err
+			}
+			logging.Warnf("Bundle %s is not signed, it will be used without verification", bundleName)
+			logging.Debugf("%v", err)
+		}
+	}
+
+	body, err := download.Stream(uri)
+	if err != nil {
+		return "", err
+	}
+	defer body.Close()
+
+	hash := sha256.New()
+	signatureVerifier := gpg.NewStreamVerifier(trustedKeys...)
+	reader := io.TeeReader(body, io.MultiWriter(hash, signatureVerifier))
+	verify := func() error {
+		// the bundle archive may be followed by padding which is not needed for the extraction
+		if _, err := io.Copy(io.Discard, reader); err != nil {
+			return err
+		}
+		if signature != nil {
+			logging.Info("Verifying the bundle signature...")
+			return signatureVerifier.Verify(bytes.NewReader(signature))
+		}
+		if sha256sum == "" {
+			return nil
+		}
+		if actual := hex.EncodeToString(hash.Sum(nil)); actual != sha256sum {
+			return fmt.Errorf("sha256sum mismatch for %s: expected %s, got %s", bundleName, sha256sum, actual)
+		}
+		return nil
+	}
+	if err := repo.ExtractStream(bundleName, reader, verify); err != nil {
+		return "", err
+	}
+	if signature != nil || sha256sum != "" {
+		return bundleName, repo.markVerified(bundleName)
+	}
+	return bundleName, nil
+}
+
+// PullAndExtract extracts the bundle while it's downloaded from the imageURI
+// container registry. The bundle is only added to the cache if its signature
+// is valid. It returns the name of the bundle.
+func (repo *Repository) PullAndExtract(imageURI string, opts DownloadOptions) (string, error) {
+	trustedKeys, err := opts.trustedKeys()
+	if err != nil {
+		return "", err
+	}
+
+	var bundleName string
+	defer func() {
+		if bundleName != "" {
+			_ = os.RemoveAll(repo.stagingDir(bundleName)) // clean up after using it
+		}
+	}()
+	err = image.StreamBundle(imageURI, trustedKeys, opts.RequireSignature, func(name string, reader io.Reader) error {
+		bundleName = name
+		return repo.stage(bundleName, reader)
+	})
+	if err != nil {
+		return "", err
+	}
+	if err := repo.commit(repo.stagingDir(bundleName), bundleName); err != nil {
+		return "", err
+	}
+	return bundleName, repo.markVerified(bundleName)
+}
+
+// ExtractLocal extracts a bundle from a local file. Its signature is read from
+// the bundle.crcbundle.sig file next to it if it exists.
+func (repo *Repository) ExtractLocal(path string, opts DownloadOptions) (string, error) {
+	bundleName := filepath.Base(path)
+	signaturePath := path + ".sig"
+	verified := false
+	if _, err := os.Stat(signaturePath); err == nil {
+		trustedKeys, err := opts.trustedKeys()
+		if err != nil {
+			return "", err
+		}
+		logging.Infof("Verifying the signature of %s...", bundleName)
+		if err := gpg.Verify(path, signaturePath, trustedKeys...); err != nil {
+			return "", err
+		}
+		verified = true
+	} else {
+		if opts.RequireSignature {
+			return "", fmt.Errorf("%s is not signed, %s is missing", bundleName, signaturePath)
+		}
+		logging.Debugf("%s is not signed, %s does not exist", bundleName, signaturePath)
+	}
+	if err := repo.Extract(path); err != nil {
+		return "", err
+	}
+	if verified {
+		return bundleName, repo.markVerified(bundleName)
+	}
+	return bundleName, nil
+}
+
+func (repo *Repository) downloadAndExtractDefault(preset crcPreset.Preset, opts DownloadOptions) (string, error) {
+	trustedKeys, err := opts.trustedKeys()
+	if err != nil {
+		return "", err
+	}
+	downloadInfo, err := getBundleDownloadInfo(preset, trustedKeys...)
+	if err != nil {
+		return "", err
+	}
+	return repo.DownloadAndExtract(downloadInfo.URI, downloadInfo.GetSha256Sum(), opts)
+}
+
+// DownloadAndExtract downloads the bundle from bundleURI and extracts it to the cache.
+// It needs about half the disk space of a download followed by an extraction,
+// as the compressed bundle is never written to disk.
+func DownloadAndExtract(preset crcPreset.Preset, bundleURI string, opts DownloadOptions) (*CrcBundleInfo, error) {
+	bundleName, err := downloadAndExtract(defaultRepo, preset, bundleURI, opts)
+	if err != nil {
+		return nil, err
+	}
+	return defaultRepo.Get(bundleName)
+}
+
+func downloadAndExtract(repo *Repository, preset crcPreset.Preset, bundleURI string, opts DownloadOptions) (string, error) {
+	// If we are asked to download
+	// ~/.crc/cache/crc_podman_libvirt_4.1.1.crcbundle, this means we want
+	// are downloading the default bundle for this release. This uses a
+	// different codepath from user-specified URIs as for the default
+	// bundles, their sha256sums are known and can be checked.
+	if bundleURI == constants.GetDefaultBundlePath(preset) {
+		switch preset {
+		case crcPreset.OpenShift, crcPreset.Microshift:
+			bundleName, err := repo.downloadAndExtractDefault(preset, opts)
+			if err != nil && opts.EnableQuayFallback {
+				logging.Info("Unable to download bundle from mirror, falling back to quay")
+				return repo.PullAndExtract(constants.GetDefaultBundleImageRegistry(preset), opts)
+			}
+			return bundleName, err
+		case crcPreset.OKD:
+			fallthrough
+		default:
+			return repo.PullAndExtract(constants.GetDefaultBundleImageRegistry(preset), opts)
+		}
+	}
+	switch {
+	case strings.HasPrefix(bundleURI, "http://"), strings.HasPrefix(bundleURI, "https://"):
+		return repo.DownloadAndExtract(bundleURI, "", opts)
+	case strings.HasPrefix(bundleURI, "docker://"):
+		return repo.PullAndExtract(bundleURI, opts)
+	}
+	// the `bundleURI` parameter turned out to be a local path
+	return repo.ExtractLocal(bundleURI, opts)
+}
@@ -0,0 +1,152 @@
+package bundle
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	crcos "github.com/crc-org/crc/v2/pkg/os"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadAndExtract(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+
+	sha256sum, err := sha256sum(filepath.Join("testdata", testBundle(t)))
+	require.NoError(t, err)
+
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+
+	bundleName, err := repo.DownloadAndExtract(server.URL+"/"+testBundle(t), sha256sum, DownloadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, testBundle(t), bundleName)
+
+	bundle, err := repo.Get(bundleName)
+	require.NoError(t, err)
+	assert.Equal(t, "4.6.1", bundle.GetVersion())
+	assert.True(t, repo.IsVerified(bundleName))
+
+	_, err = os.Stat(repo.stagingDir(bundleName))
+	assert.ErrorIs(t, err, os.ErrNotExist)
+	_, err = os.Stat(filepath.Join(repo.CacheDir, testBundle(t)))
+	assert.ErrorIs(t, err, os.ErrNotExist, "compressed bundle should not be stored in the cache")
+}
+
+func TestDownloadAndExtractChecksumMismatch(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+
+	_, err := repo.DownloadAndExtract(server.URL+"/"+testBundle(t), "0123456789abcdef", DownloadOptions{})
+	assert.ErrorContains(t, err, "sha256sum mismatch")
+
+	entries, err := os.ReadDir(repo.CacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "bundle with an invalid checksum must be discarded")
+}
+
+func TestDownloadAndExtractNotFound(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+
+	_, err := repo.DownloadAndExtract(server.URL+"/crc_libvirt_0.0.0.crcbundle", "", DownloadOptions{})
+	assert.ErrorContains(t, err, "404 Not Found")
+}
+
+func TestDownloadAndExtractRequireSignature(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+
+	_, err := repo.DownloadAndExtract(server.URL+"/"+testBundle(t), "", DownloadOptions{RequireSignature: true})
+	assert.ErrorContains(t, err, "no valid signature found")
+
+	entries, err := os.ReadDir(repo.CacheDir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "unsigned bundle must be refused")
+}
+
+// signedBundleServer serves the test bundle along with a detached signature made with a new key.
+// It returns the path to the armored public key of this key.
+func signedBundleServer(t *testing.T) (*httptest.Server, string) {
+	entity, err := openpgp.NewEntity("test", "", "test@example.com", nil)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	bundlePath := filepath.Join(dir, testBundle(t))
+	require.NoError(t, crcos.CopyFile(filepath.Join("testdata", testBundle(t)), bundlePath))
+	bundle, err := os.Open(bundlePath)
+	require.NoError(t, err)
+	defer bundle.Close()
+	var signature bytes.Buffer
+	require.NoError(t, openpgp.ArmoredDetachSign(&signature, entity, bundle, nil))
+	require.NoError(t, os.WriteFile(bundlePath+".sig", signature.Bytes(), 0600))
+
+	var publicKey bytes.Buffer
+	w, err := armor.Encode(&publicKey, openpgp.PublicKeyType, nil)
+	require.NoError(t, err)
+	require.NoError(t, entity.Serialize(w))
+	require.NoError(t, w.Close())
+	keyPath := filepath.Join(t.TempDir(), "key.asc")
+	require.NoError(t, os.WriteFile(keyPath, publicKey.Bytes(), 0600))
+
+	return httptest.NewServer(http.FileServer(http.Dir(dir))), keyPath
+}
+
+func TestDownloadAndExtractTrustedKey(t *testing.T) {
+	server, keyPath := signedBundleServer(t)
+	defer server.Close()
+
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+
+	// the signature is not made by a trusted key
+	_, err := repo.DownloadAndExtract(server.URL+"/"+testBundle(t), "", DownloadOptions{RequireSignature: true})
+	assert.ErrorContains(t, err, "signed by unknown key")
+	_, err = repo.Get(testBundle(t))
+	assert.Error(t, err)
+
+	bundleName, err := repo.DownloadAndExtract(server.URL+"/"+testBundle(t), "", DownloadOptions{
+		TrustedKeyFiles:  []string{keyPath},
+		RequireSignature: true,
+	})
+	require.NoError(t, err)
+	_, err = repo.Get(bundleName)
+	assert.NoError(t, err)
+	assert.True(t, repo.IsVerified(bundleName))
+}
+
+func TestDownloadAndExtractUnsignedIsNotVerified(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	defer server.Close()
+
+	repo := &Repository{
+		CacheDir: t.TempDir(),
+	}
+
+	bundleName, err := repo.DownloadAndExtract(server.URL+"/"+testBundle(t), "", DownloadOptions{})
+	require.NoError(t, err)
+	_, err = repo.Get(bundleName)
+	require.NoError(t, err)
+	assert.False(t, repo.IsVerified(bundleName))
+}
@@ -0,0 +1,52 @@
+package machine
+
+import (
+	"github.com/crc-org/crc/v2/pkg/crc/cache"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/bundle"
+	"github.com/docker/go-units"
+)
+
+// InUseBundles returns the bundle used by the instance and the configured bundle
+func InUseBundles(client Client, config crcConfig.Storage) []string {
+	bundles := []string{bundle.GetBundleNameFromURI(config.Get(crcConfig.Bundle).AsString())}
+	bundleName, err := client.GetBundleName()
+	if err != nil {
+		logging.Debugf("Cannot get the bundle used by the instance: %v", err)
+	} else if bundleName != "" {
+		bundles = append(bundles, bundleName)
+	}
+	return bundles
+}
+
+func getCacheGCPolicy(config crcConfig.Storage) cache.GCPolicy {
+	return cache.GCPolicy{
+		MaxSize:      int64(config.Get(crcConfig.CacheMaxSize).AsUInt()) * 1024 * 1024 * 1024,
+		KeepVersions: int(config.Get(crcConfig.CacheKeepVersions).AsUInt()),
+		KeepInUse:    config.Get(crcConfig.CacheKeepInUse).AsBool(),
+	}
+}
+
+// CollectCacheGarbage applies the cache garbage collection policy from the
+// configuration. Failures are not fatal as the cache is still usable.
+func CollectCacheGarbage(client Client, config crcConfig.Storage) {
+	policy := getCacheGCPolicy(config)
+	if !policy.IsEnabled() {
+		return
+	}
+	items, err := cache.Usage(constants.MachineCacheDir, constants.CrcBinDir, InUseBundles(client, config))
+	if err != nil {
+		logging.Warnf("Cannot compute the cache usage: %v", err)
+		return
+	}
+	removed, err := cache.CollectGarbage(items, policy)
+	if err != nil {
+		logging.Warnf("Cannot clean up the cache: %v", err)
+		return
+	}
+	if len(removed) > 0 {
+		logging.Infof("Reclaimed %s from the cache", units.HumanSize(float64(cache.TotalSize(removed))))
+	}
+}
@@ -0,0 +1,142 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/pkg/errors"
+)
+
+// loadRunningOpenShiftVM returns the VM when it is running the OpenShift
+// preset, the certificates and users of MicroShift are not managed by crc
+func (client *client) loadRunningOpenShiftVM() (*virtualMachine, error) {
+	vm, err := client.loadRunningVM()
+	if err != nil {
+		return nil, err
+	}
+	if vm.bundle.IsMicroshift() {
+		vm.Close()
+		return nil, fmt.Errorf("This command is not supported by the %s preset", crcPreset.Microshift)
+	}
+	return vm, nil
+}
+
+func (client *client) loadRunningVM() (*virtualMachine, error) {
+	vm, err := loadVirtualMachine(client.name, client.useVSock())
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Cannot load '%s' virtual machine", client.name))
+	}
+	vmStatus, err := vm.State()
+	if err != nil {
+		vm.Close()
+		return nil, errors.Wrap(err, "Cannot get machine state")
+	}
+	if vmStatus != state.Running {
+		vm.Close()
+		return nil, errors.New("CRC VM is not running")
+	}
+	return vm, nil
+}
+
+func (client *client) GetCertificatesStatus() (*types.CertificatesResult, error) {
+	vm, err := client.loadRunningOpenShiftVM()
+	if err != nil {
+		return nil, err
+	}
+	defer vm.Close()
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	return &types.CertificatesResult{
+		Certificates: cluster.GetCertificatesStatus(sshRunner),
+	}, nil
+}
+
+func (client *client) RenewCertificates(ctx context.Context) error {
+	vm, err := client.loadRunningOpenShiftVM()
+	if err != nil {
+		return err
+	}
+	defer vm.Close()
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	// the status of the certificates cached by the daemon is outdated even
+	// when the renewal failed, some of them may have been renewed
+	defer markCertsChanged(certsChangedPath(client.name))
+	if err := cluster.RenewCertificates(ctx, sshRunner, oc.UseOCWithSSH(sshRunner)); err != nil {
+		return errors.Wrap(err, "Failed to renew the certificates")
+	}
+	return nil
+}
+
+// certsChangedPath is the file written when the certificates of the cluster
+// may have changed. Its content is part of the key of the cached status of
+// the certificates, so that the daemon notices the renewals done by the
+// command line.
+func certsChangedPath(name string) string {
+	return filepath.Join(constants.MachineInstanceDir, name, "certs-changed")
+}
+
+func markCertsChanged(path string) {
+	if err := os.WriteFile(path, []byte(strconv.FormatInt(time.Now().UnixNano(), 10)), 0600); err != nil {
+		logging.Debugf("Cannot record the change of the certificates: %v", err)
+	}
+}
+
+// certsCacheKey returns the key of the cached status of the certificates
+func certsCacheKey(path string) string {
+	changed, err := os.ReadFile(path)
+	if err != nil {
+		return "certs"
+	}
+	return "certs-" + string(changed)
+}
+
+// getExpiringCertificates returns the certificates which expire within the
+// configured number of days
+func (client *client) getExpiringCertificates(vm *virtualMachine) []cluster.CertificateStatus {
+	days := client.config.Get(crcConfig.CertExpiryWarningDays).AsUInt()
+	if days == 0 {
+		return nil
+	}
+	certs, err, _ := client.certsDetails.Memoize(certsCacheKey(certsChangedPath(client.name)), func() (interface{}, error) {
+		sshRunner, err := vm.SSHRunner()
+		if err != nil {
+			return nil, errors.Wrap(err, "Error creating the ssh client")
+		}
+		defer sshRunner.Close()
+		return cluster.GetCertificatesStatus(sshRunner), nil
+	})
+	if err != nil {
+		logging.Debugf("Cannot get the certificates status: %v", err)
+		return nil
+	}
+	var expiring []cluster.CertificateStatus
+	for _, cert := range certs.([]cluster.CertificateStatus) {
+		if cert.ExpiresWithin(time.Duration(days) * 24 * time.Hour) {
+			expiring = append(expiring, cert)
+		}
+	}
+	return expiring
+}
@@ -0,0 +1,21 @@
+package machine
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCertsCacheKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "certs-changed")
+	assert.Equal(t, "certs", certsCacheKey(path))
+
+	markCertsChanged(path)
+	key := certsCacheKey(path)
+	assert.NotEqual(t, "certs", key)
+	assert.Equal(t, key, certsCacheKey(path))
+
+	markCertsChanged(path)
+	assert.NotEqual(t, key, certsCacheKey(path))
+}
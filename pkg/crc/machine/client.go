@@ -4,11 +4,13 @@ import (
 	"context"
 	"time"
 
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
 	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
 	"github.com/crc-org/crc/v2/pkg/crc/network"
 	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
 	"github.com/kofalt/go-memoize"
 )
 
@@ -22,11 +24,32 @@ type Client interface {
 	PowerOff() error
 	Start(ctx context.Context, startConfig types.StartConfig) (*types.StartResult, error)
 	Status() (*types.ClusterStatusResult, error)
+	GetClusterOperatorsStatus() (*types.ClusterOperatorsResult, error)
+	GetCertificatesStatus() (*types.CertificatesResult, error)
+	RenewCertificates(ctx context.Context) error
+	ListUsers() ([]types.User, error)
+	AddUser(username, password string, clusterRoles []string) error
+	SetUserPassword(username, password string) error
+	RemoveUser(username string) error
+	InstallOperator(ctx context.Context, operator crcConfig.Operator) (string, error)
+	ExportKubeconfig(username string) ([]byte, error)
+	MergeKubeconfig(kubeconfig string) error
+	RemoveKubeconfig(kubeconfig string) error
+	GetPullSecretRegistries() ([]cluster.PullSecretRegistry, error)
+	AddPullSecretRegistry(registry, username, password string) error
+	RemovePullSecretRegistry(registry string) error
+	LoadImages(images []string) error
+	ListImages() ([]types.Image, error)
+	ExposeRegistry() (string, error)
+	RegistryLogin(username string) (string, error)
+	RegistryPush(image, destination, username string) (string, error)
 	GetClusterLoad() (*types.ClusterLoadResult, error)
 	Stop() (state.State, error)
 	IsRunning() (bool, error)
 	GenerateBundle(forceStop bool) error
 	GetPreset() crcPreset.Preset
+	GetBundleName() (string, error)
+	ApplyResources() (*types.ApplyResourcesResult, error)
 }
 
 type client struct {
@@ -34,17 +57,19 @@ type client struct {
 	debug  bool
 	config crcConfig.Storage
 
-	diskDetails *memoize.Memoizer
-	ramDetails  *memoize.Memoizer
+	diskDetails  *memoize.Memoizer
+	ramDetails   *memoize.Memoizer
+	certsDetails *memoize.Memoizer
 }
 
 func NewClient(name string, debug bool, config crcConfig.Storage) Client {
 	return &client{
-		name:        name,
-		debug:       debug,
-		config:      config,
-		diskDetails: memoize.NewMemoizer(time.Minute, 5*time.Minute),
-		ramDetails:  memoize.NewMemoizer(30*time.Second, 2*time.Minute),
+		name:         name,
+		debug:        debug,
+		config:       config,
+		diskDetails:  memoize.NewMemoizer(time.Minute, 5*time.Minute),
+		ramDetails:   memoize.NewMemoizer(30*time.Second, 2*time.Minute),
+		certsDetails: memoize.NewMemoizer(time.Hour, 2*time.Hour),
 	}
 }
 
@@ -65,5 +90,35 @@ func (client *client) networkMode() network.Mode {
 }
 
 func (client *client) monitoringEnabled() bool {
-	return client.config.Get(crcConfig.EnableClusterMonitoring).AsBool()
+	enabled, _ := crcConfig.GetCapabilities(client.config)
+	return crcstrings.Contains(enabled, crcConfig.CapabilityMonitoring)
+}
+
+func (client *client) microshiftOptions() cluster.MicroShiftOptions {
+	return cluster.MicroShiftOptions{
+		LogLevel:        client.config.Get(crcConfig.MicroShiftLogLevel).AsString(),
+		SubjectAltNames: crcConfig.GetMicroShiftSubjectAltNames(client.config),
+		BaseDomain:      client.config.Get(crcConfig.MicroShiftBaseDomain).AsString(),
+		ClusterNetwork:  client.config.Get(crcConfig.MicroShiftClusterNetwork).AsString(),
+		ServiceNetwork:  client.config.Get(crcConfig.MicroShiftServiceNetwork).AsString(),
+		ManifestsDir:    client.config.Get(crcConfig.MicroShiftManifestsDir).AsString(),
+	}
+}
+
+func (client *client) nodeTuning() cluster.NodeTuning {
+	return cluster.NodeTuning{
+		MaxPods:              client.config.Get(crcConfig.KubeletMaxPods).AsUInt(),
+		EvictionHard:         crcConfig.GetKubeletEvictionHard(client.config),
+		ImageGCHighThreshold: client.config.Get(crcConfig.KubeletImageGCHighThreshold).AsUInt(),
+		ImageGCLowThreshold:  client.config.Get(crcConfig.KubeletImageGCLowThreshold).AsUInt(),
+		CrioLogSizeMax:       crcConfig.GetCrioLogSizeMax(client.config),
+	}
+}
+
+func (client *client) clusterStabilityOptions() cluster.StabilityOptions {
+	return cluster.StabilityOptions{
+		Timeout:           crcConfig.GetClusterStableTimeout(client.config),
+		ConsecutiveChecks: client.config.Get(crcConfig.ClusterStableChecks).AsInt(),
+		IgnoredOperators:  crcConfig.GetClusterStableIgnoredOperators(client.config),
+	}
 }
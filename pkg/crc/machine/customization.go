@@ -0,0 +1,44 @@
+package machine
+
+import (
+	"path"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/customization"
+	"github.com/crc-org/crc/v2/pkg/crc/customization/butane"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	crcssh "github.com/crc-org/crc/v2/pkg/crc/ssh"
+	"github.com/pkg/errors"
+)
+
+// ensureVMCustomization applies the Butane configuration of file when its
+// checksum differs from the one of the configuration applied last
+func ensureVMCustomization(sshRunner *crcssh.Runner, file string) error {
+	if file == "" {
+		return nil
+	}
+	config, err := butane.Parse(file)
+	if err != nil {
+		return err
+	}
+	if current, _, err := sshRunner.RunPrivate("sudo", "cat", customization.ChecksumFile); err == nil && strings.TrimSpace(current) == config.Checksum() {
+		logging.Debugf("The VM customization of %s is already applied", file)
+		return nil
+	}
+
+	logging.Infof("Applying the VM customization of %s...", file)
+	rebootRequired, err := customization.Apply(sshRunner, config)
+	if err != nil {
+		return err
+	}
+	if _, _, err := sshRunner.RunPrivileged("Creating the checksum directory", "mkdir", "-p", path.Dir(customization.ChecksumFile)); err != nil {
+		return err
+	}
+	if err := sshRunner.CopyDataPrivileged([]byte(config.Checksum()), customization.ChecksumFile, 0644); err != nil {
+		return errors.Wrap(err, "Failed to store the checksum of the VM customization")
+	}
+	if rebootRequired {
+		logging.Warn("The kernel arguments take effect after 'crc stop' and 'crc start'")
+	}
+	return nil
+}
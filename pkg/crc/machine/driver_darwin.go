@@ -39,3 +39,15 @@ func updateDriverConfig(host *host.Host, driver *machineVf.Driver) error {
 func updateDriverStruct(_ *host.Host, _ *machineVf.Driver) error {
 	return drivers.ErrNotImplemented
 }
+
+func setResourceMaximums(_ *host.Host, _, _, _, _ uint) error {
+	return drivers.ErrNotImplemented
+}
+
+func setLiveVcpus(_ *host.Host, _ uint) error {
+	return drivers.ErrNotImplemented
+}
+
+func setLiveMemory(_ *host.Host, _ uint) error {
+	return drivers.ErrNotImplemented
+}
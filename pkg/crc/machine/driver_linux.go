@@ -48,3 +48,15 @@ func (r *RPCServerDriver) SetConfigRaw(data []byte, _ *struct{}) error {
 func updateDriverStruct(_ *host.Host, _ *machineLibvirt.Driver) error {
 	return drivers.ErrNotImplemented
 }
+
+func setResourceMaximums(host *host.Host, cpus, memory, maxCPUs, maxMemory uint) error {
+	return libvirt.SetMaximums(host.Name, cpus, memory, maxCPUs, maxMemory)
+}
+
+func setLiveVcpus(host *host.Host, cpus uint) error {
+	return libvirt.SetLiveVcpus(host.Name, cpus)
+}
+
+func setLiveMemory(host *host.Host, memory uint) error {
+	return libvirt.SetLiveMemory(host.Name, memory)
+}
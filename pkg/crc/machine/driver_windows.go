@@ -9,6 +9,7 @@ import (
 	machineLibhvee "github.com/crc-org/crc/v2/pkg/drivers/libhvee"
 	"github.com/crc-org/crc/v2/pkg/libmachine"
 	"github.com/crc-org/crc/v2/pkg/libmachine/host"
+	"github.com/crc-org/machine/libmachine/drivers"
 )
 
 func newHost(api libmachine.API, machineConfig config.MachineConfig) (*host.Host, error) {
@@ -38,3 +39,15 @@ func updateDriverStruct(host *host.Host, driver *machineLibhvee.Driver) error {
 	host.Driver = driver
 	return nil
 }
+
+func setResourceMaximums(_ *host.Host, _, _, _, _ uint) error {
+	return drivers.ErrNotImplemented
+}
+
+func setLiveVcpus(_ *host.Host, _ uint) error {
+	return drivers.ErrNotImplemented
+}
+
+func setLiveMemory(_ *host.Host, _ uint) error {
+	return drivers.ErrNotImplemented
+}
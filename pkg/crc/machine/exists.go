@@ -16,6 +16,25 @@ func (client *client) Exists() (bool, error) {
 	return exists, nil
 }
 
+// GetBundleName returns the name of the bundle used by the instance, or an
+// empty string if the instance does not exist
+func (client *client) GetBundleName() (string, error) {
+	libMachineAPIClient, cleanup := createLibMachineClient()
+	defer cleanup()
+	exists, err := libMachineAPIClient.Exists(client.name)
+	if err != nil {
+		return "", fmt.Errorf("Error checking if the host exists: %s", err)
+	}
+	if !exists {
+		return "", nil
+	}
+	host, err := libMachineAPIClient.Load(client.name)
+	if err != nil {
+		return "", fmt.Errorf("Error loading the host: %s", err)
+	}
+	return host.Driver.GetBundleName()
+}
+
 func CheckIfMachineMissing(client Client) error {
 	exists, err := client.Exists()
 	if err != nil {
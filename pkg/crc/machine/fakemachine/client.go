@@ -3,7 +3,11 @@ package fakemachine
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
 	"github.com/crc-org/crc/v2/pkg/crc/network/httpproxy"
@@ -110,10 +114,189 @@ func (c *Client) Status() (*types.ClusterStatusResult, error) {
 	}, nil
 }
 
+func (c *Client) GetClusterOperatorsStatus() (*types.ClusterOperatorsResult, error) {
+	if c.Failing {
+		return nil, errors.New("broken")
+	}
+	return &types.ClusterOperatorsResult{
+		Preset: preset.OpenShift,
+		Operators: []cluster.OperatorCondition{
+			{
+				Name:               "authentication",
+				Condition:          cluster.ConditionAvailable,
+				Message:            "AsExpected",
+				LastTransitionTime: timestamp(time.Date(2024, 5, 2, 9, 30, 0, 0, time.UTC)),
+			},
+			{
+				Name:               "ingress",
+				Condition:          cluster.ConditionDegraded,
+				Message:            "The default ingress controller reports Degraded=True",
+				LastTransitionTime: timestamp(time.Date(2024, 5, 2, 9, 45, 0, 0, time.UTC)),
+			},
+		},
+	}, nil
+}
+
+func (c *Client) GetCertificatesStatus() (*types.CertificatesResult, error) {
+	if c.Failing {
+		return nil, errors.New("broken")
+	}
+	return &types.CertificatesResult{
+		Certificates: []cluster.CertificateStatus{
+			{
+				Name:     "kubelet-client",
+				Path:     cluster.KubeletClientCert,
+				NotAfter: timestamp(time.Date(2024, 6, 1, 9, 30, 0, 0, time.UTC)),
+			},
+			{
+				Name:  "ingress",
+				Error: "connection refused",
+			},
+		},
+	}, nil
+}
+
+func (c *Client) RenewCertificates(_ context.Context) error {
+	if c.Failing {
+		return errors.New("renew certificates failed")
+	}
+	return nil
+}
+
+func (c *Client) ListUsers() ([]types.User, error) {
+	if c.Failing {
+		return nil, errors.New("list users failed")
+	}
+	return []types.User{
+		{Name: "alice", Context: "crc-alice"},
+		{Name: "developer", Context: "crc-developer"},
+		{Name: "kubeadmin", Context: "crc-admin"},
+		{Name: "bob"},
+	}, nil
+}
+
+func (c *Client) AddUser(_, _ string, _ []string) error {
+	if c.Failing {
+		return errors.New("add user failed")
+	}
+	return nil
+}
+
+func (c *Client) SetUserPassword(_, _ string) error {
+	if c.Failing {
+		return errors.New("set user password failed")
+	}
+	return nil
+}
+
+func (c *Client) RemoveUser(_ string) error {
+	if c.Failing {
+		return errors.New("remove user failed")
+	}
+	return nil
+}
+
+func (c *Client) InstallOperator(_ context.Context, operator crcConfig.Operator) (string, error) {
+	if c.Failing {
+		return "", errors.New("install operator failed")
+	}
+	return operator.Package + ".v1.0.0", nil
+}
+
+func (c *Client) ExportKubeconfig(username string) ([]byte, error) {
+	if c.Failing {
+		return nil, errors.New("export kubeconfig failed")
+	}
+	if username == "" {
+		username = "kubeadmin"
+	}
+	return []byte(fmt.Sprintf("apiVersion: v1\nkind: Config\ncurrent-context: %s\n", username)), nil
+}
+
+func (c *Client) MergeKubeconfig(_ string) error {
+	if c.Failing {
+		return errors.New("merge kubeconfig failed")
+	}
+	return nil
+}
+
+func (c *Client) RemoveKubeconfig(_ string) error {
+	if c.Failing {
+		return errors.New("remove kubeconfig failed")
+	}
+	return nil
+}
+
+func (c *Client) GetPullSecretRegistries() ([]cluster.PullSecretRegistry, error) {
+	if c.Failing {
+		return nil, errors.New("get pull secret registries failed")
+	}
+	return []cluster.PullSecretRegistry{
+		{Registry: "cloud.openshift.com", Username: "user"},
+		{Registry: "registry.internal:5000"},
+	}, nil
+}
+
+func (c *Client) AddPullSecretRegistry(_, _, _ string) error {
+	if c.Failing {
+		return errors.New("add pull secret registry failed")
+	}
+	return nil
+}
+
+func (c *Client) RemovePullSecretRegistry(_ string) error {
+	if c.Failing {
+		return errors.New("remove pull secret registry failed")
+	}
+	return nil
+}
+
+func (c *Client) LoadImages(_ []string) error {
+	if c.Failing {
+		return errors.New("load images failed")
+	}
+	return nil
+}
+
+func (c *Client) ListImages() ([]types.Image, error) {
+	if c.Failing {
+		return nil, errors.New("list images failed")
+	}
+	return []types.Image{
+		{ID: "7c6d5e4f3a2b", Tags: []string{"quay.io/team/app:v1"}, Size: 52428800},
+		{ID: "2f1e0d9c8b7a", Size: 1024},
+	}, nil
+}
+
+func (c *Client) ExposeRegistry() (string, error) {
+	if c.Failing {
+		return "", errors.New("expose registry failed")
+	}
+	return "default-route-openshift-image-registry.apps-crc.testing", nil
+}
+
+func (c *Client) RegistryLogin(_ string) (string, error) {
+	if c.Failing {
+		return "", errors.New("registry login failed")
+	}
+	return "default-route-openshift-image-registry.apps-crc.testing", nil
+}
+
+func (c *Client) RegistryPush(_, destination, _ string) (string, error) {
+	if c.Failing {
+		return "", errors.New("registry push failed")
+	}
+	return cluster.InternalRegistry + "/" + destination, nil
+}
+
 func (c *Client) Exists() (bool, error) {
 	return true, nil
 }
 
+func (c *Client) GetBundleName() (string, error) {
+	return "crc_libvirt_4.16.7_amd64", nil
+}
+
 func (c *Client) IsRunning() (bool, error) {
 	return true, nil
 }
@@ -125,3 +308,17 @@ func (c *Client) GetPreset() preset.Preset {
 func (c *Client) GetClusterLoad() (*types.ClusterLoadResult, error) {
 	return nil, errors.New("not implemented")
 }
+
+func (c *Client) ApplyResources() (*types.ApplyResourcesResult, error) {
+	if c.Failing {
+		return nil, errors.New("apply resources failed")
+	}
+	return &types.ApplyResourcesResult{
+		Running: true,
+		Applied: []string{"memory"},
+	}, nil
+}
+
+func timestamp(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,143 @@
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/pkg/errors"
+	"github.com/spf13/cast"
+)
+
+// hostContainerEngines are the tools used to export the images of the host,
+// in order of preference
+var hostContainerEngines = []string{"podman", "docker"}
+
+// LoadImages copies images from the container storage of the host to the
+// container storage of the instance, which is used by cri-o
+func (client *client) LoadImages(images []string) error {
+	if len(images) == 0 {
+		return errors.New("no image to load")
+	}
+	engine, err := hostContainerEngine()
+	if err != nil {
+		return err
+	}
+	vm, err := client.loadRunningVM()
+	if err != nil {
+		return err
+	}
+	defer vm.Close()
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	logging.Infof("Loading %s into the instance...", strings.Join(images, ", "))
+	// #nosec G204
+	save := exec.Command(engine, saveArgs(engine, images)...)
+	var saveStderr strings.Builder
+	save.Stderr = &saveStderr
+	archive, err := save.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := save.Start(); err != nil {
+		return errors.Wrapf(err, "Cannot run %s save", engine)
+	}
+	stdout, stderr, err := sshRunner.RunWithStdin(archive, "sudo", "podman", "load")
+	if err != nil {
+		// the archive is not read anymore, save would block
+		_ = save.Process.Kill()
+		_ = save.Wait()
+		if saveStderr.Len() != 0 {
+			return fmt.Errorf("%s save failed: %s", engine, strings.TrimSpace(saveStderr.String()))
+		}
+		return fmt.Errorf("Failed to load the images in the instance: %v: %s", err, stderr)
+	}
+	if err := save.Wait(); err != nil {
+		return fmt.Errorf("%s save failed: %v: %s", engine, err, strings.TrimSpace(saveStderr.String()))
+	}
+	logging.Debug(stdout)
+	return nil
+}
+
+func hostContainerEngine() (string, error) {
+	for _, engine := range hostContainerEngines {
+		if path, err := exec.LookPath(engine); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("Cannot find %s on the host", strings.Join(hostContainerEngines, " or "))
+}
+
+// saveArgs returns the arguments to write a docker archive of images, with
+// their tags, to the standard output
+func saveArgs(engine string, images []string) []string {
+	args := []string{"save"}
+	if strings.HasPrefix(filepath.Base(engine), "podman") {
+		// podman only saves several images with --multi-image-archive
+		args = append(args, "--multi-image-archive")
+	}
+	return append(args, images...)
+}
+
+type crictlImages struct {
+	Images []struct {
+		ID       string      `json:"id"`
+		RepoTags []string    `json:"repoTags"`
+		Size     interface{} `json:"size"`
+	} `json:"images"`
+}
+
+// ListImages returns the images of the container storage of the instance
+func (client *client) ListImages() ([]types.Image, error) {
+	vm, err := client.loadRunningVM()
+	if err != nil {
+		return nil, err
+	}
+	defer vm.Close()
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	stdout, stderr, err := sshRunner.Run("sudo", "crictl", "images", "-o", "json")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list the images: %v: %s", err, stderr)
+	}
+	return parseCrictlImages(stdout)
+}
+
+func parseCrictlImages(output string) ([]types.Image, error) {
+	var list crictlImages
+	if err := json.Unmarshal([]byte(output), &list); err != nil {
+		return nil, err
+	}
+	var images []types.Image
+	for _, image := range list.Images {
+		images = append(images, types.Image{
+			ID:   strings.TrimPrefix(image.ID, "sha256:"),
+			Tags: image.RepoTags,
+			// crictl prints the size as a string
+			Size: cast.ToUint64(image.Size),
+		})
+	}
+	// the untagged images are listed last
+	sort.SliceStable(images, func(i, j int) bool {
+		if len(images[i].Tags) == 0 || len(images[j].Tags) == 0 {
+			return len(images[j].Tags) == 0 && len(images[i].Tags) != 0
+		}
+		return images[i].Tags[0] < images[j].Tags[0]
+	})
+	return images, nil
+}
@@ -0,0 +1,30 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveArgs(t *testing.T) {
+	assert.Equal(t, []string{"save", "--multi-image-archive", "quay.io/app:v1", "quay.io/db:v2"}, saveArgs("/usr/bin/podman", []string{"quay.io/app:v1", "quay.io/db:v2"}))
+	assert.Equal(t, []string{"save", "quay.io/app:v1"}, saveArgs("/usr/local/bin/docker", []string{"quay.io/app:v1"}))
+}
+
+func TestParseCrictlImages(t *testing.T) {
+	images, err := parseCrictlImages(`{
+  "images": [
+    {"id": "sha256:2f1e", "repoTags": [], "repoDigests": ["quay.io/openshift@sha256:aa"], "size": "1024"},
+    {"id": "sha256:9a8b", "repoTags": ["quay.io/team/db:v2"], "size": "2048"},
+    {"id": "sha256:7c6d", "repoTags": ["quay.io/team/app:v1", "localhost/app:latest"], "size": "4096"}
+  ]
+}`)
+	require.NoError(t, err)
+	assert.Equal(t, []types.Image{
+		{ID: "7c6d", Tags: []string{"quay.io/team/app:v1", "localhost/app:latest"}, Size: 4096},
+		{ID: "9a8b", Tags: []string{"quay.io/team/db:v2"}, Size: 2048},
+		{ID: "2f1e", Tags: []string{}, Size: 1024},
+	}, images)
+}
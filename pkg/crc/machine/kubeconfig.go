@@ -14,22 +14,21 @@ import (
 	"strings"
 	"time"
 
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	crcerrors "github.com/crc-org/crc/v2/pkg/crc/errors"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
 	"github.com/openshift/library-go/pkg/oauth/tokenrequest"
 	"github.com/openshift/library-go/pkg/oauth/tokenrequest/challengehandlers"
+	"github.com/pkg/errors"
 	"k8s.io/apimachinery/third_party/forked/golang/netutil"
 	restclient "k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/clientcmd/api"
 )
 
-const (
-	adminContext     = "crc-admin"
-	developerContext = "crc-developer"
-)
-
 func updateClientCrtAndKeyToKubeconfig(clientKey, clientCrt []byte, srcKubeconfigPath, destKubeconfigPath string) error {
 	cfg, err := clientcmd.LoadFromFile(srcKubeconfigPath)
 	if err != nil {
@@ -49,11 +48,33 @@ func updateClientCrtAndKeyToKubeconfig(clientKey, clientCrt []byte, srcKubeconfi
 	return clientcmd.WriteToFile(*cfg, destKubeconfigPath)
 }
 
-func writeKubeconfig(ip string, clusterConfig *types.ClusterConfig, ingressHTTPSPort uint) error {
-	kubeconfig, cfg, err := getGlobalKubeConfig()
+// kubeconfigContexts are the names of the kubeconfig contexts of kubeadmin and developer
+type kubeconfigContexts struct {
+	admin     string
+	developer string
+}
+
+func writeKubeconfig(kubeconfig string, ip string, clusterConfig *types.ClusterConfig, ingressHTTPSPort uint, contexts kubeconfigContexts) error {
+	kubeconfig, cfg, err := getKubeConfigFromFile(kubeconfig)
 	if err != nil {
 		return err
 	}
+	if err := addUserContext(cfg, ip, clusterConfig, ingressHTTPSPort, contexts.admin, "kubeadmin", clusterConfig.KubeAdminPass); err != nil {
+		return err
+	}
+	if err := addUserContext(cfg, ip, clusterConfig, ingressHTTPSPort, contexts.developer, "developer", "developer"); err != nil {
+		return err
+	}
+
+	if cfg.CurrentContext == "" {
+		cfg.CurrentContext = contexts.admin
+	}
+
+	return clientcmd.WriteToFile(*cfg, kubeconfig)
+}
+
+// addUserContext logs in as username and adds the cluster and the context of the user to cfg
+func addUserContext(cfg *api.Config, ip string, clusterConfig *types.ClusterConfig, ingressHTTPSPort uint, context, username, password string) error {
 	ca, err := certificateAuthority(clusterConfig.KubeConfig)
 	if err != nil {
 		return err
@@ -62,32 +83,55 @@ func writeKubeconfig(ip string, clusterConfig *types.ClusterConfig, ingressHTTPS
 	if err != nil {
 		return err
 	}
-
 	cfg.Clusters[host] = &api.Cluster{
 		Server:                   clusterConfig.ClusterAPI,
 		CertificateAuthorityData: ca,
 	}
 
-	kubeadminToken, err := getTokenForUser("kubeadmin", clusterConfig.KubeAdminPass, ip, ca, clusterConfig, ingressHTTPSPort)
+	token, err := getTokenForUser(username, password, ip, ca, clusterConfig, ingressHTTPSPort)
 	if err != nil {
 		return err
 	}
-	if err := addContext(cfg, clusterConfig.ClusterAPI, adminContext, "kubeadmin", kubeadminToken); err != nil {
-		return err
-	}
+	return addContext(cfg, clusterConfig.ClusterAPI, context, username, token)
+}
 
-	developerToken, err := getTokenForUser("developer", "developer", ip, ca, clusterConfig, ingressHTTPSPort)
+// userContext is the kubeconfig context of a user added with 'crc users add'
+func userContext(username string) string {
+	return fmt.Sprintf("crc-%s", username)
+}
+
+// writeUserContext logs in as username and adds its context to the kubeconfig
+func writeUserContext(ip string, clusterConfig *types.ClusterConfig, ingressHTTPSPort uint, username, password string) error {
+	kubeconfig, cfg, err := getGlobalKubeConfig()
 	if err != nil {
 		return err
 	}
-	if err := addContext(cfg, clusterConfig.ClusterAPI, developerContext, "developer", developerToken); err != nil {
+	// the OAuth server is redeployed when the htpasswd secret changes
+	login := func() error {
+		if err := addUserContext(cfg, ip, clusterConfig, ingressHTTPSPort, userContext(username), username, password); err != nil {
+			return &crcerrors.RetriableError{Err: err}
+		}
+		return nil
+	}
+	if err := crcerrors.Retry(gocontext.Background(), 5*time.Minute, login, 10*time.Second); err != nil {
 		return err
 	}
+	return clientcmd.WriteToFile(*cfg, kubeconfig)
+}
 
-	if cfg.CurrentContext == "" {
-		cfg.CurrentContext = adminContext
+func removeUserContext(username string) error {
+	kubeconfig, cfg, err := getGlobalKubeConfig()
+	if err != nil {
+		return err
+	}
+	context := userContext(username)
+	if kubeContext, ok := cfg.Contexts[context]; ok {
+		delete(cfg.AuthInfos, kubeContext.AuthInfo)
+		delete(cfg.Contexts, context)
+	}
+	if cfg.CurrentContext == context {
+		cfg.CurrentContext = ""
 	}
-
 	return clientcmd.WriteToFile(*cfg, kubeconfig)
 }
 
@@ -279,10 +323,6 @@ func contains(arr []string, str string) bool {
 	return false
 }
 
-func mergeKubeConfigFile(kubeConfigFile string) error {
-	return mergeConfigHelper(kubeConfigFile, getGlobalKubeConfigPath())
-}
-
 func mergeConfigHelper(kubeConfigFile, globalConfigFile string) error {
 
 	globalConfigPath, globalConf, err := getKubeConfigFromFile(globalConfigFile)
@@ -348,3 +388,90 @@ func appendClusternameToUser(username, clusterAPI string) (string, error) {
 
 	return fmt.Sprintf("%s/%s", username, clusterURL), nil
 }
+
+func (client *client) kubeconfigContexts() kubeconfigContexts {
+	return kubeconfigContexts{
+		admin:     client.config.Get(crcConfig.KubeconfigAdminContext).AsString(),
+		developer: client.config.Get(crcConfig.KubeconfigDeveloperContext).AsString(),
+	}
+}
+
+// ExportKubeconfig returns a kubeconfig file with the context of username
+// only, kubeadmin is used when username is empty
+func (client *client) ExportKubeconfig(username string) ([]byte, error) {
+	vm, err := client.loadRunningVM()
+	if err != nil {
+		return nil, err
+	}
+	defer vm.Close()
+
+	if vm.bundle.IsMicroshift() {
+		if username != "" {
+			return nil, fmt.Errorf("The %s preset has no %s user", crcPreset.Microshift, username)
+		}
+		return os.ReadFile(constants.KubeconfigFilePath)
+	}
+
+	clusterConfig, err := getClusterConfig(vm.bundle)
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot get cluster configuration")
+	}
+	contexts := client.kubeconfigContexts()
+	var context, password string
+	switch username {
+	case "", "kubeadmin":
+		username, context, password = "kubeadmin", contexts.admin, clusterConfig.KubeAdminPass
+	case "developer":
+		context, password = contexts.developer, "developer"
+	default:
+		return nil, fmt.Errorf("Cannot export the kubeconfig of %s, the users are kubeadmin and developer", username)
+	}
+	ip, err := vm.IP()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting the IP")
+	}
+
+	cfg := api.NewConfig()
+	if err := addUserContext(cfg, ip, clusterConfig, client.config.Get(crcConfig.IngressHTTPSPort).AsUInt(), context, username, password); err != nil {
+		return nil, err
+	}
+	cfg.CurrentContext = context
+	return clientcmd.Write(*cfg)
+}
+
+// MergeKubeconfig adds the contexts of the cluster to the kubeconfig file,
+// the global kubeconfig file is used when kubeconfig is empty
+func (client *client) MergeKubeconfig(kubeconfig string) error {
+	vm, err := client.loadRunningVM()
+	if err != nil {
+		return err
+	}
+	defer vm.Close()
+
+	if kubeconfig == "" {
+		kubeconfig = getGlobalKubeConfigPath()
+	}
+	if vm.bundle.IsMicroshift() {
+		return mergeConfigHelper(constants.KubeconfigFilePath, kubeconfig)
+	}
+
+	clusterConfig, err := getClusterConfig(vm.bundle)
+	if err != nil {
+		return errors.Wrap(err, "Cannot get cluster configuration")
+	}
+	ip, err := vm.IP()
+	if err != nil {
+		return errors.Wrap(err, "Error getting the IP")
+	}
+	return writeKubeconfig(kubeconfig, ip, clusterConfig, client.config.Get(crcConfig.IngressHTTPSPort).AsUInt(), client.kubeconfigContexts())
+}
+
+// RemoveKubeconfig removes the clusters, contexts and users of the cluster
+// from the kubeconfig file, the global kubeconfig file is used when kubeconfig
+// is empty
+func (client *client) RemoveKubeconfig(kubeconfig string) error {
+	if kubeconfig == "" {
+		kubeconfig = getGlobalKubeConfigPath()
+	}
+	return cleanKubeconfig(kubeconfig, kubeconfig)
+}
@@ -0,0 +1,48 @@
+package libvirt
+
+import (
+	"fmt"
+
+	crcos "github.com/crc-org/crc/v2/pkg/os"
+)
+
+// SetMaximums changes the definition of the stopped domain so that it boots
+// with cpus and memory, and can be given up to maxCPUs and maxMemory (in MiB)
+// while it runs. Memory above the boot memory is reclaimed with the balloon.
+func SetMaximums(name string, cpus, memory, maxCPUs, maxMemory uint) error {
+	if maxCPUs > cpus {
+		if err := virsh("setvcpus", name, fmt.Sprint(maxCPUs), "--maximum", "--config"); err != nil {
+			return err
+		}
+		if err := virsh("setvcpus", name, fmt.Sprint(cpus), "--config"); err != nil {
+			return err
+		}
+	}
+	if maxMemory > memory {
+		if err := virsh("setmaxmem", name, fmt.Sprintf("%dMiB", maxMemory), "--config"); err != nil {
+			return err
+		}
+		if err := virsh("setmem", name, fmt.Sprintf("%dMiB", memory), "--config"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SetLiveVcpus hotplugs or unplugs vCPUs of the running domain, up to its maximum
+func SetLiveVcpus(name string, cpus uint) error {
+	return virsh("setvcpus", name, fmt.Sprint(cpus), "--live")
+}
+
+// SetLiveMemory resizes the memory balloon of the running domain, up to its maximum
+func SetLiveMemory(name string, memory uint) error {
+	return virsh("setmem", name, fmt.Sprintf("%dMiB", memory), "--live")
+}
+
+func virsh(args ...string) error {
+	_, stderr, err := crcos.RunWithDefaultLocale("virsh", append([]string{"--connect", "qemu:///system"}, args...)...)
+	if err != nil {
+		return fmt.Errorf("Failed to run 'virsh %s': %s: %w", args[0], stderr, err)
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package libvirt
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeVirsh puts in PATH a virsh executable failing like libvirt does when
+// the vCPUs are above the maximum of the domain, or when it is not running
+func fakeVirsh(t *testing.T) {
+	dir := t.TempDir()
+	script := `#!/bin/sh
+case "$*" in
+*"setvcpus crc 16 --live"*)
+	echo "error: invalid argument: requested vcpus is greater than max allowable vcpus for the live domain: 16 > 8" >&2
+	exit 1;;
+*"--live"*)
+	echo "error: Requested operation is not valid: domain is not running" >&2
+	exit 1;;
+esac
+`
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "virsh"), []byte(script), 0700)) // #nosec G306
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+func TestSetLiveVcpusAboveMaximum(t *testing.T) {
+	fakeVirsh(t)
+	err := SetLiveVcpus("crc", 16)
+	assert.ErrorContains(t, err, "Failed to run 'virsh setvcpus'")
+	assert.ErrorContains(t, err, "requested vcpus is greater than max allowable vcpus")
+}
+
+func TestSetLiveResourcesNotRunning(t *testing.T) {
+	fakeVirsh(t)
+	assert.ErrorContains(t, SetLiveVcpus("crc", 6), "domain is not running")
+	err := SetLiveMemory("crc", 12288)
+	assert.ErrorContains(t, err, "Failed to run 'virsh setmem'")
+	assert.ErrorContains(t, err, "domain is not running")
+}
@@ -0,0 +1,50 @@
+package machine
+
+import (
+	"context"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	"github.com/crc-org/crc/v2/pkg/crc/ssh"
+	"github.com/pkg/errors"
+)
+
+func (client *client) InstallOperator(ctx context.Context, operator crcConfig.Operator) (string, error) {
+	if err := operator.Validate(); err != nil {
+		return "", err
+	}
+	vm, err := client.loadRunningOpenShiftVM()
+	if err != nil {
+		return "", err
+	}
+	defer vm.Close()
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return "", errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	return cluster.InstallOperator(ctx, sshRunner, oc.UseOCWithSSH(sshRunner), operator)
+}
+
+// ensureOperators installs the operators of the configuration, a failure
+// does not stop the start since the operators can be installed again with
+// 'crc operator install'
+func (client *client) ensureOperators(ctx context.Context, sshRunner *ssh.Runner, ocConfig oc.Config) {
+	operators, err := crcConfig.ParseOperators(client.config.Get(crcConfig.Operators).AsString())
+	if err != nil {
+		logging.Warnf("Invalid %s setting: %v", crcConfig.Operators, err)
+		return
+	}
+	for _, operator := range operators {
+		csv, err := cluster.InstallOperator(ctx, sshRunner, ocConfig, operator)
+		if err != nil {
+			logging.Warnf("Cannot install operator %s: %v", operator.Package, err)
+			continue
+		}
+		logging.Infof("Operator %s is installed (%s)", operator.Package, csv)
+	}
+}
@@ -0,0 +1,81 @@
+package machine
+
+import (
+	"fmt"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
+	"github.com/pkg/errors"
+)
+
+func (client *client) GetPullSecretRegistries() ([]cluster.PullSecretRegistry, error) {
+	pullSecret, err := cluster.NewNonInteractivePullSecretLoader(client.config, "").Value()
+	if err != nil {
+		return nil, err
+	}
+	return cluster.GetPullSecretRegistries(pullSecret)
+}
+
+func (client *client) AddPullSecretRegistry(registry, username, password string) error {
+	return client.updatePullSecret(func(pullSecret string) (string, error) {
+		return cluster.AddPullSecretRegistry(pullSecret, registry, username, password)
+	})
+}
+
+func (client *client) RemovePullSecretRegistry(registry string) error {
+	return client.updatePullSecret(func(pullSecret string) (string, error) {
+		return cluster.RemovePullSecretRegistry(pullSecret, registry)
+	})
+}
+
+// updatePullSecret stores the updated pull secret and copies it to the
+// running cluster, the cluster must be running when it exists since the pull
+// secret of the cluster is only replaced on start when it is invalid
+func (client *client) updatePullSecret(update func(pullSecret string) (string, error)) error {
+	if path := client.config.Get(crcConfig.PullSecretFile).AsString(); path != "" {
+		return fmt.Errorf("The pull secret is read from %s, unset '%s' to manage the pull secret with this command", path, crcConfig.PullSecretFile)
+	}
+	if preset := crcConfig.GetPreset(client.config); preset == crcPreset.OKD {
+		return fmt.Errorf("This command is not supported by the %s preset", preset)
+	}
+	pullSecret, err := cluster.NewNonInteractivePullSecretLoader(client.config, "").Value()
+	if err != nil {
+		return err
+	}
+	updated, err := update(pullSecret)
+	if err != nil {
+		return err
+	}
+
+	exists, err := client.Exists()
+	if err != nil {
+		return errors.Wrap(err, "Cannot determine if VM exists")
+	}
+	if !exists {
+		return cluster.StorePullSecret(updated)
+	}
+	vm, err := client.loadRunningVM()
+	if err != nil {
+		return err
+	}
+	defer vm.Close()
+
+	if err := cluster.StorePullSecret(updated); err != nil {
+		return err
+	}
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	logging.Info("Updating the pull secret of the cluster...")
+	if vm.bundle.IsMicroshift() {
+		return sshRunner.CopyDataPrivileged([]byte(updated), "/etc/crio/openshift-pull-secret", 0600)
+	}
+	return cluster.UpdatePullSecretInTheCluster(oc.UseOCWithSSH(sshRunner), updated)
+}
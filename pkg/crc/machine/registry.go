@@ -0,0 +1,195 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/pkg/errors"
+)
+
+var registryDestinationRegexp = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?/[a-z0-9]+([._-][a-z0-9]+)*(:[\w][\w.-]{0,127})?$`)
+
+// ExposeRegistry enables the route of the image registry and returns the
+// address of the registry on the host
+func (client *client) ExposeRegistry() (string, error) {
+	var registry string
+	err := client.withOpenShiftCluster(func(_ *virtualMachine, ocConfig oc.Config) error {
+		var err error
+		registry, err = client.exposeRegistry(ocConfig)
+		return err
+	})
+	return registry, err
+}
+
+// RegistryLogin logs podman in to the image registry with the token of username
+func (client *client) RegistryLogin(username string) (string, error) {
+	var registry string
+	err := client.withOpenShiftCluster(func(vm *virtualMachine, ocConfig oc.Config) error {
+		var err error
+		registry, err = client.exposeRegistry(ocConfig)
+		if err != nil {
+			return err
+		}
+		return client.registryLogin(vm, registry, username)
+	})
+	return registry, err
+}
+
+// RegistryPush pushes a host image to the image registry as username,
+// destination is namespace/name[:tag]. It returns the reference of the
+// pushed image inside the cluster.
+func (client *client) RegistryPush(image, destination, username string) (string, error) {
+	if !registryDestinationRegexp.MatchString(destination) {
+		return "", fmt.Errorf("invalid destination '%s', the format is namespace/name[:tag]", destination)
+	}
+	err := client.withOpenShiftCluster(func(vm *virtualMachine, ocConfig oc.Config) error {
+		registry, err := client.exposeRegistry(ocConfig)
+		if err != nil {
+			return err
+		}
+		if err := client.registryLogin(vm, registry, username); err != nil {
+			return err
+		}
+		podman, err := lookPodman()
+		if err != nil {
+			return err
+		}
+		logging.Infof("Pushing %s to %s/%s...", image, registry, destination)
+		// #nosec G204
+		cmd := exec.Command(podman, podmanPushArgs(client.registryCertsDir(), image, fmt.Sprintf("%s/%s", registry, destination))...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			return fmt.Errorf("podman push failed: %v: %s", err, strings.TrimSpace(string(out)))
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", cluster.InternalRegistry, destination), nil
+}
+
+func (client *client) exposeRegistry(ocConfig oc.Config) (string, error) {
+	if _, disabled := crcConfig.GetCapabilities(client.config); crcstrings.Contains(disabled, crcConfig.CapabilityImageRegistry) {
+		return "", fmt.Errorf("The image registry is disabled by '%s'", crcConfig.ClusterCapabilities)
+	}
+	host, err := cluster.ExposeImageRegistry(context.Background(), ocConfig)
+	if err != nil {
+		return "", err
+	}
+	return registryAddress(host, client.config.Get(crcConfig.IngressHTTPSPort).AsUInt()), nil
+}
+
+func registryAddress(host string, ingressHTTPSPort uint) string {
+	if ingressHTTPSPort == constants.OpenShiftIngressHTTPSPort {
+		return host
+	}
+	return net.JoinHostPort(host, strconv.FormatUint(uint64(ingressHTTPSPort), 10))
+}
+
+// registryCertsDir contains the certificate authority of the ingress, used
+// by podman to trust the route of the registry
+func (client *client) registryCertsDir() string {
+	return filepath.Join(constants.MachineInstanceDir, client.name, "registry-certs")
+}
+
+// lookPodman returns the path of podman when it runs the containers on the
+// host. The remote client, which is the only one on macOS and Windows, runs
+// them in the podman machine, which cannot use the certificate directory of
+// the host nor reach the ingress of the cluster on the host.
+func lookPodman() (string, error) {
+	podman, err := exec.LookPath("podman")
+	if err != nil {
+		return "", errors.New("podman is required to use the image registry")
+	}
+	remote := "true"
+	if runtime.GOOS == "linux" {
+		// #nosec G204
+		out, err := exec.Command(podman, "info", "--format", "{{.Host.ServiceIsRemote}}").Output()
+		if err != nil {
+			return "", fmt.Errorf("Cannot get podman information: %v", err)
+		}
+		remote = string(out)
+	}
+	if err := checkPodmanIsLocal(remote); err != nil {
+		return "", err
+	}
+	return podman, nil
+}
+
+func checkPodmanIsLocal(serviceIsRemote string) error {
+	if strings.TrimSpace(serviceIsRemote) == "true" {
+		return errors.New("The image registry commands are only supported with a local podman on Linux, the remote podman client cannot reach the registry of the cluster")
+	}
+	return nil
+}
+
+func podmanLoginArgs(certsDir, username, registry string) []string {
+	return []string{"login", "--cert-dir", certsDir, "--username", username, "--password-stdin", registry}
+}
+
+func podmanPushArgs(certsDir, image, reference string) []string {
+	return []string{"push", "--cert-dir", certsDir, image, reference}
+}
+
+func (client *client) registryLogin(vm *virtualMachine, registry, username string) error {
+	podman, err := lookPodman()
+	if err != nil {
+		return err
+	}
+	clusterConfig, err := getClusterConfig(vm.bundle)
+	if err != nil {
+		return errors.Wrap(err, "Cannot get cluster configuration")
+	}
+	var password string
+	switch username {
+	case "kubeadmin":
+		password = clusterConfig.KubeAdminPass
+	case "developer":
+		password = "developer"
+	default:
+		return fmt.Errorf("Cannot log in to the registry as %s, the users are kubeadmin and developer", username)
+	}
+
+	ca, err := certificateAuthority(clusterConfig.KubeConfig)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(client.registryCertsDir(), 0700); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(client.registryCertsDir(), "ca.crt"), ca, 0600); err != nil {
+		return err
+	}
+
+	ip, err := vm.IP()
+	if err != nil {
+		return errors.Wrap(err, "Error getting the IP")
+	}
+	token, err := getTokenForUser(username, password, ip, ca, clusterConfig, client.config.Get(crcConfig.IngressHTTPSPort).AsUInt())
+	if err != nil {
+		return errors.Wrapf(err, "Cannot get the token of %s", username)
+	}
+
+	logging.Infof("Logging in to %s as %s...", registry, username)
+	// #nosec G204
+	cmd := exec.Command(podman, podmanLoginArgs(client.registryCertsDir(), username, registry)...)
+	cmd.Stdin = strings.NewReader(token)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("podman login failed: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package machine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistryAddress(t *testing.T) {
+	assert.Equal(t, "default-route-openshift-image-registry.apps-crc.testing", registryAddress("default-route-openshift-image-registry.apps-crc.testing", 443))
+	assert.Equal(t, "default-route-openshift-image-registry.apps-crc.testing:9443", registryAddress("default-route-openshift-image-registry.apps-crc.testing", 9443))
+}
+
+func TestRegistryDestination(t *testing.T) {
+	for _, destination := range []string{"myproject/app", "myproject/app:v1", "my-project/team.app:1.0-rc1"} {
+		assert.True(t, registryDestinationRegexp.MatchString(destination), destination)
+	}
+	for _, destination := range []string{"app:v1", "MyProject/app", "myproject/app:", "a/b/c", "myproject/app:v1;rm"} {
+		assert.False(t, registryDestinationRegexp.MatchString(destination), destination)
+	}
+}
+
+func TestPodmanArgs(t *testing.T) {
+	assert.Equal(t, []string{"login", "--cert-dir", "/certs", "--username", "developer", "--password-stdin", "registry.apps-crc.testing"},
+		podmanLoginArgs("/certs", "developer", "registry.apps-crc.testing"))
+	assert.Equal(t, []string{"push", "--cert-dir", "/certs", "localhost/app:v1", "registry.apps-crc.testing/myproject/app:v1"},
+		podmanPushArgs("/certs", "localhost/app:v1", "registry.apps-crc.testing/myproject/app:v1"))
+}
+
+func TestCheckPodmanIsLocal(t *testing.T) {
+	assert.NoError(t, checkPodmanIsLocal("false\n"))
+	assert.ErrorContains(t, checkPodmanIsLocal("true\n"), "only supported with a local podman on Linux")
+}
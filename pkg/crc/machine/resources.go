@@ -0,0 +1,222 @@
+package machine
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/constants"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	crcstrings "github.com/crc-org/crc/v2/pkg/strings"
+	"github.com/crc-org/machine/libmachine/drivers"
+	"github.com/pkg/errors"
+)
+
+// onlineCPUs brings the hotplugged vCPUs online, RHCOS does not do it automatically
+const onlineCPUs = `for cpu in /sys/devices/system/cpu/cpu[0-9]*/online; do [ "$(cat $cpu)" = 1 ] || echo 1 > $cpu; done`
+
+// vmResources are the CPUs and memory of the running VM. They differ from the
+// configuration when it changed after the VM was started, and the change
+// could not be applied without a restart.
+type vmResources struct {
+	CPUs      uint `json:"cpus"`
+	Memory    uint `json:"memory"`
+	MaxCPUs   uint `json:"maxCpus"`
+	MaxMemory uint `json:"maxMemory"`
+}
+
+func resourcesPath(name string) string {
+	return filepath.Join(constants.MachineInstanceDir, name, "resources.json")
+}
+
+func loadResources(name string) (*vmResources, error) {
+	bin, err := os.ReadFile(resourcesPath(name))
+	if err != nil {
+		return nil, err
+	}
+	var resources vmResources
+	if err := json.Unmarshal(bin, &resources); err != nil {
+		return nil, err
+	}
+	return &resources, nil
+}
+
+func saveResources(name string, resources *vmResources) error {
+	bin, err := json.Marshal(resources)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(resourcesPath(name), bin, 0600)
+}
+
+// bootResources returns the resources the stopped VM is going to boot with,
+// after raising the maximums the running VM can be given when the driver can
+func bootResources(vm *virtualMachine, startConfig types.StartConfig) (*vmResources, error) {
+	driver, err := loadDriverConfig(vm.Host)
+	if err != nil {
+		return nil, err
+	}
+	resources := &vmResources{
+		CPUs:      driver.CPU,
+		Memory:    driver.Memory,
+		MaxCPUs:   driver.CPU,
+		MaxMemory: driver.Memory,
+	}
+	if startConfig.MaxCPUs <= resources.CPUs && startConfig.MaxMemory <= resources.Memory {
+		return resources, nil
+	}
+	if err := setResourceMaximums(vm.Host, resources.CPUs, resources.Memory, startConfig.MaxCPUs, startConfig.MaxMemory); err != nil {
+		if err == drivers.ErrNotImplemented {
+			logging.Warnf("%s and %s have been ignored as the machine driver does not support it", crcConfig.MaxCPUs, crcConfig.MaxMemory)
+			return resources, nil
+		}
+		return nil, err
+	}
+	resources.MaxCPUs = max(resources.MaxCPUs, startConfig.MaxCPUs)
+	resources.MaxMemory = max(resources.MaxMemory, startConfig.MaxMemory)
+	return resources, nil
+}
+
+// pendingChanges returns the configured resources which the running VM does not have
+func pendingChanges(resources *vmResources, cpus, memory uint) []types.PendingChange {
+	var pending []types.PendingChange
+	if resources.CPUs != cpus {
+		pending = append(pending, types.PendingChange{
+			Property:   crcConfig.CPUs,
+			Current:    resources.CPUs,
+			Configured: cpus,
+		})
+	}
+	if resources.Memory != memory {
+		pending = append(pending, types.PendingChange{
+			Property:   crcConfig.Memory,
+			Current:    resources.Memory,
+			Configured: memory,
+		})
+	}
+	return pending
+}
+
+// runningResources returns the resources of the running VM, they are not
+// recorded when it was started by an older version
+func (client *client) runningResources(vm *virtualMachine) (*vmResources, error) {
+	resources, err := loadResources(client.name)
+	if err == nil {
+		return resources, nil
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	driver, err := loadDriverConfig(vm.Host)
+	if err != nil {
+		return nil, err
+	}
+	return &vmResources{
+		CPUs:      driver.CPU,
+		Memory:    driver.Memory,
+		MaxCPUs:   driver.CPU,
+		MaxMemory: driver.Memory,
+	}, nil
+}
+
+// ApplyResources gives the running VM the configured CPUs and memory when
+// the driver supports it and they are within the maximums the VM was
+// started with. The other changes are applied by the next start.
+func (client *client) ApplyResources() (*types.ApplyResourcesResult, error) {
+	vm, err := loadVirtualMachine(client.name, client.useVSock())
+	if err != nil {
+		if errors.Is(err, errMissingHost(client.name)) {
+			return &types.ApplyResourcesResult{}, nil
+		}
+		return nil, errors.Wrap(err, fmt.Sprintf("Cannot load '%s' virtual machine", client.name))
+	}
+	defer vm.Close()
+
+	vmState, err := vm.State()
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot get machine state")
+	}
+	if vmState != state.Running {
+		return &types.ApplyResourcesResult{}, nil
+	}
+
+	resources, err := client.runningResources(vm)
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot get the resources of the running VM")
+	}
+	cpus := client.config.Get(crcConfig.CPUs).AsUInt()
+	memory := client.config.Get(crcConfig.Memory).AsUInt()
+
+	result := applyLiveResources(resources, cpus, memory,
+		func(cpus uint) error {
+			return client.applyCPUs(vm, cpus)
+		},
+		func(memory uint) error {
+			return setLiveMemory(vm.Host, memory)
+		})
+	if len(result.Applied) != 0 {
+		if err := saveResources(client.name, resources); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// applyLiveResources changes the CPUs and memory of the running VM with
+// setCPUs and setMemory when they are within its maximums, and updates
+// resources with the changes which were applied
+func applyLiveResources(resources *vmResources, cpus, memory uint, setCPUs, setMemory func(uint) error) *types.ApplyResourcesResult {
+	result := &types.ApplyResourcesResult{
+		Running: true,
+	}
+	if cpus != resources.CPUs && cpus <= resources.MaxCPUs {
+		if err := setCPUs(cpus); err != nil {
+			logging.Debugf("Cannot change the number of CPUs of the running VM: %v", err)
+		} else {
+			resources.CPUs = cpus
+			result.Applied = append(result.Applied, crcConfig.CPUs)
+		}
+	}
+	if memory != resources.Memory && memory <= resources.MaxMemory {
+		if err := setMemory(memory); err != nil {
+			logging.Debugf("Cannot change the memory of the running VM: %v", err)
+		} else {
+			resources.Memory = memory
+			result.Applied = append(result.Applied, crcConfig.Memory)
+		}
+	}
+	result.Pending = pendingChanges(resources, cpus, memory)
+	return result
+}
+
+// ApplyChangedResources gives the running VM its new CPUs or memory when
+// keys, the configuration properties which were changed, include them. It
+// returns the properties which were applied without a restart.
+func ApplyChangedResources(client Client, keys []string) []string {
+	if !crcstrings.Contains(keys, crcConfig.CPUs) && !crcstrings.Contains(keys, crcConfig.Memory) {
+		return nil
+	}
+	result, err := client.ApplyResources()
+	if err != nil {
+		logging.Debugf("Cannot apply the resources to the running VM: %v", err)
+		return nil
+	}
+	return result.Applied
+}
+
+func (client *client) applyCPUs(vm *virtualMachine, cpus uint) error {
+	if err := setLiveVcpus(vm.Host, cpus); err != nil {
+		return err
+	}
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+	_, _, err = sshRunner.RunPrivileged("Bringing hotplugged CPUs online", "sh", "-c", onlineCPUs)
+	return err
+}
@@ -0,0 +1,100 @@
+package machine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/crc-org/crc/v2/pkg/crc/machine/fakemachine"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPendingChanges(t *testing.T) {
+	resources := &vmResources{
+		CPUs:      4,
+		Memory:    10752,
+		MaxCPUs:   8,
+		MaxMemory: 10752,
+	}
+	assert.Empty(t, pendingChanges(resources, 4, 10752))
+	assert.Equal(t, []types.PendingChange{
+		{Property: "cpus", Current: 4, Configured: 6},
+		{Property: "memory", Current: 10752, Configured: 16384},
+	}, pendingChanges(resources, 6, 16384))
+}
+
+func TestApplyLiveResources(t *testing.T) {
+	resources := &vmResources{
+		CPUs:      4,
+		Memory:    10752,
+		MaxCPUs:   8,
+		MaxMemory: 16384,
+	}
+	var cpus, memory uint
+	setCPUs := func(value uint) error {
+		cpus = value
+		return nil
+	}
+	setMemory := func(value uint) error {
+		memory = value
+		return nil
+	}
+
+	result := applyLiveResources(resources, 6, 12288, setCPUs, setMemory)
+	assert.Equal(t, []string{"cpus", "memory"}, result.Applied)
+	assert.Empty(t, result.Pending)
+	assert.Equal(t, uint(6), cpus)
+	assert.Equal(t, uint(12288), memory)
+	assert.Equal(t, &vmResources{CPUs: 6, Memory: 12288, MaxCPUs: 8, MaxMemory: 16384}, resources)
+}
+
+func TestApplyLiveResourcesAboveMaximum(t *testing.T) {
+	resources := &vmResources{
+		CPUs:      4,
+		Memory:    10752,
+		MaxCPUs:   8,
+		MaxMemory: 16384,
+	}
+	notCalled := func(uint) error {
+		t.Fatal("the resources above the maximums must not be applied")
+		return nil
+	}
+
+	result := applyLiveResources(resources, 10, 20480, notCalled, notCalled)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, []types.PendingChange{
+		{Property: "cpus", Current: 4, Configured: 10},
+		{Property: "memory", Current: 10752, Configured: 20480},
+	}, result.Pending)
+	assert.Equal(t, uint(4), resources.CPUs)
+	assert.Equal(t, uint(10752), resources.Memory)
+}
+
+func TestApplyLiveResourcesFailure(t *testing.T) {
+	resources := &vmResources{
+		CPUs:      4,
+		Memory:    10752,
+		MaxCPUs:   8,
+		MaxMemory: 16384,
+	}
+	// virsh fails when the VM stopped after its state was checked
+	notRunning := func(uint) error {
+		return errors.New("Failed to run 'virsh setvcpus': error: Requested operation is not valid: domain is not running")
+	}
+
+	result := applyLiveResources(resources, 6, 12288, notRunning, notRunning)
+	assert.Empty(t, result.Applied)
+	assert.Equal(t, []types.PendingChange{
+		{Property: "cpus", Current: 4, Configured: 6},
+		{Property: "memory", Current: 10752, Configured: 12288},
+	}, result.Pending)
+}
+
+func TestApplyChangedResources(t *testing.T) {
+	client := fakemachine.NewClient()
+	assert.Empty(t, ApplyChangedResources(client, []string{"nameserver"}))
+	assert.Equal(t, []string{"memory"}, ApplyChangedResources(client, []string{"cpus", "memory"}))
+
+	client.Failing = true
+	assert.Empty(t, ApplyChangedResources(client, []string{"cpus"}))
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	crcerrors "github.com/crc-org/crc/v2/pkg/crc/errors"
 	logging "github.com/crc-org/crc/v2/pkg/crc/logging"
@@ -41,20 +42,19 @@ import (
 
 const minimumMemoryForMonitoring = 14336
 
-func getCrcBundleInfo(preset crcPreset.Preset, bundleName, bundlePath string, enableBundleQuayFallback bool) (*bundle.CrcBundleInfo, error) {
+func getCrcBundleInfo(preset crcPreset.Preset, bundleName, bundlePath string, downloadOptions bundle.DownloadOptions) (*bundle.CrcBundleInfo, error) {
 	bundleInfo, err := bundle.Use(bundleName)
-	if err == nil {
+	switch {
+	case err != nil:
+		logging.Debugf("Failed to load bundle %s: %v", bundleName, err)
+	case downloadOptions.RequireSignature && !bundle.IsVerified(bundleName):
+		logging.Infof("Bundle %s in the cache was not verified when it was extracted", bundleName)
+	default:
 		logging.Infof("Loading bundle: %s...", bundleName)
 		return bundleInfo, nil
 	}
-	logging.Debugf("Failed to load bundle %s: %v", bundleName, err)
-	logging.Infof("Downloading bundle: %s...", bundleName)
-	bundlePath, err = bundle.Download(preset, bundlePath, enableBundleQuayFallback)
-	if err != nil {
-		return nil, err
-	}
-	logging.Infof("Extracting bundle: %s...", bundleName)
-	if _, err := bundle.Extract(bundlePath); err != nil {
+	logging.Infof("Downloading and extracting bundle: %s...", bundleName)
+	if _, err := bundle.DownloadAndExtract(preset, bundlePath, downloadOptions); err != nil {
 		return nil, err
 	}
 	return bundle.Use(bundleName)
@@ -263,7 +263,17 @@ func configureSharedDirs(vm *virtualMachine, sshRunner *crcssh.Runner) error {
 	return nil
 }
 
+// Start starts the instance and collects the cache garbage once it has
+// successfully started, for both the CLI and the daemon
 func (client *client) Start(ctx context.Context, startConfig types.StartConfig) (*types.StartResult, error) {
+	result, err := client.start(ctx, startConfig)
+	if err == nil {
+		CollectCacheGarbage(client, client.config)
+	}
+	return result, err
+}
+
+func (client *client) start(ctx context.Context, startConfig types.StartConfig) (*types.StartResult, error) {
 	telemetry.SetCPUs(ctx, startConfig.CPUs)
 	telemetry.SetMemory(ctx, uint64(startConfig.Memory)*1024*1024)
 	telemetry.SetDiskSize(ctx, uint64(startConfig.DiskSize)*1024*1024*1024)
@@ -279,7 +289,11 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 	}
 
 	bundleName := bundle.GetBundleNameWithoutExtension(bundle.GetBundleNameFromURI(startConfig.BundlePath))
-	crcBundleMetadata, err := getCrcBundleInfo(startConfig.Preset, bundleName, startConfig.BundlePath, startConfig.EnableBundleQuayFallback)
+	crcBundleMetadata, err := getCrcBundleInfo(startConfig.Preset, bundleName, startConfig.BundlePath, bundle.DownloadOptions{
+		EnableQuayFallback: startConfig.EnableBundleQuayFallback,
+		TrustedKeyFiles:    startConfig.BundleTrustedKeys,
+		RequireSignature:   startConfig.RequireSignedBundles,
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "Error getting bundle metadata")
 	}
@@ -377,10 +391,19 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 		return nil, errors.Wrap(err, "Could not update CRC VM configuration")
 	}
 
+	resources, err := bootResources(vm, startConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not configure CRC VM resources")
+	}
+
 	if err := startHost(ctx, vm); err != nil {
 		return nil, errors.Wrap(err, "Error starting machine")
 	}
 
+	if err := saveResources(client.name, resources); err != nil {
+		logging.Debugf("Cannot record the resources of the CRC VM: %v", err)
+	}
+
 	// Post-VM start
 	vmState, err = vm.State()
 	if err != nil {
@@ -453,6 +476,10 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 		}
 	}
 
+	if err := ensureVMCustomization(sshRunner, client.config.Get(crcConfig.VMCustomization).AsString()); err != nil {
+		return nil, errors.Wrap(err, "Failed to apply the VM customization")
+	}
+
 	if _, _, err := sshRunner.RunPrivileged("make root Podman socket accessible", "chmod 777 /run/podman/ /run/podman/podman.sock"); err != nil {
 		return nil, errors.Wrap(err, "Failed to change permissions to root podman socket")
 	}
@@ -511,7 +538,7 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 		ocConfig.Context = "microshift"
 		ocConfig.Cluster = "microshift"
 
-		if err := startMicroshift(ctx, sshRunner, ocConfig, startConfig.PullSecret); err != nil {
+		if err := startMicroshift(ctx, sshRunner, ocConfig, startConfig.PullSecret, client.microshiftOptions(), !exists, client.nodeTuning()); err != nil {
 			return nil, err
 		}
 
@@ -520,9 +547,11 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 				return nil, err
 			}
 		}
-		logging.Info("Adding microshift context to kubeconfig...")
-		if err := mergeKubeConfigFile(constants.KubeconfigFilePath); err != nil {
-			return nil, err
+		if client.config.Get(crcConfig.KubeconfigAutoMerge).AsBool() {
+			logging.Info("Adding microshift context to kubeconfig...")
+			if err := mergeConfigHelper(constants.KubeconfigFilePath, getGlobalKubeConfigPath()); err != nil {
+				return nil, err
+			}
 		}
 
 		return &types.StartResult{
@@ -538,9 +567,19 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 		return nil, errors.Wrap(err, "Failed to check certificate validity")
 	}
 
-	logging.Info("Starting kubelet service")
 	sd := systemd.NewInstanceSystemdCommander(sshRunner)
-	if err := sd.Start("kubelet"); err != nil {
+	kubeletChanged, err := cluster.ApplyNodeTuning(sshRunner, client.nodeTuning(), cluster.EnsureKubeletConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	logging.Info("Starting kubelet service")
+	if kubeletChanged {
+		err = sd.Restart("kubelet")
+	} else {
+		err = sd.Start("kubelet")
+	}
+	if err != nil {
 		return nil, errors.Wrap(err, "Error starting kubelet")
 	}
 
@@ -550,6 +589,7 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 		logBundleDate(vm.bundle)
 		return nil, errors.Wrap(err, "Failed to renew TLS certificates: please check if a newer CRC release is available")
 	}
+	markCertsChanged(certsChangedPath(client.name))
 
 	if err := cluster.WaitForAPIServer(ctx, ocConfig); err != nil {
 		return nil, errors.Wrap(err, "Error waiting for apiserver")
@@ -589,10 +629,10 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 		}
 	}
 
-	if client.monitoringEnabled() {
-		logging.Info("Enabling cluster monitoring operator...")
-		if err := cluster.StartMonitoring(ocConfig); err != nil {
-			return nil, errors.Wrap(err, "Cannot start monitoring stack")
+	if enabled, disabled := crcConfig.GetCapabilities(client.config); len(enabled) != 0 || len(disabled) != 0 {
+		logging.Info("Updating the cluster capabilities...")
+		if err := cluster.ReconcileCapabilities(ocConfig, enabled, disabled); err != nil {
+			return nil, errors.Wrap(err, "Cannot update the cluster capabilities")
 		}
 	}
 
@@ -601,20 +641,28 @@ func (client *client) Start(ctx context.Context, startConfig types.StartConfig)
 	}
 
 	logging.Infof("Starting %s instance... [waiting for the cluster to stabilize]", startConfig.Preset)
-	if err := cluster.WaitForClusterStable(ctx, instanceIP, constants.KubeconfigFilePath, proxyConfig); err != nil {
+	if err := cluster.WaitForClusterStable(ctx, instanceIP, constants.KubeconfigFilePath, proxyConfig, client.clusterStabilityOptions()); err != nil {
+		if client.config.Get(crcConfig.ClusterStableStrict).AsBool() {
+			return nil, errors.Wrap(err, "Cluster is not ready")
+		}
 		logging.Warnf("Cluster is not ready: %v", err)
 	}
 
 	waitForProxyPropagation(ctx, ocConfig, proxyConfig)
 
+	client.ensureOperators(ctx, sshRunner, ocConfig)
+
 	clusterConfig, err := getClusterConfig(vm.bundle)
 	if err != nil {
 		return nil, errors.Wrap(err, "Cannot get cluster configuration")
 	}
 
-	logging.Info("Adding crc-admin and crc-developer contexts to kubeconfig...")
-	if err := writeKubeconfig(instanceIP, clusterConfig, startConfig.IngressHTTPSPort); err != nil {
-		logging.Errorf("Cannot update kubeconfig: %v", err)
+	if client.config.Get(crcConfig.KubeconfigAutoMerge).AsBool() {
+		contexts := client.kubeconfigContexts()
+		logging.Infof("Adding %s and %s contexts to kubeconfig...", contexts.admin, contexts.developer)
+		if err := writeKubeconfig(getGlobalKubeConfigPath(), instanceIP, clusterConfig, startConfig.IngressHTTPSPort, contexts); err != nil {
+			logging.Errorf("Cannot update kubeconfig: %v", err)
+		}
 	}
 
 	return &types.StartResult{
@@ -854,12 +902,30 @@ func updateKubeconfig(ctx context.Context, ocConfig oc.Config, sshRunner *crcssh
 	return nil
 }
 
-func startMicroshift(ctx context.Context, sshRunner *crcssh.Runner, ocConfig oc.Config, pullSec cluster.PullSecretLoader) error {
+func startMicroshift(ctx context.Context, sshRunner *crcssh.Runner, ocConfig oc.Config, pullSec cluster.PullSecretLoader, options cluster.MicroShiftOptions, created bool, tuning cluster.NodeTuning) error {
 	logging.Infof("Starting Microshift service... [takes around 1min]")
 	if err := ensurePullSecretPresentInVM(sshRunner, pullSec); err != nil {
 		return err
 	}
-	if _, _, err := sshRunner.RunPrivileged("Starting microshift service", "systemctl", "start", "microshift"); err != nil {
+	configChanged, err := cluster.EnsureMicroShiftConfig(sshRunner, options, created)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update the MicroShift configuration")
+	}
+	manifestsChanged, err := cluster.EnsureMicroShiftManifests(sshRunner, options.ManifestsDir)
+	if err != nil {
+		return errors.Wrap(err, "Failed to update the MicroShift manifests")
+	}
+	kubeletChanged, err := cluster.ApplyNodeTuning(sshRunner, tuning, cluster.EnsureMicroShiftKubeletConfig)
+	if err != nil {
+		return err
+	}
+	// microshift may already be running when it is enabled in the bundle,
+	// it is restarted to pick up the changes
+	if configChanged || manifestsChanged || kubeletChanged {
+		if _, _, err := sshRunner.RunPrivileged("Restarting microshift service", "systemctl", "restart", "microshift"); err != nil {
+			return err
+		}
+	} else if _, _, err := sshRunner.RunPrivileged("Starting microshift service", "systemctl", "start", "microshift"); err != nil {
 		return err
 	}
 	if err := sshRunner.CopyFileFromVM(fmt.Sprintf("/var/lib/microshift/resources/kubeadmin/api%s/kubeconfig", constants.ClusterDomain), constants.KubeconfigFilePath, 0600); err != nil {
@@ -67,15 +67,61 @@ func (client *client) Status() (*types.ClusterStatusResult, error) {
 		clusterStatusResult.PersistentVolumeUse, clusterStatusResult.PersistentVolumeSize = client.getPVCSize(vm)
 	case vm.bundle.IsOpenShift():
 		clusterStatusResult.OpenshiftStatus = getOpenShiftStatus(context.Background(), ip)
+		clusterStatusResult.ExpiringCertificates = client.getExpiringCertificates(vm)
+		clusterStatusResult.EnabledCapabilities, clusterStatusResult.DisabledCapabilities = getCapabilities(context.Background(), ip)
 	}
 
 	ramSize, ramUse := client.getRAMStatus(vm)
 	clusterStatusResult.RAMSize = ramSize
 	clusterStatusResult.RAMUse = ramUse
 
+	resources, err := client.runningResources(vm)
+	if err != nil {
+		logging.Debugf("Cannot get the resources of the running VM: %v", err)
+	} else {
+		clusterStatusResult.PendingChanges = pendingChanges(resources,
+			client.config.Get(config.CPUs).AsUInt(), client.config.Get(config.Memory).AsUInt())
+	}
+
 	return clusterStatusResult, nil
 }
 
+// GetClusterOperatorsStatus returns the condition of each cluster operator,
+// or of the node and pods for MicroShift
+func (client *client) GetClusterOperatorsStatus() (*types.ClusterOperatorsResult, error) {
+	vm, err := loadVirtualMachine(client.name, client.useVSock())
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("Cannot load '%s' virtual machine", client.name))
+	}
+	defer vm.Close()
+
+	vmStatus, err := vm.State()
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot get machine state")
+	}
+	if vmStatus != state.Running {
+		return nil, errors.New("CRC VM is not running")
+	}
+
+	ip, err := vm.IP()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting ip")
+	}
+
+	result := &types.ClusterOperatorsResult{}
+	if vm.bundle.IsMicroshift() {
+		result.Preset = preset.Microshift
+		result.Operators, err = cluster.GetMicroShiftConditions(context.Background(), ip, constants.KubeconfigFilePath)
+	} else {
+		result.Preset = preset.OpenShift
+		result.Operators, err = cluster.GetClusterOperatorsConditions(context.Background(), ip, constants.KubeconfigFilePath)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "Cannot get the status of the cluster operators")
+	}
+	return result, nil
+}
+
 func (client *client) GetClusterLoad() (*types.ClusterLoadResult, error) {
 	vm, err := loadVirtualMachine(client.name, client.useVSock())
 	if err != nil {
@@ -141,6 +187,15 @@ func getOpenShiftStatus(ctx context.Context, ip string) types.OpenshiftStatus {
 	return getStatus(status)
 }
 
+func getCapabilities(ctx context.Context, ip string) ([]string, []string) {
+	enabled, disabled, err := cluster.GetClusterCapabilities(ctx, ip, constants.KubeconfigFilePath)
+	if err != nil {
+		logging.Debugf("cannot get the cluster capabilities: %v", err)
+		return nil, nil
+	}
+	return enabled, disabled
+}
+
 func getMicroShiftStatus(ctx context.Context, ip string) types.OpenshiftStatus {
 	status, err := cluster.GetClusterNodeStatus(ctx, ip, constants.KubeconfigFilePath)
 	if err != nil {
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
@@ -151,6 +153,10 @@ func (s *Synchronized) Exists() (bool, error) {
 	return s.underlying.Exists()
 }
 
+func (s *Synchronized) GetBundleName() (string, error) {
+	return s.underlying.GetBundleName()
+}
+
 func (s *Synchronized) GetConsoleURL() (*types.ConsoleResult, error) {
 	return s.underlying.GetConsoleURL()
 }
@@ -182,6 +188,95 @@ func (s *Synchronized) Status() (*types.ClusterStatusResult, error) {
 	}
 }
 
+func (s *Synchronized) ApplyResources() (*types.ApplyResourcesResult, error) {
+	if s.CurrentState() != Idle {
+		return nil, errors.New("cannot change the resources of the VM while it is starting, stopping or being deleted")
+	}
+	return s.underlying.ApplyResources()
+}
+
+func (s *Synchronized) GetClusterOperatorsStatus() (*types.ClusterOperatorsResult, error) {
+	return s.underlying.GetClusterOperatorsStatus()
+}
+
+func (s *Synchronized) GetCertificatesStatus() (*types.CertificatesResult, error) {
+	return s.underlying.GetCertificatesStatus()
+}
+
+func (s *Synchronized) RenewCertificates(ctx context.Context) error {
+	if s.CurrentState() != Idle {
+		return errors.New("cannot renew the certificates while the VM is starting, stopping or being deleted")
+	}
+	return s.underlying.RenewCertificates(ctx)
+}
+
+func (s *Synchronized) ListUsers() ([]types.User, error) {
+	return s.underlying.ListUsers()
+}
+
+func (s *Synchronized) AddUser(username, password string, clusterRoles []string) error {
+	return s.underlying.AddUser(username, password, clusterRoles)
+}
+
+func (s *Synchronized) SetUserPassword(username, password string) error {
+	return s.underlying.SetUserPassword(username, password)
+}
+
+func (s *Synchronized) RemoveUser(username string) error {
+	return s.underlying.RemoveUser(username)
+}
+
+func (s *Synchronized) InstallOperator(ctx context.Context, operator crcConfig.Operator) (string, error) {
+	if s.CurrentState() != Idle {
+		return "", errors.New("cannot install an operator while the VM is starting, stopping or being deleted")
+	}
+	return s.underlying.InstallOperator(ctx, operator)
+}
+
+func (s *Synchronized) ExportKubeconfig(username string) ([]byte, error) {
+	return s.underlying.ExportKubeconfig(username)
+}
+
+func (s *Synchronized) MergeKubeconfig(kubeconfig string) error {
+	return s.underlying.MergeKubeconfig(kubeconfig)
+}
+
+func (s *Synchronized) RemoveKubeconfig(kubeconfig string) error {
+	return s.underlying.RemoveKubeconfig(kubeconfig)
+}
+
+func (s *Synchronized) GetPullSecretRegistries() ([]cluster.PullSecretRegistry, error) {
+	return s.underlying.GetPullSecretRegistries()
+}
+
+func (s *Synchronized) AddPullSecretRegistry(registry, username, password string) error {
+	return s.underlying.AddPullSecretRegistry(registry, username, password)
+}
+
+func (s *Synchronized) RemovePullSecretRegistry(registry string) error {
+	return s.underlying.RemovePullSecretRegistry(registry)
+}
+
+func (s *Synchronized) LoadImages(images []string) error {
+	return s.underlying.LoadImages(images)
+}
+
+func (s *Synchronized) ListImages() ([]types.Image, error) {
+	return s.underlying.ListImages()
+}
+
+func (s *Synchronized) ExposeRegistry() (string, error) {
+	return s.underlying.ExposeRegistry()
+}
+
+func (s *Synchronized) RegistryLogin(username string) (string, error) {
+	return s.underlying.RegistryLogin(username)
+}
+
+func (s *Synchronized) RegistryPush(image, destination, username string) (string, error) {
+	return s.underlying.RegistryPush(image, destination, username)
+}
+
 func (s *Synchronized) GetClusterLoad() (*types.ClusterLoadResult, error) {
 	return s.underlying.GetClusterLoad()
 }
@@ -6,6 +6,8 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/state"
 	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
 	crcPreset "github.com/crc-org/crc/v2/pkg/crc/preset"
@@ -175,6 +177,90 @@ func (m *waitingMachine) GetPreset() crcPreset.Preset {
 	return crcPreset.OpenShift
 }
 
+func (m *waitingMachine) GetBundleName() (string, error) {
+	return "", errors.New("not implemented")
+}
+
 func (m *waitingMachine) GetClusterLoad() (*types.ClusterLoadResult, error) {
 	return nil, errors.New("not implemented")
 }
+
+func (m *waitingMachine) ApplyResources() (*types.ApplyResourcesResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) GetClusterOperatorsStatus() (*types.ClusterOperatorsResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) GetCertificatesStatus() (*types.CertificatesResult, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) RenewCertificates(_ context.Context) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) ListUsers() ([]types.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) AddUser(_, _ string, _ []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) SetUserPassword(_, _ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) RemoveUser(_ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) ExportKubeconfig(_ string) ([]byte, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) MergeKubeconfig(_ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) RemoveKubeconfig(_ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) GetPullSecretRegistries() ([]cluster.PullSecretRegistry, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) AddPullSecretRegistry(_, _, _ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) RemovePullSecretRegistry(_ string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) LoadImages(_ []string) error {
+	return errors.New("not implemented")
+}
+
+func (m *waitingMachine) ListImages() ([]types.Image, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (m *waitingMachine) ExposeRegistry() (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *waitingMachine) RegistryLogin(_ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *waitingMachine) RegistryPush(_, _, _ string) (string, error) {
+	return "", errors.New("not implemented")
+}
+
+func (m *waitingMachine) InstallOperator(_ context.Context, _ crcConfig.Operator) (string, error) {
+	return "", errors.New("not implemented")
+}
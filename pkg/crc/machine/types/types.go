@@ -17,6 +17,10 @@ type StartConfig struct {
 	CPUs     uint
 	DiskSize uint // Disk size in GiB
 
+	// Resources which can be given to the running VM without a restart
+	MaxCPUs   uint
+	MaxMemory uint // Memory size in MiB
+
 	// Nameserver
 	NameServer string
 
@@ -46,6 +50,11 @@ type StartConfig struct {
 
 	// Enable bundle quay fallback
 	EnableBundleQuayFallback bool
+
+	// Public keys trusted to sign bundles
+	BundleTrustedKeys []string
+	// Refuse unsigned bundles
+	RequireSignedBundles bool
 }
 
 type ClusterConfig struct {
@@ -82,6 +91,47 @@ type ClusterStatusResult struct {
 	PersistentVolumeUse  int
 	PersistentVolumeSize int
 	Preset               crcpreset.Preset
+	PendingChanges       []PendingChange
+	ExpiringCertificates []cluster.CertificateStatus
+	EnabledCapabilities  []string
+	DisabledCapabilities []string
+}
+
+// PendingChange is a configuration property whose value is only applied
+// when the VM is restarted
+type PendingChange struct {
+	Property   string
+	Current    uint
+	Configured uint
+}
+
+type ApplyResourcesResult struct {
+	Running bool
+	// Applied are the properties which were applied to the running VM
+	Applied []string
+	Pending []PendingChange
+}
+
+type ClusterOperatorsResult struct {
+	Preset    crcpreset.Preset
+	Operators []cluster.OperatorCondition
+}
+
+// User is a user of the htpasswd identity provider, with its kubeconfig context
+type User struct {
+	Name    string `json:"name"`
+	Context string `json:"context,omitempty"`
+}
+
+// Image is a container image stored in the instance
+type Image struct {
+	ID   string   `json:"id"`
+	Tags []string `json:"tags,omitempty"`
+	Size uint64   `json:"size"`
+}
+
+type CertificatesResult struct {
+	Certificates []cluster.CertificateStatus
 }
 
 type ClusterLoadResult struct {
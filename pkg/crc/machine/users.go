@@ -0,0 +1,108 @@
+package machine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/crc-org/crc/v2/pkg/crc/cluster"
+	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
+	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/types"
+	"github.com/crc-org/crc/v2/pkg/crc/oc"
+	"github.com/pkg/errors"
+)
+
+// withOpenShiftCluster runs fn with an oc configuration using the running OpenShift VM
+func (client *client) withOpenShiftCluster(fn func(vm *virtualMachine, ocConfig oc.Config) error) error {
+	vm, err := client.loadRunningOpenShiftVM()
+	if err != nil {
+		return err
+	}
+	defer vm.Close()
+
+	sshRunner, err := vm.SSHRunner()
+	if err != nil {
+		return errors.Wrap(err, "Error creating the ssh client")
+	}
+	defer sshRunner.Close()
+
+	return fn(vm, oc.UseOCWithSSH(sshRunner))
+}
+
+func (client *client) ListUsers() ([]types.User, error) {
+	var users []types.User
+	err := client.withOpenShiftCluster(func(_ *virtualMachine, ocConfig oc.Config) error {
+		names, err := cluster.ListUsers(ocConfig)
+		if err != nil {
+			return err
+		}
+		_, cfg, err := getGlobalKubeConfig()
+		if err != nil {
+			return err
+		}
+		contexts := client.kubeconfigContexts()
+		for _, name := range names {
+			user := types.User{Name: name}
+			switch name {
+			case "kubeadmin":
+				user.Context = contexts.admin
+			case "developer":
+				user.Context = contexts.developer
+			default:
+				user.Context = userContext(name)
+			}
+			if _, ok := cfg.Contexts[user.Context]; !ok {
+				user.Context = ""
+			}
+			users = append(users, user)
+		}
+		return nil
+	})
+	return users, err
+}
+
+func (client *client) AddUser(username, password string, clusterRoles []string) error {
+	if context, contexts := userContext(username), client.kubeconfigContexts(); context == contexts.admin || context == contexts.developer {
+		return fmt.Errorf("user %s would conflict with the %s kubeconfig context", username, context)
+	}
+	return client.withOpenShiftCluster(func(vm *virtualMachine, ocConfig oc.Config) error {
+		if err := cluster.AddUser(context.Background(), ocConfig, username, password, clusterRoles); err != nil {
+			return err
+		}
+		return client.writeUserContext(vm, username, password)
+	})
+}
+
+func (client *client) SetUserPassword(username, password string) error {
+	return client.withOpenShiftCluster(func(vm *virtualMachine, ocConfig oc.Config) error {
+		if err := cluster.SetUserPassword(context.Background(), ocConfig, username, password); err != nil {
+			return err
+		}
+		return client.writeUserContext(vm, username, password)
+	})
+}
+
+func (client *client) RemoveUser(username string) error {
+	return client.withOpenShiftCluster(func(_ *virtualMachine, ocConfig oc.Config) error {
+		if err := cluster.RemoveUser(context.Background(), ocConfig, username); err != nil {
+			return err
+		}
+		return removeUserContext(username)
+	})
+}
+
+func (client *client) writeUserContext(vm *virtualMachine, username, password string) error {
+	clusterConfig, err := getClusterConfig(vm.bundle)
+	if err != nil {
+		return errors.Wrap(err, "Cannot get cluster configuration")
+	}
+	ip, err := vm.IP()
+	if err != nil {
+		return errors.Wrap(err, "Error getting the IP")
+	}
+	logging.Infof("Adding %s context to kubeconfig...", userContext(username))
+	if err := writeUserContext(ip, clusterConfig, client.config.Get(crcConfig.IngressHTTPSPort).AsUInt(), username, password); err != nil {
+		return errors.Wrap(err, "Cannot update kubeconfig")
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	crcConfig "github.com/crc-org/crc/v2/pkg/crc/config"
 	"github.com/crc-org/crc/v2/pkg/crc/errors"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
+	"github.com/crc-org/crc/v2/pkg/crc/machine/bundle"
 )
 
 type Flags uint32
@@ -156,9 +157,13 @@ func getPreflightChecksHelper(config crcConfig.Storage) []Check {
 	mode := crcConfig.GetNetworkMode(config)
 	bundlePath := config.Get(crcConfig.Bundle).AsString()
 	preset := crcConfig.GetPreset(config)
-	enableBundleQuayFallback := config.Get(crcConfig.EnableBundleQuayFallback).AsBool()
+	downloadOptions := bundle.DownloadOptions{
+		EnableQuayFallback: config.Get(crcConfig.EnableBundleQuayFallback).AsBool(),
+		TrustedKeyFiles:    crcConfig.GetBundleTrustedKeys(config),
+		RequireSignature:   config.Get(crcConfig.RequireSignedBundles).AsBool(),
+	}
 	logging.Infof("Using bundle path %s", bundlePath)
-	return getPreflightChecks(experimentalFeatures, mode, bundlePath, preset, enableBundleQuayFallback)
+	return getPreflightChecks(experimentalFeatures, mode, bundlePath, preset, downloadOptions)
 }
 
 // StartPreflightChecks performs the preflight checks before starting the cluster
@@ -174,6 +179,39 @@ func SetupHost(config crcConfig.Storage, checkOnly bool) error {
 	return doFixPreflightChecks(config, getPreflightChecksHelper(config), checkOnly)
 }
 
+// CheckResult is the outcome of a single preflight check
+type CheckResult struct {
+	Description string `json:"description"`
+	Skipped     bool   `json:"skipped,omitempty"`
+	Error       string `json:"error,omitempty"`
+}
+
+func doRunChecks(config crcConfig.Storage, checks []Check) []CheckResult {
+	var results []CheckResult
+	for _, check := range checks {
+		if check.flags&CleanUpOnly == CleanUpOnly {
+			continue
+		}
+		result := CheckResult{
+			Description: check.checkDescription,
+			Skipped:     check.shouldSkip(config),
+		}
+		if !result.Skipped {
+			if err := check.check(); err != nil {
+				result.Error = err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// RunChecks runs the checks of 'crc setup' and 'crc start' without fixing
+// anything, and does not stop at the first failing check
+func RunChecks(config crcConfig.Storage) []CheckResult {
+	return doRunChecks(config, getPreflightChecksHelper(config))
+}
+
 func RegisterSettings(config crcConfig.Schema) {
 	doRegisterSettings(config, getAllPreflightChecks())
 }
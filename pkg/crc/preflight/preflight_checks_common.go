@@ -18,13 +18,13 @@ import (
 	"github.com/pkg/errors"
 )
 
-func bundleCheck(bundlePath string, preset crcpreset.Preset, enableBundleQuayFallback bool) Check {
+func bundleCheck(bundlePath string, preset crcpreset.Preset, downloadOptions bundle.DownloadOptions) Check {
 	return Check{
 		configKeySuffix:  "check-bundle-extracted",
 		checkDescription: "Checking if CRC bundle is extracted in '$HOME/.crc'",
-		check:            checkBundleExtracted(bundlePath),
+		check:            checkBundleExtracted(bundlePath, downloadOptions.RequireSignature),
 		fixDescription:   "Getting bundle for the CRC executable",
-		fix:              fixBundleExtracted(bundlePath, preset, enableBundleQuayFallback),
+		fix:              fixBundleExtracted(bundlePath, preset, downloadOptions),
 		flags:            SetupOnly,
 
 		labels: None,
@@ -83,7 +83,7 @@ var genericCleanupChecks = []Check{
 	},
 }
 
-func checkBundleExtracted(bundlePath string) func() error {
+func checkBundleExtracted(bundlePath string, requireSignature bool) func() error {
 	return func() error {
 		logging.Infof("Checking if %s exists", bundlePath)
 		bundleName := bundle.GetBundleNameFromURI(bundlePath)
@@ -91,12 +91,15 @@ func checkBundleExtracted(bundlePath string) func() error {
 			logging.Debugf("error getting bundle info for %s: %v", bundleName, err)
 			return err
 		}
+		if requireSignature && !bundle.IsVerified(bundleName) {
+			return fmt.Errorf("%s was not verified when it was extracted", bundleName)
+		}
 		logging.Debugf("%s exists", bundlePath)
 		return nil
 	}
 }
 
-func fixBundleExtracted(bundlePath string, preset crcpreset.Preset, enableBundleQuayFallback bool) func() error {
+func fixBundleExtracted(bundlePath string, preset crcpreset.Preset, downloadOptions bundle.DownloadOptions) func() error {
 	// Should be removed after 1.19 release
 	// This check will ensure correct mode for `~/.crc/cache` directory
 	// in case it exists.
@@ -110,14 +113,8 @@ func fixBundleExtracted(bundlePath string, preset crcpreset.Preset, enableBundle
 		if err := os.MkdirAll(bundleDir, 0775); err != nil {
 			return fmt.Errorf("Cannot create directory %s: %v", bundleDir, err)
 		}
-		var err error
-		logging.Infof("Downloading bundle: %s...", bundlePath)
-		if bundlePath, err = bundle.Download(preset, bundlePath, enableBundleQuayFallback); err != nil {
-			return err
-		}
-
-		logging.Infof("Uncompressing %s", bundlePath)
-		if _, err := bundle.Extract(bundlePath); err != nil {
+		logging.Infof("Downloading and extracting bundle: %s...", bundlePath)
+		if _, err := bundle.DownloadAndExtract(preset, bundlePath, downloadOptions); err != nil {
 			if errors.Is(err, os.ErrNotExist) {
 				return errors.Wrap(err, "Use `crc setup -b <bundle-path>`")
 			}
@@ -49,6 +49,20 @@ func TestFixPreflightCheckOnly(t *testing.T) {
 	assert.False(t, calls.fixed)
 }
 
+func TestRunChecks(t *testing.T) {
+	check, calls := sampleCheck(errors.New("check failed"), nil)
+	cfg := config.New(config.NewEmptyInMemoryStorage(), config.NewEmptyInMemorySecretStorage())
+	doRegisterSettings(cfg, []Check{*check})
+
+	assert.Equal(t, []CheckResult{{Description: "Sample check", Error: "check failed"}}, doRunChecks(cfg, []Check{*check}))
+	assert.True(t, calls.checked)
+	assert.False(t, calls.fixed)
+
+	_, err := cfg.Set("skip-sample", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []CheckResult{{Description: "Sample check", Skipped: true}}, doRunChecks(cfg, []Check{*check}))
+}
+
 func sampleCheck(checkErr, fixErr error) (*Check, *status) {
 	status := &status{}
 	return &Check{
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"strconv"
@@ -15,6 +16,7 @@ import (
 
 type Client interface {
 	Run(command string) ([]byte, []byte, error)
+	RunWithStdin(command string, stdin io.Reader) ([]byte, []byte, error)
 	Close()
 }
 
@@ -92,6 +94,11 @@ func (client *NativeClient) session() (*ssh.Session, error) {
 }
 
 func (client *NativeClient) Run(command string) ([]byte, []byte, error) {
+	return client.RunWithStdin(command, nil)
+}
+
+// RunWithStdin runs command with stdin as its standard input
+func (client *NativeClient) RunWithStdin(command string, stdin io.Reader) ([]byte, []byte, error) {
 	session, err := client.session()
 	if err != nil {
 		if client.conn != nil {
@@ -107,6 +114,7 @@ func (client *NativeClient) Run(command string) ([]byte, []byte, error) {
 		stdout bytes.Buffer
 		stderr bytes.Buffer
 	)
+	session.Stdin = stdin
 	session.Stdout = &stdout
 	session.Stderr = &stderr
 
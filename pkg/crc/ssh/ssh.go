@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"time"
@@ -35,20 +36,20 @@ func (runner *Runner) Run(cmd string, args ...string) (string, string, error) {
 	if len(args) != 0 {
 		cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
 	}
-	return runner.runSSHCommand(cmd, false)
+	return runner.runSSHCommand(cmd, nil, false)
 }
 
 func (runner *Runner) RunPrivate(cmd string, args ...string) (string, string, error) {
 	if len(args) != 0 {
 		cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
 	}
-	return runner.runSSHCommand(cmd, true)
+	return runner.runSSHCommand(cmd, nil, true)
 }
 
 func (runner *Runner) RunPrivileged(reason string, cmdAndArgs ...string) (string, string, error) {
 	logging.Debugf("Using root access: %s", reason)
 	commandline := fmt.Sprintf("sudo %s", strings.Join(cmdAndArgs, " "))
-	return runner.runSSHCommand(commandline, false)
+	return runner.runSSHCommand(commandline, nil, false)
 }
 
 func (runner *Runner) copyDataFull(data []byte, destFilename string, mode os.FileMode, privileged bool) error {
@@ -93,14 +94,23 @@ func (runner *Runner) CopyFile(srcFilename string, destFilename string, mode os.
 	return runner.CopyDataPrivileged(data, destFilename, mode)
 }
 
-func (runner *Runner) runSSHCommand(command string, runPrivate bool) (string, string, error) {
+// RunWithStdin runs the command with the content of stdin as its standard
+// input, stdin is streamed, it can be larger than the memory
+func (runner *Runner) RunWithStdin(stdin io.Reader, cmd string, args ...string) (string, string, error) {
+	if len(args) != 0 {
+		cmd = fmt.Sprintf("%s %s", cmd, strings.Join(args, " "))
+	}
+	return runner.runSSHCommand(cmd, stdin, false)
+}
+
+func (runner *Runner) runSSHCommand(command string, stdin io.Reader, runPrivate bool) (string, string, error) {
 	if runPrivate {
 		logging.Debugf("Running SSH command: <hidden>")
 	} else {
 		logging.Debugf("Running SSH command: %s", command)
 	}
 
-	stdout, stderr, err := runner.client.Run(command)
+	stdout, stderr, err := runner.client.RunWithStdin(command, stdin)
 	if runPrivate {
 		if err != nil {
 			logging.Debugf("SSH command failed")
@@ -3,7 +3,6 @@ package systemd
 import (
 	"fmt"
 
-	"github.com/crc-org/crc/v2/pkg/crc/ssh"
 	"github.com/crc-org/crc/v2/pkg/crc/systemd/actions"
 	"github.com/crc-org/crc/v2/pkg/crc/systemd/states"
 	crcos "github.com/crc-org/crc/v2/pkg/os"
@@ -13,7 +12,7 @@ type Commander struct {
 	commandRunner crcos.CommandRunner
 }
 
-func NewInstanceSystemdCommander(sshRunner *ssh.Runner) *Commander {
+func NewInstanceSystemdCommander(sshRunner crcos.CommandRunner) *Commander {
 	return &Commander{
 		commandRunner: sshRunner,
 	}
@@ -8,6 +8,7 @@ import (
 	"net/url"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/crc-org/crc/v2/pkg/crc/constants"
 	"github.com/crc-org/crc/v2/pkg/crc/image"
@@ -164,6 +165,15 @@ func ValidateIPAddress(ipAddress string) error {
 	return nil
 }
 
+// ValidateDuration checks that the value is a positive duration such as '15m'
+func ValidateDuration(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return fmt.Errorf("'%s' is not a positive duration, such as '90s' or '15m'", value)
+	}
+	return nil
+}
+
 func ValidateURL(uri string) error {
 	u, err := url.ParseRequestURI(uri)
 	if err != nil {
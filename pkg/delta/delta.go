@@ -0,0 +1,268 @@
+// Package delta computes and applies binary deltas between two versions of
+// a disk image.
+//
+// The target file is split in fixed size blocks. Each block is either
+// copied from any block-aligned offset of the base file with the same
+// content, recreated as a hole when it only contains zeroes, or stored in
+// the delta. This works well for disk images, as filesystems and qcow2
+// clusters are block-aligned: a block which did not change between two
+// versions is found in the base file even if it moved.
+package delta
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+const (
+	magic = "CRCDELTA"
+	// formatVersion is increased on incompatible changes of the delta format
+	formatVersion = 1
+	// BlockSize matches the default qcow2 cluster size
+	BlockSize = 64 * 1024
+	// maxDataLength bounds the memory used to buffer data operations
+	maxDataLength = 16 * 1024 * 1024
+)
+
+type opKind uint8
+
+const (
+	opEnd opKind = iota
+	// opCopy copies length bytes from offset in the base file
+	opCopy
+	// opData is followed by length bytes of data
+	opData
+	// opZero leaves a hole of length bytes
+	opZero
+)
+
+type header struct {
+	Magic      [8]byte
+	Version    uint32
+	BlockSize  uint32
+	TargetSize uint64
+}
+
+type op struct {
+	Kind   opKind
+	Offset uint64
+	Length uint64
+}
+
+// Stats describes how the target file was encoded in a delta
+type Stats struct {
+	// Copied is the number of bytes which are copied from the base file
+	Copied int64
+	// Data is the number of bytes which are stored in the delta
+	Data int64
+	// Zero is the number of bytes which are recreated as holes
+	Zero int64
+}
+
+var zeroBlock = make([]byte, BlockSize)
+
+// Diff writes to out the delta needed to recreate target from base.
+func Diff(base, target *os.File, out io.Writer) (Stats, error) {
+	var stats Stats
+
+	index, err := indexBlocks(base)
+	if err != nil {
+		return stats, err
+	}
+	targetInfo, err := target.Stat()
+	if err != nil {
+		return stats, err
+	}
+
+	writer := bufio.NewWriter(out)
+	h := header{
+		Version:    formatVersion,
+		BlockSize:  BlockSize,
+		TargetSize: uint64(targetInfo.Size()),
+	}
+	copy(h.Magic[:], magic)
+	if err := binary.Write(writer, binary.BigEndian, &h); err != nil {
+		return stats, err
+	}
+
+	// consecutive blocks of the same kind are merged in a single operation
+	var pending op
+	var pendingData bytes.Buffer
+	flush := func() error {
+		if pending.Length == 0 {
+			return nil
+		}
+		if err := binary.Write(writer, binary.BigEndian, &pending); err != nil {
+			return err
+		}
+		if pending.Kind == opData {
+			if _, err := pendingData.WriteTo(writer); err != nil {
+				return err
+			}
+		}
+		pending = op{}
+		return nil
+	}
+
+	reader := bufio.NewReaderSize(target, BlockSize)
+	block := make([]byte, BlockSize)
+	for {
+		n, err := io.ReadFull(reader, block)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return stats, err
+		}
+		block := block[:n]
+
+		next := op{Kind: opData, Length: uint64(n)}
+		if bytes.Equal(block, zeroBlock[:n]) {
+			next.Kind = opZero
+			stats.Zero += int64(n)
+		} else if offset, ok := lookup(index, block); ok {
+			next.Kind = opCopy
+			next.Offset = uint64(offset)
+			stats.Copied += int64(n)
+		} else {
+			stats.Data += int64(n)
+		}
+
+		contiguous := pending.Kind == next.Kind &&
+			(next.Kind != opCopy || pending.Offset+pending.Length == next.Offset)
+		if !contiguous {
+			if err := flush(); err != nil {
+				return stats, err
+			}
+			pending = next
+		} else {
+			pending.Length += next.Length
+		}
+		if next.Kind == opData {
+			pendingData.Write(block)
+			if pendingData.Len() >= maxDataLength {
+				if err := flush(); err != nil {
+					return stats, err
+				}
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return stats, err
+	}
+	if err := binary.Write(writer, binary.BigEndian, &op{Kind: opEnd}); err != nil {
+		return stats, err
+	}
+
+	return stats, writer.Flush()
+}
+
+// indexBlocks returns the offset of each distinct non-zero block of file
+func indexBlocks(file *os.File) (map[[sha256.Size]byte]int64, error) {
+	index := make(map[[sha256.Size]byte]int64)
+	reader := bufio.NewReaderSize(file, BlockSize)
+	block := make([]byte, BlockSize)
+	for offset := int64(0); ; offset += BlockSize {
+		if _, err := io.ReadFull(reader, block); err != nil {
+			if err == io.EOF || err == io.ErrUnexpectedEOF {
+				// a partial last block cannot be used for aligned copies
+				return index, nil
+			}
+			return nil, err
+		}
+		if bytes.Equal(block, zeroBlock) {
+			continue
+		}
+		sum := sha256.Sum256(block)
+		if _, ok := index[sum]; !ok {
+			index[sum] = offset
+		}
+	}
+}
+
+// lookup returns the offset of a block of the base file with the same content as block
+func lookup(index map[[sha256.Size]byte]int64, block []byte) (int64, bool) {
+	if len(block) != BlockSize {
+		return 0, false
+	}
+	offset, ok := index[sha256.Sum256(block)]
+	return offset, ok
+}
+
+// Apply recreates in target the file described by delta, reading unchanged
+// blocks from base. Zero blocks are left as holes in target. It returns the
+// sha256sum of the recreated file so that it can be verified without reading
+// it again.
+func Apply(base io.ReaderAt, delta io.Reader, target *os.File) (string, error) {
+	reader := bufio.NewReader(delta)
+	var h header
+	if err := binary.Read(reader, binary.BigEndian, &h); err != nil {
+		return "", fmt.Errorf("cannot read delta header: %w", err)
+	}
+	if string(h.Magic[:]) != magic {
+		return "", errors.New("invalid delta file")
+	}
+	if h.Version != formatVersion {
+		return "", fmt.Errorf("unsupported delta version %d", h.Version)
+	}
+
+	hash := sha256.New()
+	var written uint64
+	for {
+		var o op
+		if err := binary.Read(reader, binary.BigEndian, &o); err != nil {
+			return "", fmt.Errorf("cannot read delta: %w", err)
+		}
+		if written+o.Length > h.TargetSize {
+			return "", errors.New("delta is larger than the target file")
+		}
+		var src io.Reader
+		switch o.Kind {
+		case opEnd:
+			if written != h.TargetSize {
+				return "", fmt.Errorf("truncated delta: got %d bytes instead of %d", written, h.TargetSize)
+			}
+			if err := target.Truncate(int64(h.TargetSize)); err != nil {
+				return "", err
+			}
+			return hex.EncodeToString(hash.Sum(nil)), nil
+		case opCopy:
+			src = io.NewSectionReader(base, int64(o.Offset), int64(o.Length))
+		case opData:
+			src = io.LimitReader(reader, int64(o.Length))
+		case opZero:
+			if _, err := target.Seek(int64(o.Length), io.SeekCurrent); err != nil {
+				return "", err
+			}
+			if _, err := io.CopyN(hash, zeroReader{}, int64(o.Length)); err != nil {
+				return "", err
+			}
+			written += o.Length
+			continue
+		default:
+			return "", fmt.Errorf("unknown delta operation %d", o.Kind)
+		}
+		n, err := io.Copy(io.MultiWriter(target, hash), src)
+		if err != nil {
+			return "", err
+		}
+		if uint64(n) != o.Length {
+			return "", fmt.Errorf("short read in delta: got %d bytes instead of %d", n, o.Length)
+		}
+		written += o.Length
+	}
+}
+
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
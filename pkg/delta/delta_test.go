@@ -0,0 +1,112 @@
+package delta
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, dir, name string, content []byte) *os.File {
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, content, 0600))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	t.Cleanup(func() { f.Close() })
+	return f
+}
+
+func TestDiffApply(t *testing.T) {
+	dir := t.TempDir()
+	random := rand.New(rand.NewSource(0)) // #nosec G404
+
+	base := make([]byte, 16*BlockSize)
+	_, _ = random.Read(base)
+
+	target := make([]byte, 0, 20*BlockSize+100)
+	// unchanged blocks
+	target = append(target, base[:4*BlockSize]...)
+	// modified block
+	modified := bytes.Clone(base[4*BlockSize : 5*BlockSize])
+	modified[42]++
+	target = append(target, modified...)
+	// zero blocks
+	target = append(target, make([]byte, 3*BlockSize)...)
+	// moved blocks
+	target = append(target, base[10*BlockSize:12*BlockSize]...)
+	target = append(target, base[2*BlockSize:3*BlockSize]...)
+	// new data, including a partial last block
+	extra := make([]byte, 2*BlockSize+100)
+	_, _ = random.Read(extra)
+	target = append(target, extra...)
+
+	baseFile := writeFile(t, dir, "base", base)
+	targetFile := writeFile(t, dir, "target", target)
+
+	var delta bytes.Buffer
+	stats, err := Diff(baseFile, targetFile, &delta)
+	require.NoError(t, err)
+	assert.Equal(t, Stats{
+		Copied: 7 * BlockSize,
+		Data:   3*BlockSize + 100,
+		Zero:   3 * BlockSize,
+	}, stats)
+	assert.Less(t, delta.Len(), 4*BlockSize)
+
+	out, err := os.Create(filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	defer out.Close()
+	sha256sum, err := Apply(baseFile, &delta, out)
+	require.NoError(t, err)
+	require.NoError(t, out.Close())
+
+	expected := sha256.Sum256(target)
+	assert.Equal(t, hex.EncodeToString(expected[:]), sha256sum)
+	reconstructed, err := os.ReadFile(out.Name())
+	require.NoError(t, err)
+	assert.Equal(t, target, reconstructed)
+}
+
+func TestApplyTrailingZeroes(t *testing.T) {
+	dir := t.TempDir()
+	baseFile := writeFile(t, dir, "base", []byte("base"))
+	targetFile := writeFile(t, dir, "target", append([]byte("target"), make([]byte, 3*BlockSize)...))
+
+	var delta bytes.Buffer
+	_, err := Diff(baseFile, targetFile, &delta)
+	require.NoError(t, err)
+
+	out, err := os.Create(filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	defer out.Close()
+	_, err = Apply(baseFile, &delta, out)
+	require.NoError(t, err)
+
+	fi, err := out.Stat()
+	require.NoError(t, err)
+	assert.Equal(t, int64(len("target")+3*BlockSize), fi.Size())
+}
+
+func TestApplyInvalidDelta(t *testing.T) {
+	dir := t.TempDir()
+	baseFile := writeFile(t, dir, "base", []byte("base"))
+	out, err := os.Create(filepath.Join(dir, "out"))
+	require.NoError(t, err)
+	defer out.Close()
+
+	_, err = Apply(baseFile, bytes.NewReader([]byte("not a delta file, really")), out)
+	assert.EqualError(t, err, "invalid delta file")
+
+	targetFile := writeFile(t, dir, "target", []byte("target"))
+	var delta bytes.Buffer
+	_, err = Diff(baseFile, targetFile, &delta)
+	require.NoError(t, err)
+	_, err = Apply(baseFile, bytes.NewReader(delta.Bytes()[:delta.Len()-4]), out)
+	assert.ErrorContains(t, err, "cannot read delta")
+}
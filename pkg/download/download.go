@@ -118,6 +118,31 @@ func InMemory(uri string) (io.ReadCloser, error) {
 	return rsp.Open()
 }
 
+// Stream takes a URL and returns a ReadCloser object to the remote file
+// content. Unlike InMemory, the content is neither stored on disk nor buffered
+// in memory, it is read from the network as the caller reads from the
+// ReadCloser. This is meant for big files which are processed on the fly.
+func Stream(uri string) (io.ReadCloser, error) {
+	logging.Debugf("Streaming %s", uri)
+
+	req, err := http.NewRequest(http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "unable to get request from %s", uri)
+	}
+	req.Header.Set("User-Agent", version.UserAgent())
+
+	client := &http.Client{Transport: httpproxy.HTTPTransport()}
+	rsp, err := client.Do(req) // #nosec G107
+	if err != nil {
+		return nil, err
+	}
+	if rsp.StatusCode != http.StatusOK {
+		rsp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", uri, rsp.Status)
+	}
+	return rsp.Body, nil
+}
+
 type RemoteFile struct {
 	URI       string
 	sha256sum string
@@ -3,6 +3,7 @@ package extract
 import (
 	"archive/tar"
 	"archive/zip"
+	"bufio"
 	"compress/gzip"
 	"fmt"
 	"io"
@@ -56,32 +57,61 @@ func uncompress(tarball, targetDir string, fileFilter func(string) bool, showPro
 		return nil, errors.Wrap(err, "cannot seek file")
 	}
 
+	if filetype.Is(header, "zip") {
+		return unzip(tarball, targetDir, fileFilter, showProgress)
+	}
+	files, err := uncompressReader(file, header, targetDir, fileFilter, showProgress)
+	if errors.Is(err, errUnknownFormat) {
+		return nil, fmt.Errorf("Unknown file format when trying to uncompress %s", tarball)
+	}
+	return files, err
+}
+
+// UncompressStream extracts the archive read from reader to targetDir. The
+// archive format is detected from the first bytes of the stream. Since the
+// archive is never stored on disk, zip archives are not supported.
+func UncompressStream(reader io.Reader, targetDir string) ([]string, error) {
+	logging.Debugf("Uncompressing stream to %s", targetDir)
+
+	bufReader := bufio.NewReader(reader)
+	header, err := bufReader.Peek(262)
+	if err != nil && err != io.EOF {
+		return nil, errors.Wrap(err, "cannot determine type by reading stream header")
+	}
+	if filetype.Is(header, "zip") {
+		return nil, fmt.Errorf("zip archives cannot be uncompressed from a stream")
+	}
+	return uncompressReader(bufReader, header, targetDir, nil, terminal.IsShowTerminalOutput())
+}
+
+var errUnknownFormat = errors.New("Unknown file format")
+
+func uncompressReader(reader io.Reader, header []byte, targetDir string, fileFilter func(string) bool, showProgress bool) ([]string, error) {
 	switch {
 	case filetype.Is(header, "xz"):
-		reader, err := xz.NewReader(file, 0)
+		xzReader, err := xz.NewReader(reader, 0)
 		if err != nil {
 			return nil, err
 		}
-		return untar(reader, targetDir, fileFilter, showProgress)
+		return untar(xzReader, targetDir, fileFilter, showProgress)
 	case filetype.Is(header, "zst"):
-		reader, err := zstd.NewReader(file)
+		zstdReader, err := zstd.NewReader(reader, zstd.WithDecoderConcurrency(0))
 		if err != nil {
 			return nil, err
 		}
-		return untar(reader, targetDir, fileFilter, showProgress)
+		defer zstdReader.Close()
+		return untar(zstdReader, targetDir, fileFilter, showProgress)
 	case filetype.Is(header, "gz"):
-		reader, err := gzip.NewReader(file)
+		gzipReader, err := gzip.NewReader(reader)
 		if err != nil {
 			return nil, err
 		}
-		defer reader.Close()
-		return untar(io.Reader(reader), targetDir, fileFilter, showProgress)
-	case filetype.Is(header, "zip"):
-		return unzip(tarball, targetDir, fileFilter, showProgress)
+		defer gzipReader.Close()
+		return untar(io.Reader(gzipReader), targetDir, fileFilter, showProgress)
 	case filetype.Is(header, "tar"):
-		return untar(file, targetDir, fileFilter, showProgress)
+		return untar(reader, targetDir, fileFilter, showProgress)
 	default:
-		return nil, fmt.Errorf("Unknown file format when trying to uncompress %s", tarball)
+		return nil, errUnknownFormat
 	}
 }
 
@@ -155,6 +185,12 @@ func uncompressFile(tarReader io.Reader, fileInfo os.FileInfo, path string, show
 	}
 	defer file.Close()
 
+	// setting the file size upfront avoids having to write the last
+	// byte of the file when it ends with a hole
+	if err := file.Truncate(fileInfo.Size()); err != nil {
+		return err
+	}
+
 	reader, cleanup := progressBarReader(tarReader, fileInfo, showProgress)
 	defer cleanup()
 
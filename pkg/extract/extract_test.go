@@ -1,11 +1,13 @@
 package extract
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/crc-org/crc/v2/pkg/compress"
 	"github.com/crc-org/crc/v2/pkg/crc/logging"
 	crcos "github.com/crc-org/crc/v2/pkg/os"
 	"github.com/stretchr/testify/assert"
@@ -166,3 +168,27 @@ func testUncompress(t *testing.T, archiveName string, fileFilter func(string) bo
 func fileFilter(filename string) bool {
 	return filepath.Base(filename) == "c.txt"
 }
+
+func BenchmarkUncompressSparse(b *testing.B) {
+	const fileSize = 64 * 1024 * 1024
+	srcDir := filepath.Join(b.TempDir(), "bundle")
+	require.NoError(b, os.MkdirAll(srcDir, 0750))
+	f, err := os.Create(filepath.Join(srcDir, "disk.img"))
+	require.NoError(b, err)
+	require.NoError(b, f.Truncate(fileSize))
+	for offset := int64(0); offset < fileSize; offset += 8 * 1024 * 1024 {
+		_, err := f.WriteAt(bytes.Repeat([]byte{'x'}, 4096), offset)
+		require.NoError(b, err)
+	}
+	require.NoError(b, f.Close())
+
+	archive := filepath.Join(b.TempDir(), "bundle.tar.zst")
+	require.NoError(b, compress.Compress(srcDir, archive))
+
+	b.SetBytes(fileSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := Uncompress(archive, b.TempDir())
+		require.NoError(b, err)
+	}
+}
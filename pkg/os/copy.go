@@ -55,7 +55,7 @@ func CopySparse(dst io.WriteSeeker, src io.Reader) (int64, error) {
 	copyBuf := make([]byte, copyChunkSize)
 	sparseWriter := newSparseWriter(dst)
 
-	bytesWritten, err := io.CopyBuffer(sparseWriter, src, copyBuf)
+	bytesWritten, err := io.CopyBuffer(sparseWriter, fullChunkReader{src}, copyBuf)
 	if err != nil {
 		return bytesWritten, err
 	}
@@ -63,6 +63,22 @@ func CopySparse(dst io.WriteSeeker, src io.Reader) (int64, error) {
 	return bytesWritten, err
 }
 
+// fullChunkReader only returns short reads at the end of the stream. This keeps
+// the chunks passed to sparseWriter aligned on copyChunkSize regardless of how
+// the underlying reader (decompressor, archive reader, ...) splits its data,
+// which is needed for the empty chunk detection to find all the holes.
+type fullChunkReader struct {
+	reader io.Reader
+}
+
+func (r fullChunkReader) Read(p []byte) (int, error) {
+	n, err := io.ReadFull(r.reader, p)
+	if err == io.ErrUnexpectedEOF {
+		return n, io.EOF
+	}
+	return n, err
+}
+
 type sparseWriter struct {
 	writer          io.WriteSeeker
 	lastChunkSparse bool
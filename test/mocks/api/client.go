@@ -145,6 +145,27 @@ func (_m *Client) Status() (client.ClusterStatusResult, error) {
 	return r0, r1
 }
 
+// StatusOperators provides a mock function with given fields:
+func (_m *Client) StatusOperators() (client.ClusterOperatorsResult, error) {
+	ret := _m.Called()
+
+	var r0 client.ClusterOperatorsResult
+	if rf, ok := ret.Get(0).(func() client.ClusterOperatorsResult); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(client.ClusterOperatorsResult)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Stop provides a mock function with given fields:
 func (_m *Client) Stop() error {
 	ret := _m.Called()